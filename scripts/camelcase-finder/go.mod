@@ -1,3 +1,9 @@
 module camelcase-finder
 
-go 1.20 
\ No newline at end of file
+go 1.24.1
+
+require rename-script v0.0.0
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
+
+replace rename-script => ../rename
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"rename-script/renamer"
+)
+
+// barrels サブコマンド
+// update: 設定ファイルのディレクトリのバレル（index.ts）を生成または更新する
+// check: 更新が必要なバレルと存在しないファイルを指す再エクスポートがある場合は終了コード 1 を返す
+func runBarrels(args []string) int {
+	fs := flag.NewFlagSet("barrels", flag.ExitOnError)
+	debug := fs.Bool("debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	configPath := fs.String("config", "", "バレルの設定ファイルのパス（デフォルト: scripts/rename/barrels.yaml）")
+	dryRun := fs.Bool("dry-run", false, "ドライラン（ファイルを変更せず、変更予定のみ表示）")
+	diff := fs.Bool("diff", false, "変更予定を git apply で適用できる unified diff で出力する（--dry-run を含む）")
+	force := fs.Bool("force", false, "コミットされていない変更があっても実行する")
+	noVerify := fs.Bool("no-verify", false, "実行後のインポートの検証を行わない")
+	rollback := fs.Bool("rollback", false, "検証で解決できないインポートが見つかった場合に確認せずに元に戻す")
+	var report reportFlags
+	report.register(fs)
+
+	positional := parseArgs(fs, args)
+	if len(positional) != 1 || (positional[0] != "update" && positional[0] != "check") {
+		fmt.Println("使い方: rename-script barrels <update|check> [オプション]")
+		return 1
+	}
+	check := positional[0] == "check"
+	if *diff && report.enabled() {
+		fmt.Println("--diff と --format は同時に指定できません")
+		return 1
+	}
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	var diffOutput *os.File
+	if *diff && !check {
+		*dryRun = true
+		diffOutput = redirectStdout()
+	}
+
+	engine, err := newEngine(*debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if *configPath == "" {
+		*configPath = renamer.BarrelConfigPath(engine.Root())
+	}
+	config, err := renamer.LoadBarrelConfig(engine.FS(), *configPath)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if config == nil {
+		fmt.Printf("バレルの設定ファイルが見つかりません: %s\n", *configPath)
+		return 1
+	}
+
+	if check {
+		results, err := engine.CheckBarrels(config)
+		if err != nil {
+			fmt.Printf("バレルの検査に失敗しました: %v\n", err)
+			return 1
+		}
+		barrelReport := engine.NewBarrelReport(true, results)
+		if err := report.write(barrelReport); err != nil {
+			fmt.Printf("%v\n", err)
+			return 1
+		}
+		fmt.Printf("\n=== バレル ===\n")
+		fmt.Printf("検査したバレル: %d 件（%s）\n", len(results), *configPath)
+		if len(barrelReport.Barrels) == 0 {
+			fmt.Printf("%s更新が必要なバレルはありません%s\n", colorGreen, colorReset)
+			return 0
+		}
+		printBarrels(barrelReport.Barrels, true)
+		return 1
+	}
+
+	if !*dryRun {
+		if err := checkWorkingTree(engine, *force); err != nil {
+			fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+			return 1
+		}
+	}
+	engine.SetDryRun(*dryRun)
+	results, run, err := engine.UpdateBarrels(config)
+	journalPath := saveJournal(engine, run)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+		return 1
+	}
+	if *diff && writeDiff(diffOutput, engine, run) != nil {
+		return 1
+	}
+	if err := report.write(engine.NewBarrelReport(false, results)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	if *dryRun {
+		fmt.Printf("\n更新する予定のバレル: %d 件（ドライランのため、ファイルは変更されていません）\n", len(results))
+	} else {
+		fmt.Printf("\n%s更新したバレル: %d 件%s\n", colorGreen, len(results), colorReset)
+	}
+	printBarrels(results, false)
+
+	if *noVerify || len(results) == 0 {
+		return 0
+	}
+	mode := rollbackPrompt
+	if *rollback {
+		mode = rollbackAuto
+	}
+	return verifyRun(engine, run, nil, journalPath, mode, *debug)
+}
+
+// バレルごとに追加・削除する再エクスポートと、存在しないファイルを指す再エクスポートを表示
+func printBarrels(results []renamer.BarrelResult, check bool) {
+	for _, result := range results {
+		switch {
+		case check && result.Outdated:
+			fmt.Printf("  %s%s（更新が必要です）%s\n", colorYellow, result.File, colorReset)
+		case result.Created:
+			fmt.Printf("  %s（新規作成）\n", result.File)
+		default:
+			fmt.Printf("  %s\n", result.File)
+		}
+		for _, spec := range result.Added {
+			fmt.Printf("      + %s\n", spec)
+		}
+		for _, spec := range result.Removed {
+			fmt.Printf("      - %s\n", spec)
+		}
+		for _, missing := range result.Missing {
+			fmt.Printf("      %s%s: 存在しないファイルを指しています%s\n", colorRed, missing, colorReset)
+		}
+	}
+}
+
+// バレルの設定ファイルがある場合は、リネームと移動の後にバレルを更新するよう設定する
+func loadBarrels(engine *renamer.Engine) error {
+	config, err := renamer.LoadBarrelConfig(engine.FS(), renamer.BarrelConfigPath(engine.Root()))
+	if err != nil {
+		return err
+	}
+	engine.SetBarrels(config)
+	return nil
+}
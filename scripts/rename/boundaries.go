@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"rename-script/renamer"
+)
+
+// boundaries サブコマンド
+// 境界の違反がある場合は終了コード 1 を返す
+func runBoundaries(args []string) int {
+	fs := flag.NewFlagSet("boundaries", flag.ExitOnError)
+	debug := fs.Bool("debug", false, "デバッグモードで実行")
+	rulesPath := fs.String("rules", "", "境界の規則ファイルのパス（デフォルト: scripts/rename/boundaries.yaml）")
+	var report reportFlags
+	report.register(fs)
+	fs.Parse(args)
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	engine, err := newEngine(*debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if !*debug {
+		engine = engine.WithOutput(io.Discard)
+	}
+
+	if *rulesPath == "" {
+		*rulesPath = renamer.BoundaryRulesPath(engine.Root())
+	}
+	rules, err := renamer.LoadBoundaryRules(engine.FS(), *rulesPath)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	violations, err := engine.CheckBoundaries(rules)
+	if err != nil {
+		fmt.Printf("ワークスペースの境界の検査に失敗しました: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("\n=== ワークスペースの境界 ===\n")
+	if rules == nil {
+		fmt.Printf("%s規則ファイルがないため、tsconfig の include だけを検査しました: %s%s\n", colorYellow, *rulesPath, colorReset)
+	} else {
+		fmt.Printf("規則: %d 件（%s）\n", len(rules.Rules), *rulesPath)
+		if *debug {
+			for _, rule := range rules.Rules {
+				fmt.Printf("  %s\n", rule)
+			}
+		}
+	}
+
+	var imports, includes []renamer.BoundaryViolation
+	for _, violation := range violations {
+		if violation.Kind == renamer.BoundaryInclude {
+			includes = append(includes, violation)
+		} else {
+			imports = append(imports, violation)
+		}
+	}
+	if len(violations) == 0 {
+		fmt.Printf("%sワークスペースの境界の違反はありません%s\n", colorGreen, colorReset)
+	}
+	if len(imports) > 0 {
+		fmt.Printf("\n%s許可されていないワークスペースのインポート: %d 件%s\n", colorRed, len(imports), colorReset)
+		for _, violation := range imports {
+			fmt.Printf("  %s:%d: '%s' → %s\n", violation.File, violation.Line, violation.Specifier, violation.Target)
+			fmt.Printf("      %s → %s（規則: %s）\n", violation.FromWorkspace, violation.ToWorkspace, violation.Rule)
+		}
+	}
+	if len(includes) > 0 {
+		fmt.Printf("\n%sワークスペースの外を指す tsconfig の include: %d 件%s\n", colorRed, len(includes), colorReset)
+		for _, violation := range includes {
+			fmt.Printf("  %s: '%s' → %s（%s）\n", violation.File, violation.Specifier, violation.Target, violation.ToWorkspace)
+		}
+		fmt.Println("\nほかのワークスペースのファイルは include せず、package.json の依存と exports を通してインポートしてください。")
+	}
+
+	if err := report.write(engine.NewBoundaryReport(violations)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if len(violations) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"rename-script/renamer"
+)
+
+// check サブコマンドの終了コード
+const (
+	checkExitOK        = 0
+	checkExitViolation = 1
+	checkExitError     = 2
+)
+
+// pre-commit フックであることを示す目印
+const hookMarker = "# rename-script check"
+
+// check サブコマンド
+func runCheck(args []string) int {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	var flags commonFlags
+	flags.register(fs)
+	flags.registerFilter(fs)
+	staged := fs.Bool("staged", false, "ステージされたファイルだけを検査する（pre-commit フック用）")
+	installHook := fs.Bool("install-hook", false, "ステージされたファイルを検査する git の pre-commit フックをインストールする")
+	force := fs.Bool("force", false, "既存の pre-commit フックを上書きする（--install-hook と併用）")
+	writeBaseline := fs.Bool("write-baseline", false, "現在の違反をすべてベースラインに記録する")
+	baselinePath := fs.String("baseline", "", "ベースラインファイルのパス（デフォルト: scripts/rename/baseline.json）")
+	cycles := fs.Bool("cycles", false, "循環しているインポートも検査する（除外設定の cycles.check と同じ）")
+	ignoreTypeImports := fs.Bool("ignore-type-imports", false, "循環の検出で型のみのインポートを無視する（--cycles を含む）")
+	var report reportFlags
+	report.register(fs)
+	fs.Parse(args)
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return checkExitError
+	}
+
+	engine, err := newEngine(flags.debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return checkExitError
+	}
+	if *installHook {
+		if err := installPreCommitHook(engine, *force); err != nil {
+			fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+			return checkExitError
+		}
+		return checkExitOK
+	}
+	if !flags.debug {
+		engine = engine.WithOutput(io.Discard)
+	}
+	opts := engine.Options()
+	engine.SetCycleCheck(opts.CheckCycles || *cycles || *ignoreTypeImports, opts.CyclesIgnoreTypeOnly || *ignoreTypeImports)
+	checkCycles := engine.Options().CheckCycles
+
+	if *staged {
+		git, err := renamer.OpenGit(engine.Root())
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			return checkExitError
+		}
+		files, err := git.StagedFiles()
+		if err != nil {
+			fmt.Printf("ステージされたファイルの取得に失敗しました: %v\n", err)
+			return checkExitError
+		}
+		if len(files) == 0 {
+			engine.SetConversionDirection(flags.conversionDirection(engine))
+			if err := report.write(engine.NewCheckReport(nil, 0)); err != nil {
+				fmt.Printf("%v\n", err)
+				return checkExitError
+			}
+			return checkExitOK
+		}
+		engine.SetOnlyFiles(files)
+	} else if err := flags.applyFilter(engine); err != nil {
+		fmt.Printf("%v\n", err)
+		return checkExitError
+	}
+
+	dirs, err := flags.targetDirs(engine)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return checkExitError
+	}
+	direction := flags.conversionDirection(engine)
+	engine.SetConversionDirection(direction)
+	violations, err := engine.Check(dirs)
+	if err != nil {
+		fmt.Printf("命名規則の検査に失敗しました: %v\n", err)
+		return checkExitError
+	}
+	var foundCycles []renamer.ImportCycle
+	if checkCycles {
+		foundCycles, err = engine.FindCycles()
+		if err != nil {
+			fmt.Printf("循環しているインポートの検出に失敗しました: %v\n", err)
+			return checkExitError
+		}
+	}
+
+	if *baselinePath == "" {
+		*baselinePath = renamer.BaselinePath(engine.Root())
+	}
+	loaded, err := renamer.LoadBaseline(engine.FS(), *baselinePath)
+	if err != nil && !*writeBaseline {
+		fmt.Printf("%v\n", err)
+		return checkExitError
+	}
+	if *writeBaseline {
+		baseline := renamer.NewNamingBaseline(direction, violations)
+		if checkCycles {
+			baseline.RecordCycles(foundCycles)
+		} else if loaded != nil {
+			// 循環を検査していない場合は記録済みの循環を残す
+			baseline.Cycles = loaded.Cycles
+		}
+		if err := baseline.Save(engine.FS(), *baselinePath); err != nil {
+			fmt.Printf("%v\n", err)
+			return checkExitError
+		}
+		fmt.Printf("%d 件の違反をベースラインに記録しました: %s\n", len(violations), *baselinePath)
+		if checkCycles {
+			fmt.Printf("%d 件の循環しているインポートをベースラインに記録しました\n", len(foundCycles))
+		}
+		return checkExitOK
+	}
+	baseline := loaded
+	if baseline != nil && baseline.Direction != direction {
+		fmt.Printf("%s警告: ベースラインの変換方向（%s）が検査の変換方向（%s）と異なるため、ベースラインを使用しません%s\n",
+			colorYellow, baseline.Direction, direction, colorReset)
+		baseline = nil
+	}
+
+	introduced, fixed := engine.CompareBaseline(baseline, dirs, violations)
+	var introducedCycles []renamer.ImportCycle
+	var fixedCycles []string
+	if checkCycles {
+		// 循環の記録は変換方向に関係しないため、読み込んだベースラインと照合する
+		introducedCycles, fixedCycles = engine.CompareCycleBaseline(loaded, foundCycles)
+	}
+	// --staged と --since / --files-from では一部のファイルだけを検査しているため、解消されたかどうかを判断できない
+	// （pre-commit フックでコミットの後にベースラインの変更が残らないよう、ベースラインは書き換えない）
+	partial := *staged || flags.since != "" || flags.filesFrom != ""
+	if partial && (len(fixed) > 0 || len(fixedCycles) > 0) {
+		fmt.Printf("解消された違反があります。ベースラインを更新するには、ファイルを限定せずに check を実行してください\n")
+	} else if len(fixed) > 0 || len(fixedCycles) > 0 {
+		// 解消された違反と循環をベースラインから削除し、再び持ち込まれないようにする
+		removed := loaded.Remove(fixed)
+		removedCycles := loaded.RemoveCycles(fixedCycles)
+		if err := loaded.Save(engine.FS(), *baselinePath); err != nil {
+			fmt.Printf("%v\n", err)
+			return checkExitError
+		}
+		if removed > 0 {
+			fmt.Printf("%s解消された %d 件の違反をベースラインから削除しました: %s%s\n", colorGreen, removed, *baselinePath, colorReset)
+		}
+		if removedCycles > 0 {
+			fmt.Printf("%s解消された %d 件の循環をベースラインから削除しました: %s%s\n", colorGreen, removedCycles, *baselinePath, colorReset)
+		}
+	}
+	known := len(violations) - len(introduced)
+	if known > 0 {
+		fmt.Printf("ベースラインに記録済みの違反: %d 件\n", known)
+	}
+	knownCycles := len(foundCycles) - len(introducedCycles)
+	if knownCycles > 0 {
+		fmt.Printf("ベースラインに記録済みの循環: %d 件\n", knownCycles)
+	}
+	result := engine.NewCheckReport(introduced, known)
+	result.AddCycles(introducedCycles, knownCycles)
+	if err := report.write(result); err != nil {
+		fmt.Printf("%v\n", err)
+		return checkExitError
+	}
+
+	exitCode := checkExitOK
+	if len(introduced) == 0 {
+		if known > 0 {
+			fmt.Printf("%s命名規則（%s）の新たな違反はありません%s\n", colorGreen, direction, colorReset)
+		} else {
+			fmt.Printf("%s命名規則（%s）に違反しているファイルはありません%s\n", colorGreen, direction, colorReset)
+		}
+	} else {
+		title := "命名規則（%s）に違反しているファイル: %d 件"
+		if known > 0 {
+			title = "命名規則（%s）の新たな違反: %d 件"
+		}
+		fmt.Printf(colorRed+title+colorReset+"\n", direction, len(introduced))
+		for _, violation := range introduced {
+			fmt.Printf("  %s\n", violation)
+		}
+		fmt.Printf("\nrename-script apply --direction %s で修正できます。\n", direction)
+		exitCode = checkExitViolation
+	}
+
+	if checkCycles {
+		if len(introducedCycles) == 0 {
+			fmt.Printf("%s新たに循環しているインポートはありません%s\n", colorGreen, colorReset)
+		} else {
+			fmt.Printf("\n%s循環しているインポート: %d 件%s\n", colorRed, len(introducedCycles), colorReset)
+			for i, cycle := range introducedCycles {
+				fmt.Printf("\n  %d. %d ファイル\n", i+1, len(cycle.Files))
+				for _, line := range strings.Split(cycle.String(), "\n") {
+					fmt.Printf("    %s\n", line)
+				}
+			}
+			exitCode = checkExitViolation
+		}
+	}
+	return exitCode
+}
+
+// ステージされたファイルを検査する pre-commit フックをインストールする
+func installPreCommitHook(engine *renamer.Engine, force bool) error {
+	git, err := renamer.OpenGit(engine.Root())
+	if err != nil {
+		return err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("実行ファイルのパスの取得に失敗しました: %v", err)
+	}
+	if strings.Contains(executable, "go-build") {
+		return fmt.Errorf("go run で実行しているためフックをインストールできません。ビルドしたバイナリから実行してください")
+	}
+
+	hooksDir, err := git.HooksDir()
+	if err != nil {
+		return fmt.Errorf("フックのディレクトリの取得に失敗しました: %v", err)
+	}
+	hookPath := filepath.Join(hooksDir, "pre-commit")
+	if data, err := os.ReadFile(hookPath); err == nil && !strings.Contains(string(data), hookMarker) && !force {
+		return fmt.Errorf("pre-commit フックが既に存在します: %s（--force で上書き）", hookPath)
+	}
+
+	script := fmt.Sprintf("#!/bin/sh\n%s --staged（rename-script check --install-hook で作成）\nexec %s check --staged\n", hookMarker, posixQuote(executable))
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return fmt.Errorf("フックのディレクトリの作成に失敗しました: %v", err)
+	}
+	if err := os.WriteFile(hookPath, []byte(script), 0755); err != nil {
+		return fmt.Errorf("フックの書き込みに失敗しました: %v", err)
+	}
+	fmt.Printf("pre-commit フックをインストールしました: %s\n", hookPath)
+	return nil
+}
+
+// sh のスクリプトに埋め込めるよう、文字列を単一引用符で囲む（"$" やバッククォート、"\" も展開されない）
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,726 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+
+	"rename-script/renamer"
+)
+
+// サブコマンド
+type command struct {
+	// コマンドの説明
+	summary string
+	// コマンドの実行（終了コードを返す）
+	run func(args []string) int
+}
+
+// 利用可能なサブコマンド一覧
+var commands = map[string]command{
+	"analyze":           {summary: "プロジェクト構造を分析して統計を表示する", run: runAnalyze},
+	"plan":              {summary: "変換計画（リネーム予定）を表示する", run: runPlan},
+	"apply":             {summary: "変換計画を実行する（--dry-run で変更予定のみ表示）", run: runApply},
+	"undo":              {summary: "最後に実行した変更をジャーナルから元に戻す", run: runUndo},
+	"check":             {summary: "命名規則に違反しているファイルを検出する（違反がある場合は終了コード 1）", run: runCheck},
+	"audit":             {summary: "ファイル名の移植性（大文字小文字の衝突・予約名・使用できない文字・長さ）を検査する", run: runAudit},
+	"check-imports":     {summary: "解決できないインポートと大文字小文字が一致しないインポートを検出する", run: runCheckImports},
+	"who-imports":       {summary: "ファイルを直接インポートしているファイルを表示する", run: runWhoImports},
+	"imports-of":        {summary: "ファイルが直接インポートしているファイルを表示する", run: runImportsOf},
+	"impact":            {summary: "ファイルを直接または間接にインポートしているファイル（変更の影響範囲）を表示する", run: runImpact},
+	"graph":             {summary: "プロジェクト全体の依存グラフを DOT または JSON で出力する", run: runGraph},
+	"unused":            {summary: "どのファイルからもインポートされていないモジュールを検出する（検出した場合は終了コード 1）", run: runUnused},
+	"export-names":      {summary: "default エクスポートまたは主な名前付きエクスポートの識別子がファイル名と一致しないモジュールを検出する（検出した場合は終了コード 1）", run: runExportNames},
+	"boundaries":        {summary: "ワークスペース間の許可されていないインポートと、ワークスペースの外を指す tsconfig の include を検出する（検出した場合は終了コード 1）", run: runBoundaries},
+	"mv":                {summary: "ファイルまたはディレクトリを移動し、プロジェクト全体の参照を書き換える（--dry-run で変更予定のみ表示）", run: runMv},
+	"rewrite-imports":   {summary: "規則ファイル（完全一致・前方一致・正規表現）に従って、ファイルを移動せずにモジュール指定子を書き換える", run: runRewriteImports},
+	"rename-package":    {summary: "ワークスペースのパッケージ名を変更し、package.json の name と依存・tsconfig・インポートを書き換える", run: runRenamePackage},
+	"hoist":             {summary: "アプリのコンポーネントを packages/ui の src/custom に移動し、exports への登録とインポートの書き換えを行う", run: runHoist},
+	"normalize-imports": {summary: "深い相対パスを tsconfig の paths のエイリアスに（--to relative でエイリアスを相対パスに）書き換えて、インポートの書き方を統一する", run: runNormalizeImports},
+	"barrels":           {summary: "設定ファイルのディレクトリのバレル（index.ts）を生成・更新する（check で更新が必要なものを検出）", run: runBarrels},
+}
+
+// 使い方を表示
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "使い方: rename-script [サブコマンド] [オプション]")
+	fmt.Fprintln(os.Stderr, "\nサブコマンドを省略すると対話モードで起動します。")
+	fmt.Fprintln(os.Stderr, "\nサブコマンド:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(os.Stderr, "\n対話モードのオプション:")
+	flag.PrintDefaults()
+}
+
+// カレントディレクトリからプロジェクトルートを検出し、除外設定を読み込んだエンジンを作成
+func newEngine(debugMode bool) (*renamer.Engine, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("現在のディレクトリの取得に失敗しました: %v", err)
+	}
+	projectRoot, err := renamer.FindProjectRoot(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクトルートの検出に失敗しました: %v", err)
+	}
+
+	// 除外設定ファイルの読み込み
+	excludeConfigPath := renamer.ExcludeConfigPath(projectRoot)
+	excludeConfig, err := renamer.LoadExcludeConfig(excludeConfigPath)
+	if err != nil {
+		fmt.Printf("警告: 除外設定ファイルの読み込みに失敗しました: %v\n", err)
+		fmt.Println("デフォルトの除外設定を使用します。")
+		excludeConfig = renamer.DefaultExcludeConfig()
+	} else if debugMode {
+		fmt.Printf("除外設定ファイルを読み込みました: %s\n", excludeConfigPath)
+		fmt.Printf("  除外ファイル: %d 件\n", len(excludeConfig.ExcludeFiles))
+		fmt.Printf("  除外インポート: %d 件\n", len(excludeConfig.ExcludeImports))
+		fmt.Printf("  除外ディレクトリ: %d 件\n", len(excludeConfig.ExcludeDirectories))
+	}
+
+	opts := renamer.Options{
+		DebugMode: debugMode,
+		Output:    os.Stdout,
+	}
+	excludeConfig.ApplyTo(&opts)
+	return renamer.NewEngine(projectRoot, opts)
+}
+
+// サブコマンド共通のオプション
+type commonFlags struct {
+	debug     bool
+	dirs      string
+	direction string
+	// 変換対象のファイルを限定するオプション（plan / apply のみ）
+	since     string
+	filesFrom string
+	// 索引のキャッシュを使用するかどうか（plan / apply のみ）
+	cache bool
+}
+
+// 共通オプションを登録
+func (c *commonFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&c.debug, "debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	fs.StringVar(&c.dirs, "dirs", "", "対象ディレクトリ（カンマ区切り、省略時は検出された全ディレクトリ）")
+	fs.StringVar(&c.direction, "direction", "", "変換方向（camel-to-kebab または kebab-to-camel、省略時は除外設定の naming.direction）")
+}
+
+// 変換方向を決定（未指定の場合は除外設定の naming.direction、それもなければ camel-to-kebab）
+func (c *commonFlags) conversionDirection(engine *renamer.Engine) string {
+	if c.direction != "" {
+		return c.direction
+	}
+	if direction := engine.Options().ConversionDirection; direction != "" {
+		return direction
+	}
+	return renamer.DirectionCamelToKebab
+}
+
+// 変換対象のファイルを限定するオプションを登録
+func (c *commonFlags) registerFilter(fs *flag.FlagSet) {
+	fs.StringVar(&c.since, "since", "", "指定した git の参照以降に追加・変更されたファイルだけを対象にする")
+	fs.StringVar(&c.filesFrom, "files-from", "", "対象ファイルの一覧を読み込む（NUL または改行区切り、- の場合は標準入力）")
+}
+
+// 索引のキャッシュのオプションを登録
+func (c *commonFlags) registerCache(fs *flag.FlagSet) {
+	fs.BoolVar(&c.cache, "cache", false, "インポートの解析結果を scripts/rename/.cache に保存し、変更のないファイルの解析を省略する")
+}
+
+// 変換対象のファイルを限定する（--since / --files-from の指定がない場合は何もしない）
+// 一覧のパスはプロジェクトルートからの相対パス（git diff --name-only の出力と同じ）とみなす
+func (c *commonFlags) applyFilter(engine *renamer.Engine) error {
+	if c.since == "" && c.filesFrom == "" {
+		return nil
+	}
+
+	files := []string{}
+	if c.since != "" {
+		git, err := renamer.OpenGit(engine.Root())
+		if err != nil {
+			return err
+		}
+		changed, err := git.ChangedFiles(c.since)
+		if err != nil {
+			return fmt.Errorf("%s 以降の変更の取得に失敗しました: %v", c.since, err)
+		}
+		files = append(files, changed...)
+	}
+	if c.filesFrom != "" {
+		var data []byte
+		var err error
+		if c.filesFrom == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(c.filesFrom)
+		}
+		if err != nil {
+			return fmt.Errorf("ファイル一覧の読み込みに失敗しました: %v", err)
+		}
+		files = append(files, renamer.ParseFileList(data)...)
+	}
+
+	engine.SetOnlyFiles(files)
+	fmt.Printf("変換対象を %d ファイルに限定します\n", len(files))
+	return nil
+}
+
+// 対象ディレクトリを決定（未指定の場合はプロジェクト解析結果を使用）
+func (c *commonFlags) targetDirs(engine *renamer.Engine) ([]string, error) {
+	if c.dirs != "" {
+		return splitDirs(c.dirs), nil
+	}
+
+	structure, err := engine.Analyze()
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクト構造の解析に失敗しました: %v", err)
+	}
+	return engine.FilterDirs(structure.Directories), nil
+}
+
+// オプションを解析し、位置引数を返す（オプションは位置引数の後にも指定できる）
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	fs.Parse(args)
+	var positional []string
+	for fs.NArg() > 0 {
+		positional = append(positional, fs.Arg(0))
+		fs.Parse(fs.Args()[1:])
+	}
+	return positional
+}
+
+// カンマ区切りのディレクトリ指定を分割する
+func splitDirs(value string) []string {
+	var dirs []string
+	for _, dir := range strings.Split(value, ",") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// analyze サブコマンド
+func runAnalyze(args []string) int {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	var flags commonFlags
+	flags.register(fs)
+	var report reportFlags
+	report.register(fs)
+	fs.Parse(args)
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	engine, err := newEngine(flags.debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	structure, err := engine.Analyze()
+	if err != nil {
+		fmt.Printf("プロジェクト構造の解析に失敗しました: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("\n検出された%s: %d ディレクトリ\n", structure.RootType, len(structure.Directories))
+	for _, dir := range structure.Directories {
+		fmt.Println(formatDirectoryInfo(dir, structure.FileStats[dir]))
+	}
+	if err := report.write(engine.NewAnalyzeReport(structure)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// plan サブコマンド
+func runPlan(args []string) int {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	var flags commonFlags
+	flags.register(fs)
+	flags.registerFilter(fs)
+	flags.registerCache(fs)
+	var report reportFlags
+	report.register(fs)
+	fs.Parse(args)
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	engine, plan, err := buildPlan(flags)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if err := loadBarrels(engine); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	// 各リネームの影響を受けるインポート元を依存グラフから求める
+	var graph *renamer.Graph
+	if plan.RenameCount() > 0 {
+		if graph, err = engine.BuildGraph(); err != nil {
+			fmt.Printf("警告: 依存グラフの作成に失敗しました: %v\n", err)
+		}
+	}
+
+	fmt.Printf("\n=== 変換計画 (%s) ===\n", plan.ConversionDirection)
+	for _, dirPlan := range plan.Dirs {
+		fmt.Printf("\n%s [合計: %d, スキップ: %d]\n", dirPlan.TargetDir, dirPlan.TotalFiles, dirPlan.SkippedFiles)
+		for _, rename := range dirPlan.Renames {
+			fmt.Printf("  %s → %s\n", engine.Rel(rename.OldPath), engine.Rel(rename.NewPath))
+			if graph == nil {
+				continue
+			}
+			for _, edge := range graph.ImportersOf(engine.Rel(rename.OldPath)) {
+				fmt.Printf("      ← %s\n", edge)
+			}
+		}
+		for _, barrel := range engine.PlannedBarrels(dirPlan) {
+			fmt.Printf("  バレルを再生成: %s\n", barrel)
+		}
+	}
+	fmt.Printf("\nリネーム予定: %d 件\n", plan.RenameCount())
+	issues := reportPlanPortability(engine, plan)
+	planReport := engine.NewPlanReport(plan)
+	planReport.AddPortability(issues)
+	if graph != nil {
+		planReport.AddImporters(graph)
+	}
+	if err := report.write(planReport); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if len(issues) > 0 && engine.Options().BlockUnportable {
+		return 1
+	}
+	return 0
+}
+
+// apply サブコマンド
+func runApply(args []string) int {
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+	var flags commonFlags
+	flags.register(fs)
+	flags.registerFilter(fs)
+	flags.registerCache(fs)
+	dryRun := fs.Bool("dry-run", false, "ドライラン（ファイルを変更せず、変更予定のみ表示）")
+	noVerify := fs.Bool("no-verify", false, "実行後のインポートの検証を行わない")
+	rollback := fs.Bool("rollback", false, "検証で解決できないインポートが見つかった場合に確認せずに元に戻す")
+	blockUnportable := fs.Bool("block-unportable", false, "移植性の問題を生じる変換計画を実行しない（除外設定の portability.block と同じ）")
+	gitMode := fs.Bool("git", false, "git で追跡されているファイルを git mv でリネームする")
+	commit := fs.Bool("commit", false, "リネームと内容の変更を別々のコミットとして記録する（--git が必要）")
+	force := fs.Bool("force", false, "コミットされていない変更があっても実行する")
+	branch := fs.String("branch", "", "新しいブランチを作成して実行し、結果をコミットする（auto の場合は名前を自動生成、--git --commit を含む）")
+	diff := fs.Bool("diff", false, "変更予定を git apply で適用できる unified diff で出力する（--dry-run を含む）")
+	var report reportFlags
+	report.register(fs)
+	fs.Parse(args)
+	if *diff && report.enabled() {
+		fmt.Println("--diff と --format は同時に指定できません")
+		return 1
+	}
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	var diffOutput *os.File
+	if *diff {
+		*dryRun = true
+		diffOutput = redirectStdout()
+	}
+
+	if *branch != "" {
+		*gitMode, *commit = true, true
+	}
+	if *commit && !*gitMode {
+		fmt.Println("--commit には --git の指定が必要です")
+		return 1
+	}
+
+	engine, plan, err := buildPlan(flags)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if *gitMode {
+		if engine, err = withGit(engine); err != nil {
+			fmt.Printf("%v\n", err)
+			return 1
+		}
+	}
+	if !*dryRun {
+		if err := checkWorkingTree(engine, *force); err != nil {
+			fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+			return 1
+		}
+		if *commit {
+			if err := checkStagedChanges(engine); err != nil {
+				fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+				return 1
+			}
+		}
+		if *branch != "" {
+			if err := createBranch(engine, *branch, plan.ConversionDirection); err != nil {
+				fmt.Printf("%v\n", err)
+				return 1
+			}
+		}
+	}
+
+	if err := loadBarrels(engine); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if *blockUnportable {
+		engine.SetBlockUnportable(true)
+	}
+	var issues []renamer.PortabilityIssue
+	if !engine.Options().BlockUnportable {
+		issues = reportPlanPortability(engine, plan)
+	}
+	engine.SetDryRun(*dryRun)
+	results, run, err := engine.ApplyRun(plan)
+	journalPath := saveJournal(engine, run)
+	if err != nil {
+		printApplyError(err)
+		return 1
+	}
+	if *diff && writeDiff(diffOutput, engine, run) != nil {
+		return 1
+	}
+	applyReport := engine.NewApplyReport(plan, results)
+	applyReport.AddPortability(issues)
+	if err := report.write(applyReport); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	var processed, errors int
+	var barrels []string
+	for _, result := range results {
+		processed += result.ProcessedFiles
+		errors += result.ErrorFiles
+		barrels = append(barrels, result.Barrels...)
+	}
+	fmt.Printf("\n処理したファイル数: %d, エラーが発生したファイル数: %d\n", processed, errors)
+	if len(barrels) > 0 {
+		label := "更新したバレル"
+		if *dryRun {
+			label = "更新予定のバレル"
+		}
+		fmt.Printf("%s: %d 件\n  %s\n", label, len(barrels), strings.Join(barrels, "\n  "))
+	}
+
+	code := 0
+	if errors > 0 {
+		code = 1
+	}
+	if !*noVerify {
+		mode := rollbackPrompt
+		if *rollback {
+			mode = rollbackAuto
+		}
+		if verifyRun(engine, run, planDirs(plan), journalPath, mode, flags.debug) != 0 {
+			code = 1
+		}
+	}
+	if *commit && !*dryRun {
+		if code != 0 {
+			fmt.Println("エラーまたは解決できないインポートがあるため、コミットしません。")
+			return code
+		}
+		if commitRun(engine, run, plan, results) != 0 {
+			code = 1
+		}
+	}
+	return code
+}
+
+// undo サブコマンド
+func runUndo(args []string) int {
+	fs := flag.NewFlagSet("undo", flag.ExitOnError)
+	debug := fs.Bool("debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	journalPath := fs.String("journal", "", "元に戻すジャーナルのパス（省略時は最後に実行した変更）")
+	fs.Parse(args)
+
+	engine, err := newEngine(*debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	var journal *renamer.Journal
+	path := *journalPath
+	if path != "" {
+		journal, err = renamer.LoadJournal(engine.FS(), path)
+	} else {
+		journal, path, err = renamer.LatestJournal(engine.FS(), renamer.JournalDir(engine.Root()))
+	}
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if journal == nil {
+		fmt.Println("元に戻せる変更はありません。")
+		return 0
+	}
+	if journal.UndoneAt != nil {
+		fmt.Printf("このジャーナルは既に元に戻されています（%s）\n", journal.UndoneAt.Format("2006-01-02 15:04:05"))
+		return 1
+	}
+
+	if journal.Git {
+		if engine, err = withGit(engine); err != nil {
+			fmt.Printf("%v\n", err)
+			return 1
+		}
+	}
+
+	fmt.Printf("%s に実行した %s を元に戻します（%d 件の操作）\n",
+		journal.CreatedAt.Format("2006-01-02 15:04:05"), journal.Command, len(journal.Ops))
+	rollbackErr := journal.Rollback(engine.FS())
+	journal.MarkUndone()
+	if _, err := journal.Save(engine.FS(), filepath.Dir(path)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if rollbackErr != nil {
+		fmt.Printf("一部の変更を元に戻せませんでした: %v\n", rollbackErr)
+		return 1
+	}
+	fmt.Println("変更を元に戻しました。")
+	return 0
+}
+
+// audit サブコマンド
+func runAudit(args []string) int {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	debug := fs.Bool("debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	dirsFlag := fs.String("dirs", "", "対象ディレクトリ（カンマ区切り、省略時はすべてのワークスペース）")
+	maxPath := fs.Int("max-path", 0, "パスの長さの上限（プロジェクトルートからの相対パスの文字数、省略時は除外設定の値）")
+	maxName := fs.Int("max-name", 0, "ファイル名の長さの上限（バイト数、省略時は除外設定の値）")
+	fs.Parse(args)
+
+	engine, err := newEngine(*debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	engine.SetPortabilityLimits(*maxPath, *maxName)
+
+	dirs := splitDirs(*dirsFlag)
+	if len(dirs) == 0 {
+		if dirs, err = engine.WorkspaceDirs(); err != nil {
+			fmt.Printf("ワークスペースの読み込みに失敗しました: %v\n", err)
+			return 1
+		}
+	}
+
+	issues, err := engine.AuditPortability(dirs)
+	if err != nil {
+		fmt.Printf("移植性の検査に失敗しました: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("\n=== ファイル名の移植性の検査 ===\n")
+	fmt.Printf("検査したディレクトリ: %d\n", len(dirs))
+	if *debug {
+		for _, dir := range dirs {
+			fmt.Printf("  %s\n", dir)
+		}
+	}
+	if len(issues) == 0 {
+		fmt.Printf("%s移植性の問題は見つかりませんでした%s\n", colorGreen, colorReset)
+		return 0
+	}
+	printPortabilityIssues("移植性の問題", issues, colorRed)
+	return 1
+}
+
+// check-imports サブコマンド
+func runCheckImports(args []string) int {
+	fs := flag.NewFlagSet("check-imports", flag.ExitOnError)
+	debug := fs.Bool("debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	dirsFlag := fs.String("dirs", "", "対象ディレクトリ（カンマ区切り、省略時はすべてのワークスペース）")
+	fix := fs.Bool("fix", false, "大文字小文字の不一致を確認せずに修正する")
+	dryRun := fs.Bool("dry-run", false, "ドライラン（ファイルを変更せず、修正予定のみ表示）")
+	diff := fs.Bool("diff", false, "修正予定を git apply で適用できる unified diff で出力する（--fix --dry-run を含む）")
+	cache := fs.Bool("cache", false, "インポートの解析結果を scripts/rename/.cache に保存し、変更のないファイルの解析を省略する")
+	fs.Parse(args)
+	var diffOutput *os.File
+	if *diff {
+		*fix, *dryRun = true, true
+		diffOutput = redirectStdout()
+	}
+
+	engine, err := newEngine(*debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	dirs := splitDirs(*dirsFlag)
+	if len(dirs) == 0 {
+		if dirs, err = engine.WorkspaceDirs(); err != nil {
+			fmt.Printf("ワークスペースの読み込みに失敗しました: %v\n", err)
+			return 1
+		}
+	}
+
+	if *cache {
+		engine.SetIndexCachePath(renamer.IndexCachePath(engine.Root()))
+	}
+	issues, err := engine.CheckImports(dirs)
+	if err != nil {
+		fmt.Printf("インポートの検査に失敗しました: %v\n", err)
+		return 1
+	}
+	if err := engine.SaveIndexCache(); err != nil {
+		fmt.Printf("警告: %v\n", err)
+	}
+
+	var mismatches, unresolved []renamer.ImportIssue
+	fixable := 0
+	for _, issue := range issues {
+		if issue.Status == renamer.ResolveCaseMismatch {
+			mismatches = append(mismatches, issue)
+			if issue.Suggestion != "" {
+				fixable++
+			}
+		} else {
+			unresolved = append(unresolved, issue)
+		}
+	}
+
+	fmt.Printf("\n=== インポートの検査 ===\n")
+	fmt.Printf("検査したディレクトリ: %d\n", len(dirs))
+	if *debug {
+		for _, dir := range dirs {
+			fmt.Printf("  %s\n", dir)
+		}
+	}
+	if len(mismatches) > 0 {
+		fmt.Printf("\n%s大文字小文字が一致しないインポート: %d 件%s\n", colorYellow, len(mismatches), colorReset)
+		fmt.Println("  （大文字小文字を区別しないファイルシステムでのみ解決できます）")
+		for _, issue := range mismatches {
+			fmt.Printf("  %s\n", issue)
+		}
+	}
+	if len(unresolved) > 0 {
+		fmt.Printf("\n%s解決できないインポート: %d 件%s\n", colorRed, len(unresolved), colorReset)
+		for _, issue := range unresolved {
+			fmt.Printf("  %s\n", issue)
+		}
+	}
+	if len(issues) == 0 {
+		fmt.Printf("%sすべてのインポートを解決できました%s\n", colorGreen, colorReset)
+		return 0
+	}
+	if fixable == 0 {
+		return 1
+	}
+
+	if !*fix && !*dryRun {
+		if !isTerminal() {
+			fmt.Printf("\n--fix を指定すると大文字小文字の不一致 %d 件を修正できます。\n", fixable)
+			return 1
+		}
+		prompt := promptui.Select{
+			Label: fmt.Sprintf("大文字小文字の不一致 %d 件を修正しますか？", fixable),
+			Items: []string{"修正する", "修正しない"},
+		}
+		if _, choice, err := prompt.Run(); err != nil || choice != "修正する" {
+			return 1
+		}
+	}
+
+	engine.SetDryRun(*dryRun)
+	fixed, run, err := engine.FixCaseMismatches("check-imports", issues)
+	saveJournal(engine, run)
+	if err != nil {
+		fmt.Printf("インポートの修正に失敗しました: %v\n", err)
+		return 1
+	}
+
+	if *diff && writeDiff(diffOutput, engine, run) != nil {
+		return 1
+	}
+	if *dryRun {
+		fmt.Printf("\n修正予定のインポート: %d 件（ドライランのため、ファイルは変更されていません）\n", len(fixed))
+	} else {
+		fmt.Printf("\n%s修正したインポート: %d 件%s\n", colorGreen, len(fixed), colorReset)
+	}
+	for _, issue := range fixed {
+		fmt.Printf("  %s:%d: '%s' → '%s'\n", issue.File, issue.Line, issue.Specifier, issue.Suggestion)
+	}
+	if *dryRun || len(fixed) < len(issues) {
+		return 1
+	}
+	return 0
+}
+
+// ドライランの実行結果を unified diff で出力する
+func writeDiff(w io.Writer, engine *renamer.Engine, run *renamer.Run) error {
+	diff, err := engine.Diff(run)
+	if err == nil {
+		_, err = io.WriteString(w, diff)
+	}
+	if err != nil {
+		fmt.Printf("差分の出力に失敗しました: %v\n", err)
+	}
+	return err
+}
+
+// 変換計画の対象ディレクトリ
+func planDirs(plan *renamer.Plan) []string {
+	dirs := make([]string, 0, len(plan.Dirs))
+	for _, dirPlan := range plan.Dirs {
+		dirs = append(dirs, dirPlan.TargetDir)
+	}
+	return dirs
+}
+
+// エンジンを作成して変換計画を立てる
+func buildPlan(flags commonFlags) (*renamer.Engine, *renamer.Plan, error) {
+	engine, err := newEngine(flags.debug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := flags.applyFilter(engine); err != nil {
+		return nil, nil, err
+	}
+	dirs, err := flags.targetDirs(engine)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if flags.cache {
+		engine.SetIndexCachePath(renamer.IndexCachePath(engine.Root()))
+	}
+	engine.SetConversionDirection(flags.conversionDirection(engine))
+	plan, err := engine.Plan(dirs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("変換計画の作成に失敗しました: %v", err)
+	}
+	if err := engine.SaveIndexCache(); err != nil {
+		fmt.Printf("警告: %v\n", err)
+	}
+	return engine, plan, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// export-names サブコマンド
+// 主なエクスポートの識別子がファイル名と一致しないモジュールがある場合は終了コード 1 を返す
+func runExportNames(args []string) int {
+	fs := flag.NewFlagSet("export-names", flag.ExitOnError)
+	var flags commonFlags
+	flags.register(fs)
+	var report reportFlags
+	report.register(fs)
+	fs.Parse(args)
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	engine, err := newEngine(flags.debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if !flags.debug {
+		engine = engine.WithOutput(io.Discard)
+	}
+
+	dirs, err := flags.targetDirs(engine)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	mismatches, err := engine.FindExportMismatches(dirs)
+	if err != nil {
+		fmt.Printf("エクスポートの検査に失敗しました: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("\n=== エクスポートの識別子とファイル名 ===\n")
+	fmt.Printf("検査したディレクトリ: %d\n", len(dirs))
+	if flags.debug {
+		for _, dir := range dirs {
+			fmt.Printf("  %s\n", dir)
+		}
+	}
+	if len(mismatches) == 0 {
+		fmt.Printf("%sファイル名と一致しないエクスポートはありません%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("\n%sファイル名と一致しないエクスポート: %d 件%s\n", colorYellow, len(mismatches), colorReset)
+		for _, mismatch := range mismatches {
+			fmt.Printf("  %s\n", mismatch)
+			from := mismatch.Path
+			if mismatch.IsDir {
+				from = path.Dir(from)
+			}
+			fmt.Printf("      ファイル名を変更: rename-script mv %s %s\n", shellQuote(from), shellQuote(mismatch.SuggestedPath))
+			fmt.Printf("      識別子を変更:     %s → %s\n", mismatch.Symbol, mismatch.SuggestedSymbol)
+		}
+	}
+
+	if err := report.write(engine.NewExportNameReport(mismatches)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if len(mismatches) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// シェルに貼り付けられるよう、特殊な文字（Next.js のルートグループや動的セグメントなど）を含むパスを引用符で囲む
+func shellQuote(p string) string {
+	if !strings.ContainsAny(p, " ()[]$&;'\"*?") {
+		return p
+	}
+	return posixQuote(p)
+}
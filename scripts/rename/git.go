@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"rename-script/renamer"
+)
+
+// 作業ツリーの確認で一覧表示するファイル数の上限
+const maxDirtyFilesShown = 20
+
+// git mv でリネームするエンジンを返す
+func withGit(engine *renamer.Engine) (*renamer.Engine, error) {
+	git, err := renamer.OpenGit(engine.Root())
+	if err != nil {
+		return nil, err
+	}
+	return engine.WithFS(renamer.NewGitFS(engine.FS(), git)), nil
+}
+
+// コミットされていない変更がある追跡中のファイルを返す（git リポジトリでない場合は nil）
+func dirtyFiles(engine *renamer.Engine) ([]string, error) {
+	git, err := renamer.OpenGit(engine.Root())
+	if err != nil {
+		return nil, nil
+	}
+	files, err := git.DirtyFiles()
+	if err != nil {
+		return nil, fmt.Errorf("作業ツリーの状態の取得に失敗しました: %v", err)
+	}
+	return files, nil
+}
+
+// コミットされていない変更があるファイルを一覧表示する
+func printDirtyFiles(files []string) {
+	fmt.Printf("%sコミットされていない変更があります: %d ファイル%s\n", colorYellow, len(files), colorReset)
+	for i, file := range files {
+		if i == maxDirtyFilesShown {
+			fmt.Printf("  ...ほか %d ファイル\n", len(files)-maxDirtyFilesShown)
+			break
+		}
+		fmt.Printf("  %s\n", file)
+	}
+}
+
+// 作業ツリーにコミットされていない変更がないか確認する（git リポジトリでない場合は確認しない）
+// 変更がある場合は、force が指定されていなければエラーを返す
+func checkWorkingTree(engine *renamer.Engine, force bool) error {
+	files, err := dirtyFiles(engine)
+	if err != nil || len(files) == 0 {
+		return err
+	}
+	printDirtyFiles(files)
+	if force {
+		fmt.Println("--force が指定されているため続行します。")
+		return nil
+	}
+	return fmt.Errorf("変換による変更と混ざらないよう、先にコミットまたは stash してください（--force で続行）")
+}
+
+// --commit の前に、インデックスにステージ済みの変更がないか確認する
+// git commit はインデックス全体を記録するため、既にステージした変更があると変換のコミットに混ざる（--force でも続行しない）
+func checkStagedChanges(engine *renamer.Engine) error {
+	gitFS, ok := engine.FS().(*renamer.GitFS)
+	if !ok {
+		return fmt.Errorf("git モードで実行していません")
+	}
+	staged, err := gitFS.Git().HasStagedChanges()
+	if err != nil {
+		return fmt.Errorf("インデックスの状態の取得に失敗しました: %v", err)
+	}
+	if staged {
+		return fmt.Errorf("ステージ済みの変更が変換のコミットに含まれないよう、先にコミットするか git restore --staged で取り消してください")
+	}
+	return nil
+}
+
+// 変換用のブランチを作成して切り替える（name が auto の場合は変換方向と日時から名前を生成する）
+func createBranch(engine *renamer.Engine, name, direction string) error {
+	gitFS, ok := engine.FS().(*renamer.GitFS)
+	if !ok {
+		return fmt.Errorf("git モードで実行していません")
+	}
+	if name == "auto" {
+		name = fmt.Sprintf("rename/%s-%s", direction, time.Now().Format("20060102-150405"))
+	}
+	if err := gitFS.Git().CreateBranch(name); err != nil {
+		return fmt.Errorf("ブランチの作成に失敗しました: %v", err)
+	}
+	fmt.Printf("ブランチ %s を作成しました\n", name)
+	return nil
+}
+
+// リネームと内容の変更を別々のコミットとして記録し、終了コードを返す
+func commitRun(engine *renamer.Engine, run *renamer.Run, plan *renamer.Plan, results []renamer.ConversionResult) int {
+	renameMessage, editMessage := engine.CommitMessages(plan.ConversionDirection, results)
+	commits, err := engine.CommitRun(run, renameMessage, editMessage)
+	for _, hash := range commits {
+		fmt.Printf("コミットしました: %s\n", hash)
+	}
+	if err != nil {
+		fmt.Printf("%sコミットに失敗しました: %v%s\n", colorRed, err, colorReset)
+		return 1
+	}
+	if len(commits) == 0 {
+		fmt.Println("コミットする変更はありません。")
+	}
+	return 0
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"rename-script/renamer"
+)
+
+// 依存グラフの問い合わせのオプション（who-imports / imports-of / impact）
+type graphQueryFlags struct {
+	debug  bool
+	asJSON bool
+}
+
+// 問い合わせのオプションを解析し、対象のパスを返す（オプションは対象のパスの後にも指定できる）
+func (g *graphQueryFlags) parse(name string, args []string) (string, bool) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.BoolVar(&g.debug, "debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	fs.BoolVar(&g.asJSON, "json", false, "結果を JSON で出力する")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fmt.Printf("使い方: rename-script %s <ファイルまたはディレクトリ> [オプション]\n", name)
+		return "", false
+	}
+	target := fs.Arg(0)
+	fs.Parse(fs.Args()[1:])
+	if fs.NArg() > 0 {
+		fmt.Printf("対象は 1 つだけ指定できます: %s\n", strings.Join(fs.Args(), " "))
+		return "", false
+	}
+	return target, true
+}
+
+// 依存グラフを作成し、対象のパスをプロジェクトルートからの相対パスに変換する
+// 対象は絶対パスまたはプロジェクトルートからの相対パスで指定する
+func loadGraph(debug bool, target string) (*renamer.Graph, string, error) {
+	engine, err := newEngine(debug)
+	if err != nil {
+		return nil, "", err
+	}
+	graph, err := engine.BuildGraph()
+	if err != nil {
+		return nil, "", fmt.Errorf("依存グラフの作成に失敗しました: %v", err)
+	}
+	if target == "" {
+		return graph, "", nil
+	}
+	if filepath.IsAbs(target) {
+		target = engine.Rel(target)
+	}
+	target = strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(filepath.Clean(target)), "./"), "/")
+	if !graph.Has(target) {
+		return nil, "", fmt.Errorf("%s は依存グラフに含まれていません（プロジェクトルートからの相対パスで指定してください）", target)
+	}
+	return graph, target, nil
+}
+
+// 結果を JSON で出力する
+func writeJSON(w *os.File, value interface{}) int {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// 依存の補足情報（解決方法・型のみ・動的・ワークスペースをまたぐ）
+func edgeNotes(edge renamer.GraphEdge) string {
+	notes := []string{edge.Kind}
+	if edge.TypeOnly {
+		notes = append(notes, "type")
+	}
+	if edge.Dynamic {
+		notes = append(notes, "dynamic")
+	}
+	if edge.CrossWorkspace {
+		notes = append(notes, "ワークスペース間")
+	}
+	return strings.Join(notes, ", ")
+}
+
+// who-imports サブコマンド
+func runWhoImports(args []string) int {
+	var flags graphQueryFlags
+	target, ok := flags.parse("who-imports", args)
+	if !ok {
+		return 1
+	}
+	var stdout *os.File
+	if flags.asJSON {
+		stdout = redirectStdout()
+	}
+	graph, target, err := loadGraph(flags.debug, target)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	edges := graph.ImportersOf(target)
+	if flags.asJSON {
+		return writeJSON(stdout, append([]renamer.GraphEdge{}, edges...))
+	}
+	fmt.Printf("\n=== %s をインポートしているファイル: %d 件 ===\n", target, len(graph.Importers(target)))
+	for _, edge := range edges {
+		fmt.Printf("  %s (%s)\n", edge, edgeNotes(edge))
+	}
+	return 0
+}
+
+// imports-of サブコマンド
+func runImportsOf(args []string) int {
+	var flags graphQueryFlags
+	target, ok := flags.parse("imports-of", args)
+	if !ok {
+		return 1
+	}
+	var stdout *os.File
+	if flags.asJSON {
+		stdout = redirectStdout()
+	}
+	graph, target, err := loadGraph(flags.debug, target)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	edges := graph.ImportsOf(target)
+	if flags.asJSON {
+		return writeJSON(stdout, append([]renamer.GraphEdge{}, edges...))
+	}
+	fmt.Printf("\n=== %s がインポートしているファイル ===\n", target)
+	for _, edge := range edges {
+		fmt.Printf("  %s → %s (%s)\n", edge, edge.To, edgeNotes(edge))
+	}
+	fmt.Printf("\n依存: %d 件\n", len(edges))
+	return 0
+}
+
+// impact サブコマンド
+func runImpact(args []string) int {
+	var flags graphQueryFlags
+	target, ok := flags.parse("impact", args)
+	if !ok {
+		return 1
+	}
+	var stdout *os.File
+	if flags.asJSON {
+		stdout = redirectStdout()
+	}
+	graph, target, err := loadGraph(flags.debug, target)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	impacts := graph.Impact(target)
+	if flags.asJSON {
+		return writeJSON(stdout, append([]renamer.GraphImpact{}, impacts...))
+	}
+	fmt.Printf("\n=== %s の変更の影響を受けるファイル: %d 件 ===\n", target, len(impacts))
+	depth := 0
+	for _, impact := range impacts {
+		if impact.Depth != depth {
+			depth = impact.Depth
+			if depth == 1 {
+				fmt.Println("\n直接インポートしているファイル:")
+			} else {
+				fmt.Printf("\n%d 段目:\n", depth)
+			}
+		}
+		fmt.Printf("  %s\n", impact.Path)
+	}
+	return 0
+}
+
+// graph サブコマンド
+func runGraph(args []string) int {
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	debug := fs.Bool("debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	format := fs.String("format", renamer.GraphFormatDOT, fmt.Sprintf("出力形式（%s）", strings.Join(renamer.GraphFormats, " / ")))
+	output := fs.String("output", "", "出力先のファイル（省略時は標準出力）")
+	fs.Parse(args)
+	if !slices.Contains(renamer.GraphFormats, *format) {
+		fmt.Printf("対応していない出力形式です: %s（%s）\n", *format, strings.Join(renamer.GraphFormats, " / "))
+		return 1
+	}
+
+	stdout := redirectStdout()
+	graph, _, err := loadGraph(*debug, "")
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	w := stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Printf("出力先のファイルの作成に失敗しました: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := renamer.WriteGraph(w, *format, graph); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	fmt.Printf("依存グラフ: ファイル %d 件、依存 %d 件\n", len(graph.Nodes), len(graph.Edges))
+	return 0
+}
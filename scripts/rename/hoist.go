@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// hoist サブコマンド
+// アプリのコンポーネントを共有パッケージに移動し、exports への登録とインポートの書き換えを行う
+func runHoist(args []string) int {
+	fs := flag.NewFlagSet("hoist", flag.ExitOnError)
+	pkg := fs.String("package", "@kit/ui", "ホイスト先のワークスペースのパッケージ名")
+	name := fs.String("name", "", "ホイスト後のコンポーネントの名前（ケバブケース、省略時はファイル名から求める）")
+	debug := fs.Bool("debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	dryRun := fs.Bool("dry-run", false, "ドライラン（ファイルを変更せず、変更予定のみ表示）")
+	diff := fs.Bool("diff", false, "変更予定を git apply で適用できる unified diff で出力する（--dry-run を含む）")
+	gitMode := fs.Bool("git", false, "git で追跡されているファイルを git mv で移動する")
+	force := fs.Bool("force", false, "コミットされていない変更があっても実行する")
+	noVerify := fs.Bool("no-verify", false, "実行後のインポートの検証を行わない")
+	rollback := fs.Bool("rollback", false, "検証で解決できないインポートが見つかった場合に確認せずに元に戻す")
+	var report reportFlags
+	report.register(fs)
+
+	paths := parseArgs(fs, args)
+	if len(paths) != 1 {
+		fmt.Println("使い方: rename-script hoist <コンポーネントのファイルまたはディレクトリ> [オプション]")
+		return 1
+	}
+	if *diff && report.enabled() {
+		fmt.Println("--diff と --format は同時に指定できません")
+		return 1
+	}
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	var diffOutput *os.File
+	if *diff {
+		*dryRun = true
+		diffOutput = redirectStdout()
+	}
+
+	engine, err := newEngine(*debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if *gitMode {
+		if engine, err = withGit(engine); err != nil {
+			fmt.Printf("%v\n", err)
+			return 1
+		}
+	}
+	if !*dryRun {
+		if err := checkWorkingTree(engine, *force); err != nil {
+			fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+			return 1
+		}
+	}
+
+	engine.SetDryRun(*dryRun)
+	result, run, err := engine.Hoist(paths[0], *pkg, *name)
+	journalPath := saveJournal(engine, run)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+		return 1
+	}
+	if *diff && writeDiff(diffOutput, engine, run) != nil {
+		return 1
+	}
+	if err := report.write(engine.NewHoistReport(result)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	if *dryRun {
+		fmt.Printf("\n%s に移動して %s として公開する予定です（ドライランのため、ファイルは変更されていません）\n", result.To, result.Specifier)
+	} else {
+		fmt.Printf("\n%s%s に移動して %s として公開しました%s\n", colorGreen, result.To, result.Specifier, colorReset)
+	}
+	fmt.Printf("移動したファイル: %d 件\n", len(result.Files))
+	for _, file := range result.Files {
+		fmt.Printf("  %s → %s\n", file.From, file.To)
+	}
+	fmt.Printf("追加した exports: %d 件\n", len(result.Exports))
+	for _, export := range result.Exports {
+		fmt.Printf("  %s\n", export)
+	}
+	fmt.Printf("書き換えた参照: %d 件\n", len(result.Rewrites))
+	for _, rewrite := range result.Rewrites {
+		fmt.Printf("  %s\n", rewrite)
+		if *debug {
+			fmt.Printf("      （%s）\n", rewrite.Kind)
+		}
+	}
+	if len(result.Warnings) > 0 {
+		fmt.Printf("\n%s確認が必要な項目: %d 件%s\n", colorYellow, len(result.Warnings), colorReset)
+		fmt.Printf("  %s\n", strings.Join(result.Warnings, "\n  "))
+	}
+
+	if *noVerify {
+		return 0
+	}
+	mode := rollbackPrompt
+	if *rollback {
+		mode = rollbackAuto
+	}
+	return verifyRun(engine, run, nil, journalPath, mode, *debug)
+}
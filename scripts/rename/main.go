@@ -9,6 +9,8 @@ import (
 	"syscall"
 
 	"github.com/manifoldco/promptui"
+
+	"rename-script/renamer"
 )
 
 // ANSI エスケープシーケンス：文字色
@@ -22,6 +24,18 @@ const (
 	colorCyan   = "\033[36m"
 )
 
+// 対話モードで選択された設定
+type Config struct {
+	// 対象ディレクトリ（複数）
+	TargetDirs []string
+	// 変換方向: "camel-to-kebab" または "kebab-to-camel"
+	ConversionDirection string
+	// ドライラン（true: 実際に変更を行わない、変更予定のファイルだけ表示）
+	DryRun bool
+	// デバッグモード（true: 詳細情報を表示）
+	DebugMode bool
+}
+
 // キャメルケース文字列をカラー表示用にフォーマット
 func formatCamelCase(s string) string {
 	return fmt.Sprintf("\033[1;33m%s\033[0m", s)
@@ -33,7 +47,7 @@ func formatKebabCase(s string) string {
 }
 
 // ディレクトリ情報を文字列に整形
-func formatDirectoryInfo(dir string, stats FileStatistics) string {
+func formatDirectoryInfo(dir string, stats renamer.FileStatistics) string {
 	return fmt.Sprintf("%-40s [%sキャメル: %d%s, %sケバブ: %d%s, 合計: %d]",
 		dir,
 		colorBlue, stats.CamelCaseCount, colorReset,
@@ -43,7 +57,7 @@ func formatDirectoryInfo(dir string, stats FileStatistics) string {
 }
 
 // 変換設定の取得
-func promptForConfig(structure *ProjectStructure, excludeConfig *ExcludeConfig) (Config, error) {
+func promptForConfig(structure *renamer.ProjectStructure) (Config, error) {
 	// Ctrl+C のハンドリング
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -53,15 +67,6 @@ func promptForConfig(structure *ProjectStructure, excludeConfig *ExcludeConfig)
 		os.Exit(0)
 	}()
 
-	// Next.jsの特殊ファイルを除外パターンとして設定
-	excludePatterns := excludeConfig.ExcludeFiles
-
-	// 除外インポートパスを設定
-	excludeImportPatterns := excludeConfig.ExcludeImports
-
-	// 除外ディレクトリを設定
-	excludeDirectories := excludeConfig.ExcludeDirectories
-
 	var targetDirs []string
 	var conversionDirection string
 
@@ -94,7 +99,7 @@ selectionModeStep:
 			// 複数選択モード
 			fmt.Println("\n以下のディレクトリから選択してください（スペースキーで選択/解除、Enterで確定）:")
 			fmt.Println("----------------------------------------")
-			
+
 			selected := make(map[string]bool)
 			dirToDisplayMap := make(map[string]string)
 			var displayToDir = make(map[string]string)
@@ -174,7 +179,7 @@ selectionModeStep:
 			}
 		}
 
-conversionDirectionStep:
+	conversionDirectionStep:
 		// 変換方向の選択
 		conversionDirection, err = promptForConversionDirection(targetDirs, structure)
 		if err != nil {
@@ -202,11 +207,11 @@ conversionDirectionStep:
 			totalKebab += stats.KebabCaseCount
 			totalFiles += stats.TotalFiles
 		}
-		
+
 		// 変換方向と例の表示
 		var directionText string
 		var exampleFrom, exampleTo string
-		if conversionDirection == "camel-to-kebab" {
+		if conversionDirection == renamer.DirectionCamelToKebab {
 			directionText = "キャメルケース → ケバブケース"
 			exampleFrom = formatCamelCase("MyComponent")
 			exampleTo = formatKebabCase("my-component")
@@ -215,9 +220,9 @@ conversionDirectionStep:
 			exampleFrom = formatKebabCase("user-profile")
 			exampleTo = formatCamelCase("UserProfile")
 		}
-		
+
 		fmt.Printf("変換方向: %s（例: %s → %s）\n", directionText, exampleFrom, exampleTo)
-		
+
 		// モード選択プロンプト
 		modePrompt := promptui.Select{
 			Label: "実行モードを選択してください",
@@ -248,12 +253,9 @@ conversionDirectionStep:
 		case fmt.Sprintf("%sテストする（ドライラン・変更なし）%s", colorGreen, colorReset):
 			return Config{
 				TargetDirs:          targetDirs,
-				ExcludePatterns:     excludePatterns,
-				ExcludeImportPatterns: excludeImportPatterns,
-				ExcludeDirectories:  excludeDirectories,
 				ConversionDirection: conversionDirection,
-				DryRun:             true,
-				DebugMode:          false,
+				DryRun:              true,
+				DebugMode:           false,
 			}, nil
 		case fmt.Sprintf("%s変換する（実際に変更を適用）%s", colorRed, colorReset):
 			// テスト実行の確認
@@ -275,32 +277,25 @@ conversionDirectionStep:
 			if testConfirmResult == "いいえ、テストに戻ります" {
 				return Config{
 					TargetDirs:          targetDirs,
-					ExcludePatterns:     excludePatterns,
-					ExcludeImportPatterns: excludeImportPatterns,
-					ExcludeDirectories:  excludeDirectories,
 					ConversionDirection: conversionDirection,
-					DryRun:             true,
-					DebugMode:          false,
+					DryRun:              true,
+					DebugMode:           false,
 				}, nil
 			}
 
 			return Config{
 				TargetDirs:          targetDirs,
-				ExcludePatterns:     excludePatterns,
-				ExcludeImportPatterns: excludeImportPatterns,
-				ExcludeDirectories:  excludeDirectories,
 				ConversionDirection: conversionDirection,
-				DryRun:             false,
-				DebugMode:          false,
+				DryRun:              false,
+				DebugMode:           false,
 			}, nil
 		}
 	}
 
-	
 }
 
 // 変換方向を選択する関数
-func promptForConversionDirection(targetDirs []string, structure *ProjectStructure) (string, error) {
+func promptForConversionDirection(targetDirs []string, structure *renamer.ProjectStructure) (string, error) {
 	// 選択されたディレクトリの統計を集計
 	var totalCamelCase, totalKebabCase int
 	for _, dir := range targetDirs {
@@ -312,15 +307,15 @@ func promptForConversionDirection(targetDirs []string, structure *ProjectStructu
 
 	// 変換方向の選択肢
 	directionOptions := []string{
-		fmt.Sprintf("%s → %s（%d ファイルが対象）例: %s → %s", 
-			"キャメルケース", "ケバブケース", 
+		fmt.Sprintf("%s → %s（%d ファイルが対象）例: %s → %s",
+			"キャメルケース", "ケバブケース",
 			totalCamelCase,
-			formatCamelCase("MyComponent"), 
+			formatCamelCase("MyComponent"),
 			formatKebabCase("my-component")),
-		fmt.Sprintf("%s → %s（%d ファイルが対象）例: %s → %s", 
-			"ケバブケース", "キャメルケース", 
+		fmt.Sprintf("%s → %s（%d ファイルが対象）例: %s → %s",
+			"ケバブケース", "キャメルケース",
 			totalKebabCase,
-			formatKebabCase("user-profile"), 
+			formatKebabCase("user-profile"),
 			formatCamelCase("UserProfile")),
 		"前のステップに戻る",
 		"キャンセル",
@@ -353,9 +348,9 @@ func promptForConversionDirection(targetDirs []string, structure *ProjectStructu
 
 	// 選択結果を返す
 	if idx == 0 {
-		return "camel-to-kebab", nil
+		return renamer.DirectionCamelToKebab, nil
 	} else if idx == 1 {
-		return "kebab-to-camel", nil
+		return renamer.DirectionKebabToCamel, nil
 	}
 
 	// ここには到達しないはず
@@ -378,7 +373,7 @@ func runTests() {
 		{"FAQPage", "faq-page", "camel-to-kebab", "先頭の略語 (FAQPage)"},
 		{"TableUIComponent", "table-ui-component", "camel-to-kebab", "中間の略語 (TableUIComponent)"},
 		{"UserIDCard", "user-id-card", "camel-to-kebab", "略語ID (UserIDCard)"},
-		
+
 		// ケバブケース → キャメルケース
 		{"form-ui", "FormUI", "kebab-to-camel", "UIを含む (form-ui)"},
 		{"user-api-service", "UserAPIService", "kebab-to-camel", "APIを含む (user-api-service)"},
@@ -390,7 +385,7 @@ func runTests() {
 	fmt.Println("キャメルケース → ケバブケース:")
 	for _, tc := range testCases {
 		if tc.Direction == "camel-to-kebab" {
-			result := camelToKebab(tc.Input)
+			result := renamer.CamelToKebab(tc.Input)
 			success := result == tc.ExpectedOut
 			if success {
 				fmt.Printf("✅ %s: %s → %s\n", tc.Desc, formatCamelCase(tc.Input), formatKebabCase(result))
@@ -403,7 +398,7 @@ func runTests() {
 	fmt.Println("\nケバブケース → キャメルケース:")
 	for _, tc := range testCases {
 		if tc.Direction == "kebab-to-camel" {
-			result := kebabToCamel(tc.Input)
+			result := renamer.KebabToCamel(tc.Input)
 			success := result == tc.ExpectedOut
 			if success {
 				fmt.Printf("✅ %s: %s → %s\n", tc.Desc, formatKebabCase(tc.Input), formatCamelCase(result))
@@ -412,15 +407,28 @@ func runTests() {
 			}
 		}
 	}
-	
+
 	fmt.Println("\nテスト完了")
 }
 
 func main() {
+	// サブコマンドが指定されていれば実行
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			os.Exit(command.run(os.Args[2:]))
+		}
+	}
+
+	runInteractive()
+}
+
+// 対話モードで実行
+func runInteractive() {
 	// コマンドラインオプションの処理
 	var debugMode bool
 	flag.BoolVar(&debugMode, "debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
 	flag.BoolVar(&debugMode, "d", false, "デバッグモードを有効にする（短縮オプション）")
+	flag.Usage = printUsage
 	flag.Parse()
 
 	if debugMode {
@@ -436,20 +444,11 @@ func main() {
 	fmt.Println("また、インポートパスも自動的に更新します。")
 	fmt.Println("===================================================")
 
-	// 除外設定ファイルの読み込み
-	excludeConfigPath := getExcludeConfigPath()
-	excludeConfig, err := loadExcludeConfig(excludeConfigPath)
+	// プロジェクトルートの検出と除外設定の読み込み
+	engine, err := newEngine(debugMode)
 	if err != nil {
-		fmt.Printf("警告: 除外設定ファイルの読み込みに失敗しました: %v\n", err)
-		fmt.Println("デフォルトの除外設定を使用します。")
-		excludeConfig = getDefaultExcludeConfig()
-	} else {
-		fmt.Printf("除外設定ファイルを読み込みました: %s\n", excludeConfigPath)
-		if debugMode {
-			fmt.Printf("  除外ファイル: %d 件\n", len(excludeConfig.ExcludeFiles))
-			fmt.Printf("  除外インポート: %d 件\n", len(excludeConfig.ExcludeImports))
-			fmt.Printf("  除外ディレクトリ: %d 件\n", len(excludeConfig.ExcludeDirectories))
-		}
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
 	}
 
 	// テスト実行
@@ -457,15 +456,10 @@ func main() {
 
 	// プロジェクトの解析
 	fmt.Println("\n--- プロジェクト解析中 ---")
-	projectRoot, err := findProjectRoot()
-	if err != nil {
-		fmt.Printf("プロジェクトルートの検出に失敗しました: %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("プロジェクトルート: %s\n\n", projectRoot)
+	fmt.Printf("プロジェクトルート: %s\n\n", engine.Root())
 
 	// プロジェクト構造の解析
-	structure, err := analyzeProjectStructure(projectRoot)
+	structure, err := engine.Analyze()
 	if err != nil {
 		fmt.Printf("プロジェクト構造の解析に失敗しました: %v\n", err)
 		os.Exit(1)
@@ -473,14 +467,14 @@ func main() {
 
 	// ディレクトリ情報の表示
 	fmt.Printf("検出された%s: %d ディレクトリ\n", structure.RootType, len(structure.Directories))
-	
+
 	// 設定の取得
-	config, err := promptForConfig(structure, excludeConfig)
+	config, err := promptForConfig(structure)
 	if err != nil {
 		fmt.Printf("設定の取得に失敗しました: %v\n", err)
 		os.Exit(1)
 	}
-	
+
 	// デバッグモードを設定
 	config.DebugMode = debugMode
 
@@ -503,7 +497,7 @@ func main() {
 	// 処理実行前の最終確認
 	fmt.Println("\n--- 最終確認 ---")
 	fmt.Printf("選択されたディレクトリ: %d ディレクトリ\n", len(config.TargetDirs))
-	
+
 	// 合計ファイル数の計算
 	var totalCamel, totalKebab, totalFiles int
 	for _, dir := range config.TargetDirs {
@@ -512,19 +506,19 @@ func main() {
 		totalKebab += stats.KebabCaseCount
 		totalFiles += stats.TotalFiles
 	}
-	
+
 	// 変換モードに応じて対象ファイル数を表示
 	var targetFileCount int
-	if config.ConversionDirection == "camel-to-kebab" {
+	if config.ConversionDirection == renamer.DirectionCamelToKebab {
 		targetFileCount = totalCamel
-		fmt.Printf("変換対象: %sキャメルケース%s → %sケバブケース%s (%d ファイル)\n", 
+		fmt.Printf("変換対象: %sキャメルケース%s → %sケバブケース%s (%d ファイル)\n",
 			colorYellow, colorReset, colorCyan, colorReset, targetFileCount)
 	} else {
 		targetFileCount = totalKebab
 		fmt.Printf("変換対象: %sケバブケース%s → %sキャメルケース%s (%d ファイル)\n",
 			colorCyan, colorReset, colorYellow, colorReset, targetFileCount)
 	}
-	
+
 	// 合計ファイル数の表示（色付き）
 	fmt.Printf("対象ディレクトリ: %d ディレクトリ [%sキャメル: %d%s, %sケバブ: %d%s, 合計: %d]\n",
 		len(config.TargetDirs),
@@ -532,21 +526,29 @@ func main() {
 		colorCyan, totalKebab, colorReset,
 		totalFiles,
 	)
-	
+
 	// 実行モードの表示
 	if config.DryRun {
 		fmt.Printf("実行モード: %sドライラン%s（ファイルは変更されません）\n", colorPurple, colorReset)
 	} else {
 		fmt.Printf("実行モード: %s本番処理%s（ファイルは実際に変更されます）\n", colorRed, colorReset)
+
+		// コミットされていない変更がある場合は警告する（続行するかは次の確認で選択する）
+		if files, err := dirtyFiles(engine); err != nil {
+			fmt.Printf("%s警告: %v%s\n", colorYellow, err, colorReset)
+		} else if len(files) > 0 {
+			printDirtyFiles(files)
+			fmt.Println("  続行すると、これらの変更と変換による変更が混ざります。")
+		}
 	}
-	
+
 	// デバッグモードの表示
 	if config.DebugMode {
 		fmt.Printf("詳細表示: %sオン%s（詳細情報が表示されます）\n", colorGreen, colorReset)
 	} else {
 		fmt.Printf("詳細表示: %sオフ%s（簡易表示です）\n", colorYellow, colorReset)
 	}
-	
+
 	// ユーザ確認
 	confirmPrompt := promptui.Select{
 		Label: "処理を実行しますか？",
@@ -561,74 +563,85 @@ func main() {
 
 	// 各ディレクトリに対してファイル処理を実行
 	var totalFilesCount, totalProcessedCount, totalSkippedCount, totalErrorCount int
-	var results []ConversionResult
-	
+
 	fmt.Println("\n=== ファイル処理を開始します ===")
-	
-	for _, dir := range config.TargetDirs {
-		dirConfig := config
-		dirConfig.TargetDir = dir
-		result := processFiles(dirConfig)
-		results = append(results, result)
-		
+
+	engine.SetConversionDirection(config.ConversionDirection)
+	engine.SetDryRun(config.DryRun)
+	plan, err := engine.Plan(config.TargetDirs)
+	if err != nil {
+		fmt.Printf("変換計画の作成に失敗しました: %v\n", err)
+		os.Exit(1)
+	}
+	if !engine.Options().BlockUnportable {
+		reportPlanPortability(engine, plan)
+	}
+	results, run, err := engine.ApplyRun(plan)
+	journalPath := saveJournal(engine, run)
+	if err != nil {
+		printApplyError(err)
+		os.Exit(1)
+	}
+
+	for _, result := range results {
 		totalFilesCount += result.TotalFiles
 		totalProcessedCount += result.ProcessedFiles
 		totalSkippedCount += result.SkippedFiles
 		totalErrorCount += result.ErrorFiles
 	}
-	
+
 	// 処理の最終結果を表示
 	fmt.Println("\n=== 最終処理結果 ===")
 	fmt.Printf("処理したディレクトリ数: %d\n", len(config.TargetDirs))
 	fmt.Printf("合計ファイル数: %s%d%s\n", colorCyan, totalFilesCount, colorReset)
-	fmt.Printf("処理したファイル数: %s%d%s (%.1f%%)\n", 
-		colorGreen, 
-		totalProcessedCount, 
+	fmt.Printf("処理したファイル数: %s%d%s (%.1f%%)\n",
+		colorGreen,
+		totalProcessedCount,
 		colorReset,
-		float64(totalProcessedCount) / float64(totalFilesCount) * 100,
+		float64(totalProcessedCount)/float64(totalFilesCount)*100,
 	)
-	fmt.Printf("スキップしたファイル数: %s%d%s (%.1f%%)\n", 
-		colorYellow, 
-		totalSkippedCount, 
+	fmt.Printf("スキップしたファイル数: %s%d%s (%.1f%%)\n",
+		colorYellow,
+		totalSkippedCount,
 		colorReset,
-		float64(totalSkippedCount) / float64(totalFilesCount) * 100,
+		float64(totalSkippedCount)/float64(totalFilesCount)*100,
 	)
-	
+
 	if totalErrorCount > 0 {
-		fmt.Printf("エラーが発生したファイル数: %s%d%s (%.1f%%)\n", 
-			colorRed, 
-			totalErrorCount, 
+		fmt.Printf("エラーが発生したファイル数: %s%d%s (%.1f%%)\n",
+			colorRed,
+			totalErrorCount,
 			colorReset,
-			float64(totalErrorCount) / float64(totalFilesCount) * 100,
+			float64(totalErrorCount)/float64(totalFilesCount)*100,
 		)
 	} else {
 		fmt.Printf("エラーが発生したファイル数: %s%d%s (0.0%%)\n", colorReset, totalErrorCount, colorReset)
 	}
-	
+
 	// インポートパス更新の情報を表示
 	var allImportUpdateFiles []string
 	for _, result := range results {
 		allImportUpdateFiles = append(allImportUpdateFiles, result.ImportUpdateFiles...)
 	}
-	
+
 	// 重複を除去
 	uniqueImportFiles := make(map[string]bool)
 	for _, file := range allImportUpdateFiles {
 		uniqueImportFiles[file] = true
 	}
-	
+
 	// 結果をスライスに変換
 	var uniqueImportUpdateFiles []string
 	for file := range uniqueImportFiles {
 		uniqueImportUpdateFiles = append(uniqueImportUpdateFiles, file)
 	}
-	
+
 	if len(uniqueImportUpdateFiles) > 0 {
-		fmt.Printf("インポートパス更新対象ファイル数: %s%d%s\n", 
-			colorPurple, 
-			len(uniqueImportUpdateFiles), 
+		fmt.Printf("インポートパス更新対象ファイル数: %s%d%s\n",
+			colorPurple,
+			len(uniqueImportUpdateFiles),
 			colorReset)
-		
+
 		// ドライランモードの場合、すべてのインポートパス更新対象ファイルを表示
 		if config.DryRun {
 			fmt.Println("\n--- インポートパス更新対象ファイル（すべてのディレクトリ） ---")
@@ -637,26 +650,31 @@ func main() {
 			}
 		}
 	}
-	
+
 	// 変換方向の情報を表示
 	var directionInfo string
-	if config.ConversionDirection == "camel-to-kebab" {
-		directionInfo = fmt.Sprintf("%sキャメルケース%s → %sケバブケース%s", 
+	if config.ConversionDirection == renamer.DirectionCamelToKebab {
+		directionInfo = fmt.Sprintf("%sキャメルケース%s → %sケバブケース%s",
 			colorBlue, colorReset, colorGreen, colorReset)
 	} else {
-		directionInfo = fmt.Sprintf("%sケバブケース%s → %sキャメルケース%s", 
+		directionInfo = fmt.Sprintf("%sケバブケース%s → %sキャメルケース%s",
 			colorGreen, colorReset, colorBlue, colorReset)
 	}
 	fmt.Printf("変換方向: %s\n", directionInfo)
-	
+
 	// 実行モードの表示
 	if config.DryRun {
-		fmt.Printf("実行モード: %sドライラン%s（実際の変更は行われていません）\n", 
+		fmt.Printf("実行モード: %sドライラン%s（実際の変更は行われていません）\n",
 			colorPurple, colorReset)
 	} else {
-		fmt.Printf("実行モード: %s本番処理%s（変更が適用されました）\n", 
+		fmt.Printf("実行モード: %s本番処理%s（変更が適用されました）\n",
 			colorGreen, colorReset)
 	}
-	
+
+	// すべてのインポートが解決できるか検証する
+	if verifyRun(engine, run, config.TargetDirs, journalPath, rollbackPrompt, config.DebugMode) != 0 {
+		os.Exit(1)
+	}
+
 	fmt.Println("\n処理が完了しました！")
-} 
\ No newline at end of file
+}
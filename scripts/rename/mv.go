@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// mv サブコマンド
+// ファイルまたはディレクトリを移動し、プロジェクト全体の参照を書き換える
+func runMv(args []string) int {
+	fs := flag.NewFlagSet("mv", flag.ExitOnError)
+	debug := fs.Bool("debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	dryRun := fs.Bool("dry-run", false, "ドライラン（ファイルを変更せず、変更予定のみ表示）")
+	diff := fs.Bool("diff", false, "変更予定を git apply で適用できる unified diff で出力する（--dry-run を含む）")
+	gitMode := fs.Bool("git", false, "git で追跡されているファイルを git mv で移動する")
+	force := fs.Bool("force", false, "コミットされていない変更があっても実行する")
+	noVerify := fs.Bool("no-verify", false, "実行後のインポートの検証を行わない")
+	rollback := fs.Bool("rollback", false, "検証で解決できないインポートが見つかった場合に確認せずに元に戻す")
+	var report reportFlags
+	report.register(fs)
+
+	paths := parseArgs(fs, args)
+	if len(paths) != 2 {
+		fmt.Println("使い方: rename-script mv <移動元> <移動先> [オプション]")
+		return 1
+	}
+	if *diff && report.enabled() {
+		fmt.Println("--diff と --format は同時に指定できません")
+		return 1
+	}
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	var diffOutput *os.File
+	if *diff {
+		*dryRun = true
+		diffOutput = redirectStdout()
+	}
+
+	engine, err := newEngine(*debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if *gitMode {
+		if engine, err = withGit(engine); err != nil {
+			fmt.Printf("%v\n", err)
+			return 1
+		}
+	}
+	if !*dryRun {
+		if err := checkWorkingTree(engine, *force); err != nil {
+			fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+			return 1
+		}
+	}
+
+	if err := loadBarrels(engine); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	engine.SetDryRun(*dryRun)
+	result, run, err := engine.Move(paths[0], paths[1])
+	journalPath := saveJournal(engine, run)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+		return 1
+	}
+	if *diff && writeDiff(diffOutput, engine, run) != nil {
+		return 1
+	}
+	if err := report.write(engine.NewMoveReport(result)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	if *dryRun {
+		fmt.Printf("\n移動予定のファイル数: %d（ドライランのため、ファイルは変更されていません）\n", result.Files)
+	} else {
+		fmt.Printf("\n%s移動したファイル数: %d%s\n", colorGreen, result.Files, colorReset)
+	}
+	fmt.Printf("書き換えた参照: %d 件\n", len(result.Rewrites)+len(result.PackageEntries))
+	for _, rewrite := range result.Rewrites {
+		fmt.Printf("  %s\n", rewrite)
+		if *debug {
+			fmt.Printf("      （%s）\n", rewrite.Kind)
+		}
+	}
+	for _, entry := range result.PackageEntries {
+		fmt.Printf("  %s\n", entry)
+	}
+	if len(result.Barrels) > 0 {
+		fmt.Printf("更新したバレル: %d 件\n", len(result.Barrels))
+		fmt.Printf("  %s\n", strings.Join(result.Barrels, "\n  "))
+	}
+	if len(result.Warnings) > 0 {
+		fmt.Printf("\n%s確認が必要な書き換え: %d 件%s\n", colorYellow, len(result.Warnings), colorReset)
+		fmt.Printf("  %s\n", strings.Join(result.Warnings, "\n  "))
+	}
+
+	if *noVerify {
+		return 0
+	}
+	mode := rollbackPrompt
+	if *rollback {
+		mode = rollbackAuto
+	}
+	return verifyRun(engine, run, nil, journalPath, mode, *debug)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"rename-script/renamer"
+)
+
+// normalize-imports サブコマンド
+// 深い相対パスをエイリアスに（またはエイリアスを相対パスに）書き換えて、インポートの書き方を統一する
+func runNormalizeImports(args []string) int {
+	fs := flag.NewFlagSet("normalize-imports", flag.ExitOnError)
+	var flags commonFlags
+	fs.BoolVar(&flags.debug, "debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	fs.StringVar(&flags.dirs, "dirs", "", "対象ディレクトリ（カンマ区切り、省略時はプロジェクト全体）")
+	flags.registerFilter(fs)
+	to := fs.String("to", renamer.NormalizeToAlias, "書き換えの方向（alias: 深い相対パスをエイリアスに / relative: エイリアスを相対パスに）")
+	maxDepth := fs.Int("max-depth", 2, "相対パスで許容する親ディレクトリ（../）の数")
+	sameDir := fs.Bool("same-dir", false, "同じディレクトリ以下のファイルを指すエイリアスを相対パス（./）に書き換える")
+	dryRun := fs.Bool("dry-run", false, "ドライラン（ファイルを変更せず、変更予定のみ表示）")
+	diff := fs.Bool("diff", false, "変更予定を git apply で適用できる unified diff で出力する（--dry-run を含む）")
+	force := fs.Bool("force", false, "コミットされていない変更があっても実行する")
+	noVerify := fs.Bool("no-verify", false, "実行後のインポートの検証を行わない")
+	rollback := fs.Bool("rollback", false, "検証で解決できないインポートが見つかった場合に確認せずに元に戻す")
+	var report reportFlags
+	report.register(fs)
+
+	if positional := parseArgs(fs, args); len(positional) != 0 {
+		fmt.Println("使い方: rename-script normalize-imports [--to alias|relative] [--max-depth <数>] [--same-dir] [オプション]")
+		return 1
+	}
+	if *diff && report.enabled() {
+		fmt.Println("--diff と --format は同時に指定できません")
+		return 1
+	}
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	var diffOutput *os.File
+	if *diff {
+		*dryRun = true
+		diffOutput = redirectStdout()
+	}
+
+	engine, err := newEngine(flags.debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if err := flags.applyFilter(engine); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if !*dryRun {
+		if err := checkWorkingTree(engine, *force); err != nil {
+			fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+			return 1
+		}
+	}
+
+	engine.SetDryRun(*dryRun)
+	opts := renamer.NormalizeOptions{To: *to, MaxDepth: *maxDepth, SameDirRelative: *sameDir}
+	result, run, err := engine.NormalizeImports(opts, splitDirs(flags.dirs))
+	journalPath := saveJournal(engine, run)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+		return 1
+	}
+	if *diff && writeDiff(diffOutput, engine, run) != nil {
+		return 1
+	}
+	if err := report.write(engine.NewNormalizeReport(result)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	if *dryRun {
+		fmt.Printf("\n書き換える予定の指定子: %d 件（%d ファイルを検査、ドライランのため、ファイルは変更されていません）\n", len(result.Rewrites), result.Files)
+	} else {
+		fmt.Printf("\n%s書き換えた指定子: %d 件（%d ファイルを検査）%s\n", colorGreen, len(result.Rewrites), result.Files, colorReset)
+	}
+	for _, rewrite := range result.Rewrites {
+		fmt.Printf("  %s\n", rewrite)
+		if flags.debug {
+			fmt.Printf("      （%s）\n", rewrite.Rule)
+		}
+	}
+
+	if *noVerify || len(result.Rewrites) == 0 {
+		return 0
+	}
+	mode := rollbackPrompt
+	if *rollback {
+		mode = rollbackAuto
+	}
+	return verifyRun(engine, run, nil, journalPath, mode, flags.debug)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"rename-script/renamer"
+)
+
+// 移植性の問題を一覧表示する
+func printPortabilityIssues(title string, issues []renamer.PortabilityIssue, color string) {
+	fmt.Printf("%s%s: %d 件%s\n", color, title, len(issues), colorReset)
+	for _, issue := range issues {
+		fmt.Printf("  %s\n", issue)
+	}
+}
+
+// 変換計画の移植性を検査して問題を表示し、見つかった問題を返す
+func reportPlanPortability(engine *renamer.Engine, plan *renamer.Plan) []renamer.PortabilityIssue {
+	issues, err := engine.AuditPlan(plan)
+	if err != nil {
+		fmt.Printf("%s警告: 移植性の検査に失敗しました: %v%s\n", colorYellow, err, colorReset)
+		return nil
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	color := colorYellow
+	if engine.Options().BlockUnportable {
+		color = colorRed
+	}
+	printPortabilityIssues("変換後に移植性の問題が生じる名前", issues, color)
+	if !engine.Options().BlockUnportable {
+		fmt.Println("  （--block-unportable を指定すると、この計画の実行を拒否します）")
+	}
+	return issues
+}
+
+// 変換の実行エラーを表示する（移植性の問題による中止の場合は問題の一覧も表示する）
+func printApplyError(err error) {
+	var portabilityErr *renamer.PortabilityError
+	if errors.As(err, &portabilityErr) {
+		fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+		printPortabilityIssues("移植性の問題", portabilityErr.Issues, colorRed)
+		return
+	}
+	fmt.Printf("変換の実行に失敗しました: %v\n", err)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"rename-script/renamer"
+)
+
+// rename-package サブコマンド
+// ワークスペースのパッケージ名を変更し、package.json・tsconfig・インポートを書き換える
+func runRenamePackage(args []string) int {
+	fs := flag.NewFlagSet("rename-package", flag.ExitOnError)
+	debug := fs.Bool("debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	dryRun := fs.Bool("dry-run", false, "ドライラン（ファイルを変更せず、変更予定のみ表示）")
+	diff := fs.Bool("diff", false, "変更予定を git apply で適用できる unified diff で出力する（--dry-run を含む）")
+	force := fs.Bool("force", false, "コミットされていない変更があっても実行する")
+	noVerify := fs.Bool("no-verify", false, "実行後のインポートの検証を行わない")
+	rollback := fs.Bool("rollback", false, "検証で解決できないインポートが見つかった場合に確認せずに元に戻す")
+	var report reportFlags
+	report.register(fs)
+
+	names := parseArgs(fs, args)
+	if len(names) != 2 {
+		fmt.Println("使い方: rename-script rename-package <変更前のパッケージ名> <変更後のパッケージ名> [オプション]")
+		return 1
+	}
+	if *diff && report.enabled() {
+		fmt.Println("--diff と --format は同時に指定できません")
+		return 1
+	}
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	var diffOutput *os.File
+	if *diff {
+		*dryRun = true
+		diffOutput = redirectStdout()
+	}
+
+	engine, err := newEngine(*debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if !*dryRun {
+		if err := checkWorkingTree(engine, *force); err != nil {
+			fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+			return 1
+		}
+	}
+
+	engine.SetDryRun(*dryRun)
+	result, run, err := engine.RenamePackage(names[0], names[1])
+	journalPath := saveJournal(engine, run)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+		return 1
+	}
+	if *diff && writeDiff(diffOutput, engine, run) != nil {
+		return 1
+	}
+	if err := report.write(engine.NewPackageRenameReport(result)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	fmt.Printf("\n=== %s → %s（%s）===\n", result.From, result.To, result.Workspace)
+	if *dryRun {
+		fmt.Println("ドライランのため、ファイルは変更されていません。")
+	}
+	printRewrites := func(title string, rewrites []renamer.SpecifierRewrite) {
+		fmt.Printf("\n%s: %d 件\n", title, len(rewrites))
+		for _, rewrite := range rewrites {
+			if rewrite.Kind != "" {
+				fmt.Printf("  %s（%s）\n", rewrite, rewrite.Kind)
+			} else {
+				fmt.Printf("  %s\n", rewrite)
+			}
+		}
+	}
+	printRewrites("package.json", result.Manifests)
+	printRewrites("tsconfig", result.TSConfigs)
+	printRewrites("インポート", result.Imports)
+	if len(result.Remaining) > 0 {
+		fmt.Printf("\n%sインポート以外で %s を参照している箇所: %d 件（書き換えていません）%s\n", colorYellow, result.From, len(result.Remaining), colorReset)
+		for _, mention := range result.Remaining {
+			fmt.Printf("  %s\n", mention)
+		}
+	}
+	if !*dryRun {
+		fmt.Println("\nlockfile を更新するため、パッケージを再インストールしてください。")
+	}
+
+	if *noVerify {
+		return 0
+	}
+	mode := rollbackPrompt
+	if *rollback {
+		mode = rollbackAuto
+	}
+	return verifyRun(engine, run, nil, journalPath, mode, *debug)
+}
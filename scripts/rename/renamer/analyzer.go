@@ -0,0 +1,410 @@
+package renamer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Analyze はプロジェクト構造を分析します
+func (e *Engine) Analyze() (*ProjectStructure, error) {
+	e.printf("プロジェクトルートを検出しました: %s\n", e.root)
+
+	// ルートタイプに基づいてディレクトリをスキャン (findProjectRootの結果を直接使用)
+	rootType, err := e.determineRootType()
+	if err != nil {
+		return nil, fmt.Errorf("ルートタイプの特定に失敗しました: %w", err)
+	}
+
+	dirs, err := e.scanDirectories(rootType)
+	if err != nil {
+		return nil, fmt.Errorf("ディレクトリのスキャンに失敗しました: %w", err)
+	}
+	if len(dirs) == 0 {
+		e.println("変換対象のディレクトリが見つかりませんでした。")
+		// エラーではなく、空の構造体を返すことも検討
+		return &ProjectStructure{RootType: rootType, Directories: []string{}, FileStats: map[string]FileStatistics{}}, nil
+	}
+
+	fileStats := make(map[string]FileStatistics)
+	for _, dir := range dirs {
+		stats := e.analyzeFiles(dir) // analyzeFilesはエラーを返さないので、エラーチェックは不要
+		fileStats[dir] = stats
+	}
+
+	return &ProjectStructure{
+		RootType:    rootType,
+		Directories: dirs,
+		FileStats:   fileStats,
+	}, nil
+}
+
+// ディレクトリをスキャン
+func (e *Engine) scanDirectories(rootType string) ([]string, error) {
+	var dirs []string
+	uniqueDirsMap := make(map[string]bool)
+	projectRoot := e.root
+	e.printf("検索開始: rootType = %s, projectRoot = %s\n", rootType, projectRoot)
+
+	searchPrefixes := []string{}
+	if rootType == "apps" || rootType == "apps/packages" {
+		searchPrefixes = append(searchPrefixes, "apps")
+	}
+	if rootType == "packages" || rootType == "apps/packages" {
+		searchPrefixes = append(searchPrefixes, "packages")
+	}
+
+	for _, prefix := range searchPrefixes {
+		searchPath := filepath.Join(projectRoot, prefix)
+		e.printf("Walking path: %s\n", searchPath)
+		err := e.walkIndexed(searchPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				// ディレクトリが存在しない等のエラーは無視して探索を続ける
+				if os.IsNotExist(err) {
+					return nil
+				}
+				e.printf("filepath.Walk でエラーが発生しました (%s): %v\n", path, err)
+				return err // その他のエラーは処理を中断
+			}
+
+			if info.IsDir() {
+				// 除外対象ディレクトリはスキップ
+				if isExcludedDir(info.Name()) {
+					return filepath.SkipDir
+				}
+
+				// 相対パスを取得
+				relPath, err := filepath.Rel(projectRoot, path)
+				if err != nil {
+					e.printf("相対パスの取得に失敗しました (%s): %v\n", path, err)
+					return err
+				}
+
+				// パターンマッチングを行う
+				isTargetDir := false
+
+				// 従来の components ディレクトリチェック
+				if info.Name() == "components" || strings.HasSuffix(path, "/components") {
+					isTargetDir = true
+				}
+
+				// apps/*/app パターンチェック
+				if strings.HasPrefix(relPath, "apps/") && strings.HasSuffix(relPath, "/app") {
+					parts := strings.Split(relPath, "/")
+					if len(parts) == 3 && parts[2] == "app" {
+						isTargetDir = true
+					}
+				}
+
+				// packages/ui/src パターンチェック
+				if relPath == "packages/ui/src" {
+					isTargetDir = true
+				}
+
+				// Next.js の特殊ディレクトリチェック (除外リストに含まれない限り対象にする)
+				// app/*, pages/* など
+				if strings.HasSuffix(relPath, "/app") ||
+					strings.HasSuffix(relPath, "/pages") ||
+					strings.Contains(relPath, "/app/") ||
+					strings.Contains(relPath, "/pages/") {
+					isExcluded := false
+					for _, pattern := range getDefaultExcludePatterns() {
+						if strings.Contains(relPath, pattern) {
+							isExcluded = true
+							break
+						}
+					}
+					if !isExcluded {
+						isTargetDir = true
+					}
+				}
+
+				// components、features、libs などの一般的なディレクトリも対象に
+				if strings.Contains(relPath, "/components/") ||
+					strings.Contains(relPath, "/features/") ||
+					strings.Contains(relPath, "/libs/") ||
+					strings.Contains(relPath, "/utils/") ||
+					strings.Contains(relPath, "/hooks/") {
+					isTargetDir = true
+				}
+
+				if isTargetDir {
+					// ディレクトリ内に .tsx または .jsx ファイルがあるか確認
+					if e.containsComponentFile(path) {
+						if !uniqueDirsMap[relPath] {
+							dirs = append(dirs, relPath)
+							uniqueDirsMap[relPath] = true
+							e.printf("ディレクトリを追加: %s\n", relPath)
+						}
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			e.printf("ディレクトリ %s の探索中にエラー: %v\n", searchPath, err)
+			// エラーが発生しても、他のプレフィックスの探索は続ける
+			continue
+		}
+	}
+
+	if len(dirs) == 0 {
+		e.println("警告: 対象ディレクトリが見つかりませんでした")
+	}
+
+	// パスをソートして返す (テストの安定性のため)
+	sort.Strings(dirs)
+
+	// 親子関係のあるディレクトリを除外する
+	// 例: apps/web/app が対象なら apps/web/app/components は除外する
+	dirs = e.removeChildDirectories(dirs)
+
+	return dirs, nil
+}
+
+// 親ディレクトリが既に含まれる場合に子ディレクトリを除外する関数
+func (e *Engine) removeChildDirectories(directories []string) []string {
+	if len(directories) <= 1 {
+		return directories
+	}
+
+	// 長さでソートして短いパス（親になる可能性が高い）を先に処理
+	sort.Slice(directories, func(i, j int) bool {
+		return len(directories[i]) < len(directories[j])
+	})
+
+	// 除外されないディレクトリを保持する
+	result := []string{}
+
+	for i, dir := range directories {
+		isChild := false
+
+		// 現在のディレクトリが他のディレクトリの子ディレクトリかチェック
+		for j, parentCandidate := range directories {
+			// 同じディレクトリはスキップ
+			if i == j {
+				continue
+			}
+
+			// dirがparentCandidateの子ディレクトリかどうかをチェック
+			// 例: dir="apps/web/app/components", parentCandidate="apps/web/app"
+			if strings.HasPrefix(dir, parentCandidate+"/") {
+				isChild = true
+				e.printf("除外: %s は %s の子ディレクトリです\n", dir, parentCandidate)
+				break
+			}
+		}
+
+		if !isChild {
+			result = append(result, dir)
+		}
+	}
+
+	// 結果をオリジナルの順序に戻すためにソート
+	sort.Strings(result)
+
+	return result
+}
+
+// ファイル統計を収集
+func (e *Engine) collectFileStatistics(dirs []string) map[string]FileStatistics {
+	stats := make(map[string]FileStatistics)
+	for _, dir := range dirs {
+		stats[dir] = e.analyzeFiles(dir)
+	}
+	return stats
+}
+
+// ディレクトリ内のファイルを分析
+func (e *Engine) analyzeFiles(dir string) FileStatistics {
+	stats := FileStatistics{
+		FilePaths: make([]string, 0),
+	}
+
+	e.printf("ディレクトリ分析中: %s\n", dir)
+
+	fullPath := e.abs(dir)
+
+	// indexファイルとそのディレクトリ名を保存するためのマップ
+	indexFiles := make(map[string]string)
+
+	// 第一段階: 全ファイルを走査してindexファイルを見つける
+	err := e.walkIndexed(fullPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			e.printf("警告: %s の走査中にエラー: %v\n", path, err)
+			return nil
+		}
+
+		if info.IsDir() {
+			if isExcludedDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// index.tsx または index.jsx ファイルを処理
+		if info.Name() == "index.tsx" || info.Name() == "index.jsx" {
+			// ディレクトリ名を取得
+			dirName := filepath.Base(filepath.Dir(path))
+			indexFiles[path] = dirName
+		}
+
+		if strings.HasSuffix(path, ".tsx") || strings.HasSuffix(path, ".jsx") {
+			stats.TotalFiles++
+			stats.FilePaths = append(stats.FilePaths, path)
+
+			// 通常のファイル（index.tsxではない）を処理
+			if filepath.Base(path) != "index.tsx" && filepath.Base(path) != "index.jsx" {
+				baseName := filepath.Base(path)
+				baseName = strings.TrimSuffix(baseName, filepath.Ext(path))
+
+				if IsKebabCase(baseName) {
+					stats.KebabCaseCount++
+					e.printf("  ケバブケース検出: %s\n", baseName)
+				} else if IsCamelCase(baseName) {
+					stats.CamelCaseCount++
+					e.printf("  キャメルケース検出: %s\n", baseName)
+				} else {
+					e.printf("  その他の形式: %s\n", baseName)
+				}
+			}
+		}
+
+		return nil
+	})
+
+	// 第二段階: ディレクトリ型コンポーネントの処理
+	for path, dirName := range indexFiles {
+		e.printf("  ディレクトリ型コンポーネント検出: %s (%s)\n", dirName, path)
+
+		if IsKebabCase(dirName) {
+			stats.KebabCaseCount++
+			e.printf("  ケバブケース検出 (ディレクトリ): %s\n", dirName)
+		} else if IsCamelCase(dirName) {
+			stats.CamelCaseCount++
+			e.printf("  キャメルケース検出 (ディレクトリ): %s\n", dirName)
+		} else {
+			e.printf("  その他の形式 (ディレクトリ): %s\n", dirName)
+		}
+	}
+
+	if err != nil {
+		e.printf("警告: ディレクトリ %s の分析中にエラー: %v\n", dir, err)
+	}
+
+	return stats
+}
+
+// 統計情報を表示
+func (e *Engine) displayProjectStatistics(structure *ProjectStructure) {
+	e.println("\n検出されたディレクトリ構造:")
+
+	var totalCamel, totalKebab int
+
+	for dir, stats := range structure.FileStats {
+		e.printf("\n%s/\n", dir)
+		e.printf("  合計ファイル数: %d\n", stats.TotalFiles)
+		e.printf("  - キャメルケース: %d ファイル\n", stats.CamelCaseCount)
+		e.printf("  - ケバブケース: %d ファイル\n", stats.KebabCaseCount)
+
+		totalCamel += stats.CamelCaseCount
+		totalKebab += stats.KebabCaseCount
+	}
+
+	e.printf("\n全体の統計:\n")
+	e.printf("- キャメルケース: %d ファイル\n", totalCamel)
+	e.printf("- ケバブケース: %d ファイル\n", totalKebab)
+	e.printf("- 合計: %d ファイル\n", totalCamel+totalKebab)
+}
+
+// determineRootType は検出されたルートに基づいてタイプを決定します (analyzeProjectStructureで使用)
+func (e *Engine) determineRootType() (string, error) {
+	appsExists, _ := e.dirExists(filepath.Join(e.root, "apps"))
+	packagesExists, _ := e.dirExists(filepath.Join(e.root, "packages"))
+
+	if appsExists && packagesExists {
+		return "apps/packages", nil
+	} else if appsExists {
+		return "apps", nil
+	} else if packagesExists {
+		return "packages", nil
+	} else {
+		// 通常 findProjectRoot で検出されるため、ここには到達しないはず
+		return "", fmt.Errorf("apps または packages ディレクトリが見つかりません")
+	}
+}
+
+// dirExists はディレクトリが存在するか確認します
+func (e *Engine) dirExists(path string) (bool, error) {
+	info, err := e.fs.Stat(path)
+	if isNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// FindCamelCase は searchDir 以下のキャメルケースの .tsx/.jsx ファイルとディレクトリを検出します
+// appsOnly が true の場合は apps/ と packages/ 配下のみを対象にします
+func (e *Engine) FindCamelCase(searchDir string, appsOnly bool) ([]CamelCaseEntry, error) {
+	var results []CamelCaseEntry
+	totalFiles := 0
+
+	err := e.walkIndexed(e.abs(searchDir), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			e.printf("警告: %s の走査中にエラー: %v\n", path, err)
+			return nil
+		}
+
+		relPath, err := filepath.Rel(e.root, path)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		// apps/ または packages/ 配下だけを検索する場合
+		if appsOnly && info.IsDir() && relPath != "." &&
+			!strings.HasPrefix(relPath+"/", "apps/") && !strings.HasPrefix(relPath+"/", "packages/") {
+			return filepath.SkipDir
+		}
+
+		if info.IsDir() {
+			// 除外対象のディレクトリはスキップ
+			if isExcludedDir(info.Name()) || contains(e.opts.ExcludeDirectories, info.Name()) {
+				return filepath.SkipDir
+			}
+		} else if !strings.HasSuffix(path, ".tsx") && !strings.HasSuffix(path, ".jsx") {
+			// .tsx または .jsx ファイルのみを処理
+			return nil
+		}
+
+		baseName := info.Name()
+		if !info.IsDir() {
+			totalFiles++
+			baseName = strings.TrimSuffix(baseName, filepath.Ext(baseName))
+			// index.tsx や index.jsx は処理しない
+			if baseName == "index" {
+				return nil
+			}
+		}
+
+		if IsCamelCase(baseName) {
+			e.debugf("キャメルケースファイル検出: %s (%s)\n", baseName, path)
+			results = append(results, CamelCaseEntry{
+				Path:     path,
+				FileName: baseName,
+				IsDir:    info.IsDir(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return results, fmt.Errorf("ディレクトリの走査中にエラーが発生しました: %w", err)
+	}
+
+	e.debugf("合計ファイル数: %d, キャメルケースファイル数: %d\n", totalFiles, len(results))
+	return results, nil
+}
@@ -0,0 +1,471 @@
+package renamer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// バレル（兄弟のモジュールを再エクスポートする index.ts）の書式
+const (
+	// 生成したバレルの 1 行目
+	barrelHeader = "// このファイルは rename-script barrels で生成しています。手で書く export は barrel:keep のフェンスの中に書いてください"
+	// 再生成しても残す手書きの部分の開始と終了
+	barrelKeepStart = "// barrel:keep-start"
+	barrelKeepEnd   = "// barrel:keep-end"
+	// 既定のバレルのファイル名
+	defaultBarrelFile = "index.ts"
+)
+
+// 生成した再エクスポートの行
+var barrelExportRegex = regexp.MustCompile(`^export \* from '([^']+)';$`)
+
+// バレルに含めないファイル
+var barrelDefaultExcludes = []string{"*.test.*", "*.spec.*", "*.stories.*", "*.d.ts"}
+
+// BarrelConfig はバレルを生成するディレクトリの設定です
+type BarrelConfig struct {
+	Barrels []BarrelRule `yaml:"barrels"`
+}
+
+// BarrelRule は 1 件のバレルの設定です
+type BarrelRule struct {
+	// バレルを置くディレクトリ（プロジェクトルートからの相対パス、"apps/web/components/*" のような glob も使用できる）
+	Dir string `yaml:"dir"`
+	// バレルのファイル名（省略時は index.ts）
+	File string `yaml:"file"`
+	// 再エクスポートしないファイルやディレクトリの名前のパターン（テスト・ストーリー・型定義は常に除く）
+	Exclude []string `yaml:"exclude"`
+}
+
+// BarrelConfigPath はプロジェクトルートからバレルの設定ファイルのパスを生成します
+func BarrelConfigPath(projectRoot string) string {
+	return filepath.Join(projectRoot, "scripts", "rename", "barrels.yaml")
+}
+
+// LoadBarrelConfig はバレルの設定ファイルを読み込みます
+// ファイルが存在しない場合は nil を返します
+func LoadBarrelConfig(fsys FS, configPath string) (*BarrelConfig, error) {
+	data, err := fsys.ReadFile(configPath)
+	if err != nil {
+		if isNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("バレルの設定の読み込みに失敗しました: %w", err)
+	}
+	var config BarrelConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("バレルの設定の解析に失敗しました: %w", err)
+	}
+	for i, rule := range config.Barrels {
+		if rule.Dir == "" {
+			return nil, fmt.Errorf("バレルの設定 %d 番目に dir がありません", i+1)
+		}
+		if rule.File != "" && (!IsSourceFile(rule.File) || strings.ContainsAny(rule.File, `/\`)) {
+			return nil, fmt.Errorf("バレルの設定 %d 番目の file はソースファイルの名前を指定してください: %s", i+1, rule.File)
+		}
+		for _, pattern := range append([]string{rule.Dir}, rule.Exclude...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("バレルの設定のパターンが不正です: %q", pattern)
+			}
+		}
+	}
+	return &config, nil
+}
+
+// BarrelResult は 1 件のバレルの検査または更新の結果です
+// パスはすべてプロジェクトルートからの相対パス（スラッシュ区切り）です
+type BarrelResult struct {
+	// バレルのファイル
+	File string `json:"file"`
+	// バレルがまだ存在しないかどうか
+	Created bool `json:"created,omitempty"`
+	// 生成した内容と異なるかどうか（check では更新が必要なもの）
+	Outdated bool `json:"outdated,omitempty"`
+	// 追加と削除した再エクスポートの指定子
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	// 存在しないファイルを指している再エクスポート（"行: 指定子" の形式、手書きの部分を含む）
+	Missing []string `json:"missing,omitempty"`
+}
+
+// バレルの現在の内容と生成する内容
+type barrelState struct {
+	result  BarrelResult
+	path    string
+	content []byte
+}
+
+// CheckBarrels は config のバレルを生成する内容と比べ、更新が必要なものと存在しないファイルを指す再エクスポートを検出します
+func (e *Engine) CheckBarrels(config *BarrelConfig) ([]BarrelResult, error) {
+	states, err := e.barrelStates(config, nil)
+	if err != nil {
+		return nil, err
+	}
+	results := []BarrelResult{}
+	for _, state := range states {
+		results = append(results, state.result)
+	}
+	return results, nil
+}
+
+// UpdateBarrels は config のディレクトリのバレルを生成または更新し、barrel:keep のフェンスの中の手書きの部分はそのまま残します
+func (e *Engine) UpdateBarrels(config *BarrelConfig) ([]BarrelResult, *Run, error) {
+	results := []BarrelResult{}
+	run, err := e.Execute("barrels", func(worker *Engine) error {
+		var err error
+		results, err = worker.updateBarrels(config, nil)
+		return err
+	})
+	return results, run, err
+}
+
+// 現在の FS のバレルを更新し、変更したバレルの結果を返す（Missing は更新後も残る手書きの部分の再エクスポート）
+// changed を指定した場合は（リネームや移動の後の更新）、changed のパスを含むディレクトリのバレルだけを対象にし、存在しないディレクトリは無視します
+func (e *Engine) updateBarrels(config *BarrelConfig, changed []string) ([]BarrelResult, error) {
+	states, err := e.barrelStates(config, changed)
+	if err != nil {
+		return nil, err
+	}
+	results := []BarrelResult{}
+	for _, state := range states {
+		if !state.result.Outdated {
+			continue
+		}
+		e.printf("バレルを更新します: %s\n", state.result.File)
+		if err := e.fs.WriteFile(state.path, state.content, 0644); err != nil {
+			return results, fmt.Errorf("%s の書き込みに失敗しました: %w", state.result.File, err)
+		}
+		remaining := make(map[string]bool)
+		for _, ref := range ScanImports(state.content) {
+			remaining[ref.Specifier] = true
+		}
+		state.result.Missing = slices.DeleteFunc(state.result.Missing, func(missing string) bool {
+			_, spec, _ := strings.Cut(missing, ": ")
+			return !remaining[spec]
+		})
+		if len(state.result.Missing) == 0 {
+			state.result.Missing = nil
+		}
+		results = append(results, state.result)
+	}
+	if len(results) > 0 {
+		e.InvalidateIndex()
+	}
+	return results, nil
+}
+
+// ジャーナルに記録したリネームで古くなった可能性のあるバレルを更新し、更新したバレルのファイルを返す
+// バレルの設定がない場合や、リネームしていない場合は何もしない
+func (e *Engine) updateRenamedBarrels() ([]string, error) {
+	journal := e.journal()
+	if e.opts.Barrels == nil || journal == nil {
+		return nil, nil
+	}
+	changed := journal.RenamedPaths()
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	results, err := e.updateBarrels(e.opts.Barrels, changed)
+	var files []string
+	for _, result := range results {
+		files = append(files, result.File)
+	}
+	return files, err
+}
+
+// PlannedBarrels は SetBarrels で指定したバレルのうち、dirPlan のリネームの後に再生成の対象になるもの
+// （ディレクトリがリネームの元または先のパスを含むもの）を返します
+// 実際に書き込むのは、再生成した内容が現在の内容と異なる場合だけです
+func (e *Engine) PlannedBarrels(dirPlan DirPlan) []string {
+	if e.opts.Barrels == nil {
+		return nil
+	}
+	var changed []string
+	for _, rename := range dirPlan.Renames {
+		changed = append(changed, e.Rel(rename.OldPath), e.Rel(rename.NewPath))
+	}
+	targets, _ := e.barrelTargets(e.opts.Barrels, true)
+	var files []string
+	for _, target := range targets {
+		if containsAnyPath(e.Rel(filepath.Dir(target.path)), changed) {
+			files = append(files, e.Rel(target.path))
+		}
+	}
+	return files
+}
+
+// 設定のバレルの状態
+// changed を指定した場合は、changed のパスを含むディレクトリのバレルだけを対象にし、存在しないディレクトリは無視します
+func (e *Engine) barrelStates(config *BarrelConfig, changed []string) ([]barrelState, error) {
+	if config == nil {
+		return nil, nil
+	}
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := e.barrelTargets(config, changed != nil)
+	if err != nil {
+		return nil, err
+	}
+	var states []barrelState
+	for _, target := range targets {
+		if changed != nil && !containsAnyPath(e.Rel(filepath.Dir(target.path)), changed) {
+			continue
+		}
+		state, err := e.barrelState(resolver, target.rule, target.path)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// 設定のバレルのファイルとその設定
+type barrelTarget struct {
+	rule BarrelRule
+	path string
+}
+
+// 設定のすべてのバレルのファイル（skipMissing の場合は存在しないディレクトリを無視する）
+func (e *Engine) barrelTargets(config *BarrelConfig, skipMissing bool) ([]barrelTarget, error) {
+	var targets []barrelTarget
+	seen := make(map[string]bool)
+	for _, rule := range config.Barrels {
+		dirs, err := e.barrelDirs(rule.Dir)
+		if err != nil {
+			if skipMissing {
+				e.debugf("%v\n", err)
+				continue
+			}
+			return nil, err
+		}
+		file := rule.File
+		if file == "" {
+			file = defaultBarrelFile
+		}
+		for _, dir := range dirs {
+			barrel := filepath.Join(dir, file)
+			if seen[barrel] {
+				continue
+			}
+			seen[barrel] = true
+			targets = append(targets, barrelTarget{rule: rule, path: barrel})
+		}
+	}
+	return targets, nil
+}
+
+// paths（スラッシュ区切りの相対パス）のいずれかがディレクトリ dir の配下にあるかどうか
+func containsAnyPath(dir string, paths []string) bool {
+	for _, p := range paths {
+		if dir == "." || strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// 設定のバレルのうち存在するもの
+func (e *Engine) barrelFiles(config *BarrelConfig) []string {
+	var files []string
+	targets, _ := e.barrelTargets(config, true)
+	for _, target := range targets {
+		if exists(e.fs, target.path) {
+			files = append(files, target.path)
+		}
+	}
+	return files
+}
+
+// パターンに一致するディレクトリ（除外するディレクトリを除く）
+func (e *Engine) barrelDirs(pattern string) ([]string, error) {
+	pattern = path.Clean(filepath.ToSlash(pattern))
+	if !strings.ContainsAny(pattern, "*?[") {
+		dir := e.abs(filepath.FromSlash(pattern))
+		if info, err := e.fs.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("バレルのディレクトリが見つかりません: %s", pattern)
+		}
+		return []string{dir}, nil
+	}
+	var dirs []string
+	err := e.walkIndexed(e.root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if p != e.root && (isExcludedDir(info.Name()) || contains(e.opts.ExcludeDirectories, info.Name())) {
+			return filepath.SkipDir
+		}
+		if matched, _ := path.Match(pattern, e.Rel(p)); matched {
+			dirs = append(dirs, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("バレルのディレクトリの検索に失敗しました: %w", err)
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// 1 件のバレルの現在の内容と生成する内容を比べる
+func (e *Engine) barrelState(resolver *Resolver, rule BarrelRule, barrel string) (barrelState, error) {
+	state := barrelState{path: barrel, result: BarrelResult{File: e.Rel(barrel)}}
+	current, err := e.fs.ReadFile(barrel)
+	if err != nil && !isNotExist(err) {
+		return state, fmt.Errorf("%s の読み込みに失敗しました: %w", state.result.File, err)
+	}
+	state.result.Created = err != nil
+
+	generated, kept := parseBarrel(current)
+	keptSpecs := make(map[string]bool)
+	for _, ref := range ScanImports([]byte(strings.Join(kept, "\n"))) {
+		keptSpecs[ref.Specifier] = true
+	}
+	modules, err := e.barrelModules(rule, barrel)
+	if err != nil {
+		return state, err
+	}
+	var exports []string
+	for _, spec := range modules {
+		if !keptSpecs[spec] {
+			exports = append(exports, spec)
+		}
+	}
+	state.content = formatBarrel(exports, kept)
+	state.result.Outdated = !bytes.Equal(current, state.content)
+	for _, spec := range exports {
+		if !slices.Contains(generated, spec) {
+			state.result.Added = append(state.result.Added, spec)
+		}
+	}
+	for _, spec := range generated {
+		if !slices.Contains(exports, spec) {
+			state.result.Removed = append(state.result.Removed, spec)
+		}
+	}
+
+	// 現在のバレルで存在しないファイルを指している再エクスポート
+	for _, ref := range ScanImports(current) {
+		if !strings.HasPrefix(ref.Specifier, ".") {
+			continue
+		}
+		if res := resolver.Resolve(barrel, ref.Specifier); res.Status != ResolveOK {
+			state.result.Missing = append(state.result.Missing, fmt.Sprintf("%d: %s", ref.Line, ref.Specifier))
+		}
+	}
+	return state, nil
+}
+
+// バレルのディレクトリで再エクスポートするモジュールの指定子（"./name" の形式、名前順）
+func (e *Engine) barrelModules(rule BarrelRule, barrel string) ([]string, error) {
+	dir := filepath.Dir(barrel)
+	entries, err := e.fs.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(dir), err)
+	}
+	excluded := func(name string) bool {
+		for _, pattern := range append(append([]string(nil), barrelDefaultExcludes...), rule.Exclude...) {
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+		return false
+	}
+	var modules []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if excluded(name) || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if entry.IsDir() {
+			if isExcludedDir(name) || contains(e.opts.ExcludeDirectories, name) {
+				continue
+			}
+			for _, ext := range sourceExtensions {
+				if exists(e.fs, filepath.Join(dir, name, "index"+ext)) {
+					modules = append(modules, "./"+name)
+					break
+				}
+			}
+			continue
+		}
+		stem := strings.TrimSuffix(name, filepath.Ext(name))
+		if !IsSourceFile(name) || stem == "index" || name == filepath.Base(barrel) {
+			continue
+		}
+		modules = append(modules, "./"+stem)
+	}
+	sort.Strings(modules)
+	return slices.Compact(modules), nil
+}
+
+// バレルの内容を生成した再エクスポートの指定子と、手書きの部分（フェンスを含む行のまとまり）に分ける
+// rename-script で生成していないバレルは、全体を 1 つの手書きの部分とします
+func parseBarrel(content []byte) (generated []string, kept []string) {
+	text := strings.TrimSpace(strings.ReplaceAll(string(content), "\r\n", "\n"))
+	if text == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(text, barrelHeader) {
+		return nil, []string{barrelKeepStart + "\n" + text + "\n" + barrelKeepEnd}
+	}
+	var section []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case section != nil:
+			section = append(section, line)
+			if trimmed == barrelKeepEnd {
+				kept = append(kept, strings.Join(section, "\n"))
+				section = nil
+			}
+		case trimmed == barrelKeepStart:
+			section = []string{line}
+		default:
+			if match := barrelExportRegex.FindStringSubmatch(trimmed); match != nil {
+				generated = append(generated, match[1])
+			}
+		}
+	}
+	if section != nil {
+		// 閉じていないフェンスは末尾までを手書きの部分とする
+		kept = append(kept, strings.Join(append(section, barrelKeepEnd), "\n"))
+	}
+	return generated, kept
+}
+
+// 生成する再エクスポートと手書きの部分からバレルの内容を作成する
+func formatBarrel(exports []string, kept []string) []byte {
+	var b strings.Builder
+	b.WriteString(barrelHeader + "\n")
+	if len(exports) > 0 {
+		b.WriteString("\n")
+		for _, spec := range exports {
+			fmt.Fprintf(&b, "export * from '%s';\n", spec)
+		}
+	}
+	for _, section := range kept {
+		b.WriteString("\n" + section + "\n")
+	}
+	return []byte(b.String())
+}
+
+// rename-script barrels で生成したバレルかどうか
+func (e *Engine) isGeneratedBarrel(file string) bool {
+	content, err := e.fs.ReadFile(file)
+	return err == nil && bytes.HasPrefix(bytes.TrimSpace(content), []byte(barrelHeader))
+}
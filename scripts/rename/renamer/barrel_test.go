@@ -0,0 +1,216 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BarrelTestSuite struct {
+	suite.Suite
+	fs     *MemFS
+	root   string
+	config *BarrelConfig
+}
+
+func (s *BarrelTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"packages/ui/src/hooks/use-mobile.ts":               "",
+		"packages/ui/src/hooks/use-mobile.test.ts":          "",
+		"apps/web/components/forms/TextField.tsx":           "",
+		"apps/web/components/forms/SelectField.tsx":         "",
+		"apps/web/components/forms/SelectField.stories.tsx": "",
+		"apps/web/components/forms/date/index.ts":           "",
+		"apps/web/components/forms/index.ts":                "export { TextField as default } from './TextField';\nexport * from './Legacy';\n",
+	})
+	s.config = &BarrelConfig{Barrels: []BarrelRule{
+		{Dir: "packages/ui/src/hooks"},
+		{Dir: "apps/web/components/form*", Exclude: []string{"date"}},
+	}}
+}
+
+func (s *BarrelTestSuite) newEngine() *Engine {
+	engine, err := NewEngine(s.root, Options{FS: s.fs})
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *BarrelTestSuite) update() []BarrelResult {
+	results, _, err := s.newEngine().UpdateBarrels(s.config)
+	s.Require().NoError(err)
+	return results
+}
+
+func (s *BarrelTestSuite) read(path string) string {
+	content, err := s.fs.ReadFile(filepath.Join(s.root, filepath.FromSlash(path)))
+	s.Require().NoError(err)
+	return string(content)
+}
+
+func (s *BarrelTestSuite) write(path, content string) {
+	s.Require().NoError(s.fs.WriteFile(filepath.Join(s.root, filepath.FromSlash(path)), []byte(content), 0644))
+}
+
+func (s *BarrelTestSuite) TestLoadBarrelConfig() {
+	path := filepath.Join(s.root, "barrels.yaml")
+	config, err := LoadBarrelConfig(s.fs, path)
+	s.NoError(err)
+	s.Nil(config, "設定ファイルがない場合は nil")
+
+	s.write("barrels.yaml", "barrels:\n  - dir: packages/ui/src/hooks\n  - dir: apps/*/components/forms\n    file: index.tsx\n    exclude: ['*.server.ts']\n")
+	config, err = LoadBarrelConfig(s.fs, path)
+	s.Require().NoError(err)
+	s.Equal([]BarrelRule{
+		{Dir: "packages/ui/src/hooks"},
+		{Dir: "apps/*/components/forms", File: "index.tsx", Exclude: []string{"*.server.ts"}},
+	}, config.Barrels)
+
+	for _, invalid := range []string{
+		"barrels:\n  - file: index.ts\n",
+		"barrels:\n  - dir: src\n    file: index.md\n",
+		"barrels:\n  - dir: '[src'\n",
+	} {
+		s.write("barrels.yaml", invalid)
+		_, err = LoadBarrelConfig(s.fs, path)
+		s.Error(err, invalid)
+	}
+}
+
+// バレルを生成する
+func (s *BarrelTestSuite) TestUpdateCreatesBarrel() {
+	results := s.update()
+	s.Require().Len(results, 2)
+	s.Equal(BarrelResult{File: "packages/ui/src/hooks/index.ts", Created: true, Outdated: true, Added: []string{"./use-mobile", "./use-toast"}}, results[0])
+	s.Equal(barrelHeader+"\n\nexport * from './use-mobile';\nexport * from './use-toast';\n", s.read("packages/ui/src/hooks/index.ts"),
+		"テストは再エクスポートしない")
+
+	s.Empty(s.update(), "変更がない場合は書き込まない")
+}
+
+// 生成していない既存のバレルは全体を手書きの部分として残す
+func (s *BarrelTestSuite) TestUpdateAdoptsHandWrittenBarrel() {
+	results := s.update()
+	s.Equal(BarrelResult{
+		File:     "apps/web/components/forms/index.ts",
+		Outdated: true,
+		Added:    []string{"./SelectField"},
+		Missing:  []string{"2: ./Legacy"},
+	}, results[1], "手書きの部分で再エクスポートしているモジュールと除外したディレクトリは生成しない")
+	s.Equal(barrelHeader+"\n\nexport * from './SelectField';\n\n"+
+		barrelKeepStart+"\nexport { TextField as default } from './TextField';\nexport * from './Legacy';\n"+barrelKeepEnd+"\n",
+		s.read("apps/web/components/forms/index.ts"))
+}
+
+// 再生成してもフェンスの中の手書きの部分は残す
+func (s *BarrelTestSuite) TestUpdateKeepsFencedSections() {
+	s.write("packages/ui/src/hooks/index.ts", barrelHeader+"\n\nexport * from './use-toast';\nexport * from './use-old';\n\n"+
+		barrelKeepStart+"\nexport { useMobile as useIsMobile } from './use-mobile';\n"+barrelKeepEnd+"\n")
+	s.write("packages/ui/src/hooks/use-theme.ts", "")
+
+	results := s.update()
+	s.Equal(BarrelResult{File: "packages/ui/src/hooks/index.ts", Outdated: true, Added: []string{"./use-theme"}, Removed: []string{"./use-old"}}, results[0],
+		"存在しなくなったモジュールの再エクスポートは削除する")
+	s.Equal(barrelHeader+"\n\nexport * from './use-theme';\nexport * from './use-toast';\n\n"+
+		barrelKeepStart+"\nexport { useMobile as useIsMobile } from './use-mobile';\n"+barrelKeepEnd+"\n",
+		s.read("packages/ui/src/hooks/index.ts"))
+}
+
+// 更新が必要なバレルと存在しないファイルを指す再エクスポートを検出する
+func (s *BarrelTestSuite) TestCheckBarrels() {
+	s.update()
+	results, err := s.newEngine().CheckBarrels(s.config)
+	s.Require().NoError(err)
+	s.Require().Len(results, 2)
+	s.False(results[0].Outdated)
+	s.Equal([]string{"7: ./Legacy"}, results[1].Missing)
+
+	s.Require().NoError(s.fs.Rename(filepath.Join(s.root, "packages/ui/src/hooks/use-toast.ts"), filepath.Join(s.root, "packages/ui/src/hooks/use-notify.ts")))
+	results, err = s.newEngine().CheckBarrels(s.config)
+	s.Require().NoError(err)
+	s.Equal(BarrelResult{
+		File:     "packages/ui/src/hooks/index.ts",
+		Outdated: true,
+		Added:    []string{"./use-notify"},
+		Removed:  []string{"./use-toast"},
+		Missing:  []string{"4: ./use-toast"},
+	}, results[0])
+	s.Contains(s.read("packages/ui/src/hooks/index.ts"), "'./use-toast'", "検査ではファイルを変更しない")
+
+	_, err = s.newEngine().CheckBarrels(&BarrelConfig{Barrels: []BarrelRule{{Dir: "packages/missing"}}})
+	s.Error(err)
+}
+
+// mv の後にバレルを更新する
+func (s *BarrelTestSuite) TestMoveUpdatesBarrels() {
+	s.update()
+	engine := s.newEngine()
+	engine.SetBarrels(s.config)
+	result, _, err := engine.Move("packages/ui/src/hooks/use-mobile.ts", "packages/ui/src/hooks/use-viewport.ts")
+	s.Require().NoError(err)
+	s.Equal([]string{"packages/ui/src/hooks/index.ts"}, result.Barrels, "参照の書き換えで名前順でなくなったバレルを並べ直す")
+	s.Equal(barrelHeader+"\n\nexport * from './use-toast';\nexport * from './use-viewport';\n", s.read("packages/ui/src/hooks/index.ts"))
+
+	result, _, err = engine.Move("packages/ui/src/hooks/use-viewport.ts", "packages/ui/src/hooks/use-window.ts")
+	s.Require().NoError(err)
+	s.Empty(result.Barrels, "参照の書き換えだけで済む場合はバレルを更新しない")
+}
+
+// apply の後にバレルを更新する
+func (s *BarrelTestSuite) TestApplyUpdatesBarrels() {
+	s.update()
+	engine := s.newEngine()
+	engine.SetBarrels(s.config)
+	engine.SetConversionDirection(DirectionCamelToKebab)
+	plan, err := engine.Plan([]string{"apps/web/components/forms"})
+	s.Require().NoError(err)
+	s.Equal([]string{"apps/web/components/forms/index.ts"}, engine.PlannedBarrels(plan.Dirs[0]))
+	_, err = engine.Apply(plan)
+	s.Require().NoError(err)
+	s.Equal(barrelHeader+"\n\nexport * from './select-field';\n\n"+
+		barrelKeepStart+"\nexport { TextField as default } from './text-field';\nexport * from './Legacy';\n"+barrelKeepEnd+"\n",
+		s.read("apps/web/components/forms/index.ts"), "生成した部分は並べ直し、手書きの部分はインポートの更新だけを反映する")
+}
+
+// リネームしたパスを含まないディレクトリのバレルは apply の後も変更しない
+func (s *BarrelTestSuite) TestApplyLeavesUnrelatedBarrels() {
+	s.write("apps/web/lib/DateLabel.tsx", "export const DateLabel = () => '';\n")
+	s.write("packages/ui/src/hooks/index.ts", "export * from './use-toast';\n")
+	barrels := []string{"apps/web/components/forms/index.ts", "packages/ui/src/hooks/index.ts"}
+	var before []string
+	for _, barrel := range barrels {
+		before = append(before, s.read(barrel))
+	}
+
+	engine := s.newEngine()
+	engine.SetBarrels(s.config)
+	engine.SetConversionDirection(DirectionCamelToKebab)
+	plan, err := engine.Plan([]string{"apps/web/lib"})
+	s.Require().NoError(err)
+	s.Require().Equal(1, plan.RenameCount())
+	s.Empty(engine.PlannedBarrels(plan.Dirs[0]))
+	results, err := engine.Apply(plan)
+	s.Require().NoError(err)
+	s.Empty(results[0].Barrels)
+	for i, barrel := range barrels {
+		s.Equal(before[i], s.read(barrel), "古いバレルでもリネームと関係なければ再生成しない")
+	}
+}
+
+// 生成したバレルの index.tsx はディレクトリ型コンポーネントとして扱わない
+func (s *BarrelTestSuite) TestGeneratedBarrelIsNotComponent() {
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/components/forms/index.tsx":     barrelHeader + "\n",
+		"apps/web/components/user-menu/index.tsx": "export function UserMenu() {}\n",
+	})
+	dirs, err := s.newEngine().findDirectoryComponents(filepath.Join(s.root, "apps", "web", "components"))
+	s.Require().NoError(err)
+	s.Equal([]string{filepath.Join(s.root, "apps", "web", "components", "user-menu")}, dirs)
+}
+
+func TestBarrelSuite(t *testing.T) {
+	suite.Run(t, new(BarrelTestSuite))
+}
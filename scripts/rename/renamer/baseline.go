@@ -0,0 +1,160 @@
+package renamer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ベースラインの形式のバージョン
+const baselineVersion = 1
+
+// Baseline は既知の違反の記録です
+// 記録された違反は検査で失敗とみなさず、解消されたものは記録から削除していきます（ラチェット）
+type Baseline struct {
+	Version int `json:"version"`
+	// 命名規則の違反を記録したときの変換方向
+	Direction string `json:"direction,omitempty"`
+	// 命名規則に違反しているパス（プロジェクトルートからの相対パス、スラッシュ区切り）
+	Naming []string `json:"naming,omitempty"`
+	// 循環しているインポート（ImportCycle.Key の形式）
+	Cycles []string `json:"cycles,omitempty"`
+}
+
+// BaselinePath はプロジェクトルートからベースラインファイルのパスを生成します
+func BaselinePath(projectRoot string) string {
+	return filepath.Join(projectRoot, "scripts", "rename", "baseline.json")
+}
+
+// LoadBaseline はベースラインファイルを読み込みます
+// ファイルが存在しない場合は nil を返します
+func LoadBaseline(fsys FS, path string) (*Baseline, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		if isNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ベースラインの読み込みに失敗しました: %w", err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("ベースラインの解析に失敗しました: %w", err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("対応していないベースラインのバージョンです: %d", b.Version)
+	}
+	return &b, nil
+}
+
+// Save はベースラインをファイルに保存します（差分が読みやすいよう、エントリは並べ替えて 1 行ずつ出力します）
+func (b *Baseline) Save(fsys FS, path string) error {
+	b.Version = baselineVersion
+	sort.Strings(b.Naming)
+	b.Naming = dedupeSorted(b.Naming)
+	sort.Strings(b.Cycles)
+	b.Cycles = dedupeSorted(b.Cycles)
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ベースラインの変換に失敗しました: %w", err)
+	}
+	if err := fsys.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("ベースラインの保存先の作成に失敗しました: %w", err)
+	}
+	if err := fsys.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("ベースラインの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// NewNamingBaseline は命名規則の違反を記録したベースラインを作成します
+func NewNamingBaseline(direction string, violations []NamingViolation) *Baseline {
+	b := &Baseline{Version: baselineVersion, Direction: direction, Naming: []string{}}
+	for _, violation := range violations {
+		b.Naming = append(b.Naming, violation.Path)
+	}
+	return b
+}
+
+// RecordCycles はベースラインの循環しているインポートの記録を cycles で置き換えます
+func (b *Baseline) RecordCycles(cycles []ImportCycle) {
+	b.Cycles = []string{}
+	for _, cycle := range cycles {
+		b.Cycles = append(b.Cycles, cycle.Key())
+	}
+}
+
+// CompareBaseline は dirs（プロジェクトルートからの相対パス）を検査した結果の violations をベースラインと照合し、
+// ベースラインにない新たな違反と、解消されたためベースラインから削除できるパスを返します
+// 検査の範囲外（dirs の外や、変換対象の限定から外れたパス）のエントリは、存在しない場合を除き解消とみなしません
+// ベースラインの変換方向が異なる場合は、すべての違反を新たな違反として返します
+func (e *Engine) CompareBaseline(baseline *Baseline, dirs []string, violations []NamingViolation) (introduced []NamingViolation, fixed []string) {
+	if baseline == nil || baseline.Direction != e.opts.ConversionDirection {
+		return violations, nil
+	}
+
+	known := make(map[string]bool, len(baseline.Naming))
+	for _, path := range baseline.Naming {
+		known[path] = true
+	}
+	violating := make(map[string]bool, len(violations))
+	for _, violation := range violations {
+		violating[violation.Path] = true
+		if !known[violation.Path] {
+			introduced = append(introduced, violation)
+		}
+	}
+
+	for _, path := range baseline.Naming {
+		if violating[path] {
+			continue
+		}
+		abs := e.abs(filepath.FromSlash(path))
+		checked := e.inDirs(path, dirs) && (e.selectedFile(abs) || e.selectedDir(abs))
+		if checked || !exists(e.fs, abs) {
+			fixed = append(fixed, path)
+		}
+	}
+	return introduced, fixed
+}
+
+// Remove はベースラインからパスを削除し、削除した件数を返します
+func (b *Baseline) Remove(paths []string) int {
+	var removed int
+	b.Naming, removed = removeEntries(b.Naming, paths)
+	return removed
+}
+
+// RemoveCycles はベースラインから循環の識別子を削除し、削除した件数を返します
+func (b *Baseline) RemoveCycles(keys []string) int {
+	var removed int
+	b.Cycles, removed = removeEntries(b.Cycles, keys)
+	return removed
+}
+
+// entries から remove に含まれるものを削除し、削除した件数とともに返す
+func removeEntries(entries, remove []string) ([]string, int) {
+	removing := make(map[string]bool, len(remove))
+	for _, entry := range remove {
+		removing[entry] = true
+	}
+	kept := entries[:0]
+	for _, entry := range entries {
+		if !removing[entry] {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, len(entries) - len(kept)
+}
+
+// パス（プロジェクトルートからの相対パス）が dirs のいずれかの配下にあるかどうか
+func (e *Engine) inDirs(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		dir = e.Rel(e.abs(filepath.FromSlash(dir)))
+		if dir == "." || path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
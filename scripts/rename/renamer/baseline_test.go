@@ -0,0 +1,124 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BaselineTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *BaselineTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/components/Button.tsx":       "",
+		"apps/web/components/NavBar/index.tsx": "",
+		"apps/web/lib/LegacyCard.tsx":          "",
+	})
+}
+
+func (s *BaselineTestSuite) newEngine() *Engine {
+	engine, err := NewEngine(s.root, Options{FS: s.fs, ConversionDirection: DirectionCamelToKebab})
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *BaselineTestSuite) paths(violations []NamingViolation) []string {
+	var paths []string
+	for _, v := range violations {
+		paths = append(paths, v.Path)
+	}
+	return paths
+}
+
+func (s *BaselineTestSuite) TestSaveAndLoad() {
+	path := BaselinePath(s.root)
+	baseline, err := LoadBaseline(s.fs, path)
+	s.NoError(err)
+	s.Nil(baseline, "ファイルがない場合は nil")
+
+	engine := s.newEngine()
+	dirs := []string{"apps/web"}
+	violations, err := engine.Check(dirs)
+	s.Require().NoError(err)
+	s.Require().NoError(NewNamingBaseline(DirectionCamelToKebab, violations).Save(s.fs, path))
+
+	data, err := s.fs.ReadFile(path)
+	s.Require().NoError(err)
+	s.Equal(`{
+  "version": 1,
+  "direction": "camel-to-kebab",
+  "naming": [
+    "apps/web/components/Button.tsx",
+    "apps/web/components/NavBar",
+    "apps/web/lib/LegacyCard.tsx"
+  ]
+}
+`, string(data))
+
+	baseline, err = LoadBaseline(s.fs, path)
+	s.Require().NoError(err)
+	s.Len(baseline.Naming, 3)
+}
+
+func (s *BaselineTestSuite) TestCompareBaseline() {
+	engine := s.newEngine()
+	dirs := []string{"apps/web"}
+	violations, err := engine.Check(dirs)
+	s.Require().NoError(err)
+	baseline := NewNamingBaseline(DirectionCamelToKebab, violations)
+
+	// 既知の違反だけの場合は新たな違反はない
+	introduced, fixed := engine.CompareBaseline(baseline, dirs, violations)
+	s.Empty(introduced)
+	s.Empty(fixed)
+
+	// 新たな違反を追加し、既知の違反を 1 件修正する
+	s.Require().NoError(s.fs.WriteFile(filepath.Join(s.root, "apps/web/components/NewCard.tsx"), nil, 0644))
+	s.Require().NoError(s.fs.Rename(filepath.Join(s.root, "apps/web/components/Button.tsx"), filepath.Join(s.root, "apps/web/components/button.tsx")))
+	engine.InvalidateIndex()
+	violations, err = engine.Check(dirs)
+	s.Require().NoError(err)
+	introduced, fixed = engine.CompareBaseline(baseline, dirs, violations)
+	s.Equal([]string{"apps/web/components/NewCard.tsx"}, s.paths(introduced))
+	s.Equal([]string{"apps/web/components/Button.tsx"}, fixed)
+
+	s.Equal(1, baseline.Remove(fixed))
+	s.Equal([]string{"apps/web/components/NavBar", "apps/web/lib/LegacyCard.tsx"}, baseline.Naming)
+
+	// 変換方向が異なるベースラインは使用しない
+	engine.SetConversionDirection(DirectionKebabToCamel)
+	introduced, fixed = engine.CompareBaseline(baseline, dirs, violations)
+	s.Len(introduced, len(violations))
+	s.Empty(fixed)
+}
+
+func (s *BaselineTestSuite) TestCompareBaselinePartialCheck() {
+	engine := s.newEngine()
+	violations, err := engine.Check([]string{"apps/web"})
+	s.Require().NoError(err)
+	baseline := NewNamingBaseline(DirectionCamelToKebab, violations)
+
+	// 検査の範囲外のエントリは解消とみなさない
+	engine.SetOnlyFiles([]string{"apps/web/components/Button.tsx"})
+	violations, err = engine.Check([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	introduced, fixed := engine.CompareBaseline(baseline, []string{"apps/web/components"}, violations)
+	s.Empty(introduced)
+	s.Empty(fixed)
+
+	// 存在しないエントリは範囲外でも解消とみなす
+	s.Require().NoError(s.fs.Remove(filepath.Join(s.root, "apps/web/lib/LegacyCard.tsx")))
+	_, fixed = engine.CompareBaseline(baseline, []string{"apps/web/components"}, violations)
+	s.Equal([]string{"apps/web/lib/LegacyCard.tsx"}, fixed)
+}
+
+func TestBaselineSuite(t *testing.T) {
+	suite.Run(t, new(BaselineTestSuite))
+}
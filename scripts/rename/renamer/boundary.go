@@ -0,0 +1,250 @@
+package renamer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ワークスペースの境界の違反の種類
+const (
+	// 許可されていないワークスペースのインポート
+	BoundaryImport = "import"
+	// ワークスペースの外を指す tsconfig の include
+	BoundaryInclude = "tsconfig-include"
+)
+
+// BoundaryRules はワークスペース間のインポートの規則です
+type BoundaryRules struct {
+	Rules []BoundaryRule `yaml:"rules"`
+}
+
+// BoundaryRule は from に一致するワークスペースがインポートできるワークスペースの規則です
+// パターンはワークスペースのディレクトリ（"apps/*" など）またはパッケージ名（"@kit/*" など）に一致する glob で、
+// どのワークスペースにも含まれないファイルは "." に、"*" だけのパターンはすべてに一致します
+type BoundaryRule struct {
+	From string `yaml:"from"`
+	// インポートできるワークスペース（指定した場合は、一致しないワークスペースのインポートを違反とする）
+	Allow []string `yaml:"allow"`
+	// インポートできないワークスペース
+	Deny []string `yaml:"deny"`
+}
+
+// String は規則を "from → allow / !deny" の形式で表します
+func (r BoundaryRule) String() string {
+	var targets []string
+	targets = append(targets, r.Allow...)
+	for _, deny := range r.Deny {
+		targets = append(targets, "!"+deny)
+	}
+	return fmt.Sprintf("%s → %s", r.From, strings.Join(targets, ", "))
+}
+
+// BoundaryViolation はワークスペースの境界の違反です
+type BoundaryViolation struct {
+	// 違反の種類（BoundaryImport / BoundaryInclude）
+	Kind string `json:"kind"`
+	// インポートするファイルまたは tsconfig.json（プロジェクトルートからの相対パス、スラッシュ区切り）
+	File string `json:"file"`
+	// 1 から始まる行番号（include の場合は 0）
+	Line int `json:"line,omitempty"`
+	// モジュール指定子または include のエントリ
+	Specifier string `json:"specifier"`
+	// インポートされるファイルまたは include の指す先（プロジェクトルートからの相対パス、スラッシュ区切り）
+	Target string `json:"target"`
+	// インポートするワークスペースとされるワークスペースのディレクトリ（ワークスペースの外の場合は "."）
+	FromWorkspace string `json:"fromWorkspace"`
+	ToWorkspace   string `json:"toWorkspace"`
+	// 違反した規則（include の場合は空）
+	Rule string `json:"rule,omitempty"`
+}
+
+// Message は違反の内容を表します
+func (v BoundaryViolation) Message() string {
+	if v.Kind == BoundaryInclude {
+		return fmt.Sprintf("include '%s' は %s の外（%s）を指しています", v.Specifier, v.FromWorkspace, v.Target)
+	}
+	return fmt.Sprintf("'%s' は %s から %s へのインポートです（規則: %s）", v.Specifier, v.FromWorkspace, v.ToWorkspace, v.Rule)
+}
+
+// String は "ファイル:行: 内容" の形式で違反を表します
+func (v BoundaryViolation) String() string {
+	if v.Line == 0 {
+		return fmt.Sprintf("%s: %s", v.File, v.Message())
+	}
+	return fmt.Sprintf("%s:%d: %s", v.File, v.Line, v.Message())
+}
+
+// BoundaryRulesPath はプロジェクトルートから境界の規則ファイルのパスを生成します
+func BoundaryRulesPath(projectRoot string) string {
+	return filepath.Join(projectRoot, "scripts", "rename", "boundaries.yaml")
+}
+
+// LoadBoundaryRules は境界の規則ファイルを読み込みます
+// ファイルが存在しない場合は nil を返します
+func LoadBoundaryRules(fsys FS, rulesPath string) (*BoundaryRules, error) {
+	data, err := fsys.ReadFile(rulesPath)
+	if err != nil {
+		if isNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("境界の規則の読み込みに失敗しました: %w", err)
+	}
+	var rules BoundaryRules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("境界の規則の解析に失敗しました: %w", err)
+	}
+	for i, rule := range rules.Rules {
+		if rule.From == "" {
+			return nil, fmt.Errorf("境界の規則 %d 番目に from がありません", i+1)
+		}
+		for _, pattern := range append(append([]string{rule.From}, rule.Allow...), rule.Deny...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("境界の規則のパターンが不正です: %q", pattern)
+			}
+		}
+	}
+	return &rules, nil
+}
+
+// ワークスペースがパターンに一致するかどうか
+func matchWorkspace(pattern string, ws *Workspace) bool {
+	if pattern == "*" {
+		return true
+	}
+	if ws == nil {
+		return pattern == "."
+	}
+	if matched, _ := path.Match(pattern, ws.Dir); matched {
+		return true
+	}
+	matched, _ := path.Match(pattern, ws.Name)
+	return ws.Name != "" && matched
+}
+
+// Check は from から to へのインポートが規則に違反する場合、違反した規則を返します
+// 同じワークスペースの中のインポートと、from に一致する規則がない場合は違反としません
+func (r *BoundaryRules) Check(from, to *Workspace) (BoundaryRule, bool) {
+	if from == to {
+		return BoundaryRule{}, false
+	}
+	for _, rule := range r.Rules {
+		if !matchWorkspace(rule.From, from) {
+			continue
+		}
+		for _, deny := range rule.Deny {
+			if matchWorkspace(deny, to) {
+				return rule, true
+			}
+		}
+		if len(rule.Allow) == 0 {
+			continue
+		}
+		allowed := false
+		for _, allow := range rule.Allow {
+			if matchWorkspace(allow, to) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return rule, true
+		}
+	}
+	return BoundaryRule{}, false
+}
+
+// ワークスペースのディレクトリ（ワークスペースの外の場合は "."）
+func workspaceDir(ws *Workspace) string {
+	if ws == nil {
+		return "."
+	}
+	return ws.Dir
+}
+
+// CheckBoundaries はプロジェクト全体のインポートを rules と照合し、許可されていないワークスペースへのインポートと、
+// ワークスペースの tsconfig.json の include のうちワークスペースの外を指すものを返します
+// rules が nil の場合は include だけを検査します
+func (e *Engine) CheckBoundaries(rules *BoundaryRules) ([]BoundaryViolation, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []BoundaryViolation
+	if rules != nil && len(rules.Rules) > 0 {
+		graph, err := e.BuildGraph()
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range graph.Edges {
+			if !edge.CrossWorkspace {
+				continue
+			}
+			from := resolver.WorkspaceOf(e.abs(filepath.FromSlash(edge.From)))
+			to := resolver.WorkspaceOf(e.abs(filepath.FromSlash(edge.To)))
+			rule, violated := rules.Check(from, to)
+			if !violated {
+				continue
+			}
+			violations = append(violations, BoundaryViolation{
+				Kind:          BoundaryImport,
+				File:          edge.From,
+				Line:          edge.Line,
+				Specifier:     edge.Specifier,
+				Target:        edge.To,
+				FromWorkspace: workspaceDir(from),
+				ToWorkspace:   workspaceDir(to),
+				Rule:          rule.String(),
+			})
+		}
+	}
+
+	includes, err := e.outOfWorkspaceIncludes(resolver)
+	if err != nil {
+		return nil, err
+	}
+	violations = append(violations, includes...)
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+	return violations, nil
+}
+
+// ワークスペースの tsconfig.json の include のうち、ワークスペースの外を指すもの
+func (e *Engine) outOfWorkspaceIncludes(resolver *Resolver) ([]BoundaryViolation, error) {
+	var violations []BoundaryViolation
+	for _, ws := range resolver.Workspaces() {
+		configPath := filepath.Join(ws.Path, "tsconfig.json")
+		if !exists(e.fs, configPath) {
+			continue
+		}
+		config, err := LoadTSConfig(e.fs, configPath, resolver.Workspaces())
+		if err != nil {
+			return nil, err
+		}
+		for _, include := range config.Include {
+			target := filepath.Join(ws.Path, filepath.FromSlash(include))
+			if target == ws.Path || strings.HasPrefix(target, ws.Path+string(filepath.Separator)) {
+				continue
+			}
+			to := resolver.WorkspaceOf(target)
+			violations = append(violations, BoundaryViolation{
+				Kind:          BoundaryInclude,
+				File:          e.Rel(configPath),
+				Specifier:     include,
+				Target:        e.Rel(target),
+				FromWorkspace: ws.Dir,
+				ToWorkspace:   workspaceDir(to),
+			})
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,121 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BoundaryTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *BoundaryTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"package.json": `{"name": "root", "workspaces": ["apps/*", "packages/*", "packages/features/*", "tooling/*"]}`,
+
+		"apps/web/tsconfig.json": `{
+  "extends": "@kit/tsconfig/base.json",
+  "include": ["**/*.ts", "**/*.tsx", "../admin/lib/server/skills.ts", "../../packages/ui/src/custom/theme-toggle.tsx"],
+}`,
+		"apps/web/app/page.tsx": "import { PageHeader } from '@kit/ui/page-header';\n" +
+			"import { skills } from '../../admin/lib/server/skills';\n" +
+			"import { utils } from '../lib/utils';\n",
+		"apps/web/lib/auth.ts": "import { LoginDialog } from '@kit/auth';\n",
+
+		"apps/admin/package.json":          `{"name": "admin"}`,
+		"apps/admin/lib/server/skills.ts":  "",
+		"apps/admin/lib/server/gemini.tsx": "import { PageHeader } from '@kit/ui/page-header';\n",
+
+		"packages/ui/src/custom/theme-toggle.tsx": "import { useSession } from '@kit/auth';\n",
+		"packages/ui/src/lib/utils.ts":            "import { x } from '@kit/shared';\n",
+
+		"packages/features/auth/package.json": `{"name": "@kit/auth", "main": "./src/index.ts"}`,
+		"packages/features/auth/src/index.ts": "import { PageHeader } from '@kit/ui/page-header';\n",
+	})
+}
+
+func (s *BoundaryTestSuite) rules(yaml string) *BoundaryRules {
+	path := filepath.Join(s.root, "boundaries.yaml")
+	s.Require().NoError(s.fs.WriteFile(path, []byte(yaml), 0o644))
+	rules, err := LoadBoundaryRules(s.fs, path)
+	s.Require().NoError(err)
+	return rules
+}
+
+func (s *BoundaryTestSuite) check(rules *BoundaryRules) []string {
+	engine, err := NewEngine(s.root, Options{FS: s.fs})
+	s.Require().NoError(err)
+	violations, err := engine.CheckBoundaries(rules)
+	s.Require().NoError(err)
+	var result []string
+	for _, violation := range violations {
+		result = append(result, violation.String())
+	}
+	return result
+}
+
+func (s *BoundaryTestSuite) TestLoadBoundaryRules() {
+	rules, err := LoadBoundaryRules(s.fs, filepath.Join(s.root, "missing.yaml"))
+	s.NoError(err)
+	s.Nil(rules, "規則ファイルがない場合は nil")
+
+	path := filepath.Join(s.root, "invalid.yaml")
+	s.Require().NoError(s.fs.WriteFile(path, []byte("rules:\n  - allow: [\"packages/*\"]\n"), 0o644))
+	_, err = LoadBoundaryRules(s.fs, path)
+	s.Error(err, "from のない規則はエラー")
+
+	s.Require().NoError(s.fs.WriteFile(path, []byte("rules:\n  - from: \"apps/[\"\n"), 0o644))
+	_, err = LoadBoundaryRules(s.fs, path)
+	s.Error(err, "不正なパターンはエラー")
+}
+
+func (s *BoundaryTestSuite) TestCheck() {
+	rules := s.rules(`
+rules:
+  - from: "apps/*"
+    allow: ["packages/*", "packages/features/*", "tooling/*"]
+  - from: "@kit/ui"
+    deny: ["packages/features/*"]
+`)
+	s.Equal([]string{
+		"apps/web/app/page.tsx:2: '../../admin/lib/server/skills' は apps/web から apps/admin へのインポートです（規則: apps/* → packages/*, packages/features/*, tooling/*）",
+		"apps/web/tsconfig.json: include '../admin/lib/server/skills.ts' は apps/web の外（apps/admin/lib/server/skills.ts）を指しています",
+		"apps/web/tsconfig.json: include '../../packages/ui/src/custom/theme-toggle.tsx' は apps/web の外（packages/ui/src/custom/theme-toggle.tsx）を指しています",
+		"packages/ui/src/custom/theme-toggle.tsx:1: '@kit/auth' は packages/ui から packages/features/auth へのインポートです（規則: @kit/ui → !packages/features/*）",
+	}, s.check(rules), "許可されたインポート・同じワークスペースの中のインポート・規則のないワークスペースのインポートは報告しない")
+}
+
+func (s *BoundaryTestSuite) TestWithoutRules() {
+	s.Equal([]string{
+		"apps/web/tsconfig.json: include '../admin/lib/server/skills.ts' は apps/web の外（apps/admin/lib/server/skills.ts）を指しています",
+		"apps/web/tsconfig.json: include '../../packages/ui/src/custom/theme-toggle.tsx' は apps/web の外（packages/ui/src/custom/theme-toggle.tsx）を指しています",
+	}, s.check(nil), "規則がない場合は tsconfig の include だけを検査する")
+}
+
+func (s *BoundaryTestSuite) TestRuleCheck() {
+	web := &Workspace{Name: "web", Dir: "apps/web"}
+	ui := &Workspace{Name: "@kit/ui", Dir: "packages/ui"}
+	rules := &BoundaryRules{Rules: []BoundaryRule{{From: "apps/*", Allow: []string{"packages/*"}}, {From: ".", Deny: []string{"*"}}}}
+
+	_, violated := rules.Check(web, ui)
+	s.False(violated)
+	_, violated = rules.Check(web, web)
+	s.False(violated, "同じワークスペースの中は常に許可")
+	_, violated = rules.Check(web, nil)
+	s.True(violated, "ワークスペースの外は allow の \".\" に一致しない")
+	_, violated = rules.Check(nil, ui)
+	s.True(violated, "ワークスペースの外のファイルは \".\" に一致する")
+	_, violated = rules.Check(ui, web)
+	s.False(violated, "規則のないワークスペースは検査しない")
+}
+
+func TestBoundarySuite(t *testing.T) {
+	suite.Run(t, new(BoundaryTestSuite))
+}
@@ -0,0 +1,126 @@
+package renamer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// NamingViolation は命名規則に違反しているファイルまたはディレクトリ型コンポーネントです
+type NamingViolation struct {
+	// プロジェクトルートからの相対パス（スラッシュ区切り）
+	Path string `json:"path"`
+	// 命名規則に従った名前に変更した場合のパス
+	Suggested string `json:"suggested"`
+	// ディレクトリ型コンポーネントかどうか
+	IsDir bool `json:"isDir,omitempty"`
+	// 推奨される名前のファイルまたはディレクトリが既に存在するかどうか（apply では変換できないため手で解決する）
+	Collision bool `json:"collision,omitempty"`
+}
+
+// String は "パス → 推奨されるパス" の形式で違反を表します
+func (v NamingViolation) String() string {
+	var text string
+	if v.IsDir {
+		text = fmt.Sprintf("%s/ → %s/", v.Path, v.Suggested)
+	} else {
+		text = fmt.Sprintf("%s → %s", v.Path, v.Suggested)
+	}
+	if v.Collision {
+		text += "（既に存在します）"
+	}
+	return text
+}
+
+// Check は dirs（プロジェクトルートからの相対パス）配下のファイル名とディレクトリ型コンポーネントの名前が、
+// 設定された変換方向（ConversionDirection）の命名規則に従っているかを検査します
+// 変換計画と違い、除外するインポート（ExcludeImportPatterns）を含むファイルも検査し、
+// 変換後の名前が既に存在するものも Collision として報告します（除外パターンと除外ディレクトリ、変換対象の限定は反映します）
+func (e *Engine) Check(dirs []string) ([]NamingViolation, error) {
+	if e.opts.ConversionDirection != DirectionCamelToKebab && e.opts.ConversionDirection != DirectionKebabToCamel {
+		return nil, fmt.Errorf("変換方向が不正です: %q", e.opts.ConversionDirection)
+	}
+
+	seen := make(map[string]bool)
+	var violations []NamingViolation
+	add := func(oldPath, newName string, isDir bool) {
+		rel := e.Rel(oldPath)
+		if seen[rel] {
+			return
+		}
+		seen[rel] = true
+		newPath := filepath.Join(filepath.Dir(oldPath), newName)
+		violation := NamingViolation{Path: rel, Suggested: e.Rel(newPath), IsDir: isDir}
+		if newName != filepath.Base(oldPath) {
+			violation.Collision = e.hasEntry(filepath.Dir(oldPath), newName)
+		}
+		violations = append(violations, violation)
+	}
+	for _, dir := range dirs {
+		root := e.abs(dir)
+		files, err := e.findTsxJsxFiles(root)
+		if err != nil {
+			return nil, fmt.Errorf("ファイル検索中にエラーが発生しました (%s): %w", dir, err)
+		}
+		dirComponents, err := e.findDirectoryComponents(root)
+		if err != nil {
+			return nil, fmt.Errorf("ディレクトリ型コンポーネント検索中にエラーが発生しました (%s): %w", dir, err)
+		}
+		if e.opts.OnlyFiles != nil {
+			files = filterPaths(files, e.selectedFile)
+			dirComponents = filterPaths(dirComponents, e.selectedDir)
+		}
+
+		for _, file := range files {
+			name := filepath.Base(file)
+			if name == "index.tsx" || name == "index.jsx" || e.excludedFileName(name) {
+				continue
+			}
+			ext := filepath.Ext(name)
+			if newBase, ok := e.conventionalName(strings.TrimSuffix(name, ext)); ok {
+				add(file, newBase+ext, false)
+			}
+		}
+		for _, dirPath := range dirComponents {
+			name := filepath.Base(dirPath)
+			if e.excludedFileName(name) {
+				continue
+			}
+			if newName, ok := e.conventionalName(name); ok {
+				add(dirPath, newName, true)
+			}
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Path < violations[j].Path })
+	return violations, nil
+}
+
+// ディレクトリに name と大文字小文字まで一致するエントリがあるかどうか
+// 大文字小文字を区別しないファイルシステムでも、Button.tsx → button.tsx のような変換を衝突とみなさないよう一覧で比べる
+func (e *Engine) hasEntry(dir, name string) bool {
+	entries, err := e.fs.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// 名前が変換方向の変換元の命名規則に一致する場合は、変換後の名前を返す
+func (e *Engine) conventionalName(name string) (string, bool) {
+	if e.opts.ConversionDirection == DirectionCamelToKebab {
+		if !IsCamelCase(name) {
+			return "", false
+		}
+		return camelToKebab(name, e.opts.PreserveAcronymCase), true
+	}
+	if !IsKebabCase(name) {
+		return "", false
+	}
+	return KebabToCamel(name), true
+}
@@ -0,0 +1,102 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CheckTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *CheckTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/components/Button.tsx":          "",
+		"apps/web/components/user-card.tsx":       "",
+		"apps/web/components/NavBar/index.tsx":    "",
+		"apps/web/components/page.tsx":            "",
+		"apps/web/components/forms/LoginForm.tsx": "",
+	})
+}
+
+func (s *CheckTestSuite) newEngine(direction string) *Engine {
+	engine, err := NewEngine(s.root, Options{
+		FS:                  s.fs,
+		ConversionDirection: direction,
+		ExcludePatterns:     []string{"page.tsx"},
+	})
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *CheckTestSuite) TestCheck() {
+	violations, err := s.newEngine(DirectionCamelToKebab).Check([]string{"apps/web/components"})
+	s.Require().NoError(err)
+
+	var lines []string
+	for _, v := range violations {
+		lines = append(lines, v.String())
+	}
+	s.Equal([]string{
+		"apps/web/components/Button.tsx → apps/web/components/button.tsx",
+		"apps/web/components/NavBar/ → apps/web/components/nav-bar/",
+		"apps/web/components/forms/LoginForm.tsx → apps/web/components/forms/login-form.tsx",
+	}, lines)
+
+	// 逆方向の規則
+	violations, err = s.newEngine(DirectionKebabToCamel).Check([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	s.Require().Len(violations, 1)
+	s.Equal("apps/web/components/UserCard.tsx", violations[0].Suggested)
+}
+
+// 変換計画と違い、除外するインポートを含むファイルと変換後の名前が既に存在するファイルも報告する
+func (s *CheckTestSuite) TestCheckIgnoresPlannerSkips() {
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/components/IconButton.tsx": "import { Button } from '@kit/ui/button';\n",
+		"apps/web/components/Card.tsx":       "",
+		"apps/web/components/card.tsx":       "",
+	})
+	engine, err := NewEngine(s.root, Options{
+		FS:                    s.fs,
+		ConversionDirection:   DirectionCamelToKebab,
+		ExcludePatterns:       []string{"page.tsx"},
+		ExcludeImportPatterns: []string{"@kit/ui"},
+	})
+	s.Require().NoError(err)
+	violations, err := engine.Check([]string{"apps/web/components"})
+	s.Require().NoError(err)
+
+	byPath := make(map[string]NamingViolation)
+	for _, v := range violations {
+		byPath[v.Path] = v
+	}
+	s.Contains(byPath, "apps/web/components/IconButton.tsx", "@kit/ui をインポートしていても報告する")
+	s.False(byPath["apps/web/components/IconButton.tsx"].Collision)
+	s.True(byPath["apps/web/components/Card.tsx"].Collision, "変換後の名前が既に存在する")
+	s.Equal("apps/web/components/Card.tsx → apps/web/components/card.tsx（既に存在します）", byPath["apps/web/components/Card.tsx"].String())
+}
+
+func (s *CheckTestSuite) TestCheckOnlyFiles() {
+	engine := s.newEngine(DirectionCamelToKebab)
+	engine.SetOnlyFiles([]string{"apps/web/components/forms/LoginForm.tsx", "apps/web/components/user-card.tsx"})
+	violations, err := engine.Check([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	s.Require().Len(violations, 1)
+	s.Equal("apps/web/components/forms/LoginForm.tsx", violations[0].Path)
+}
+
+func (s *CheckTestSuite) TestCheckInvalidDirection() {
+	_, err := s.newEngine("").Check([]string{"apps/web/components"})
+	s.Error(err)
+}
+
+func TestCheckSuite(t *testing.T) {
+	suite.Run(t, new(CheckTestSuite))
+}
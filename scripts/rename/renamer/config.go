@@ -0,0 +1,128 @@
+package renamer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 除外設定ファイルの構造体
+type ExcludeConfig struct {
+	ExcludeFiles       []string `yaml:"exclude_files"`
+	ExcludeImports     []string `yaml:"exclude_imports"`
+	ExcludeDirectories []string `yaml:"exclude_directories"`
+	// 移植性の検査の設定
+	Portability PortabilityConfig `yaml:"portability"`
+	// 命名規則の設定
+	Naming NamingConfig `yaml:"naming"`
+	// 未使用のモジュールの検出の設定
+	Unused UnusedConfig `yaml:"unused"`
+	// 循環しているインポートの検査の設定
+	Cycles CyclesConfig `yaml:"cycles"`
+}
+
+// 未使用のモジュールの検出の設定
+type UnusedConfig struct {
+	// インポートされていなくても報告しないファイルのパターン（ファイル名または相対パスの glob、"/" で終わる場合はディレクトリ）
+	Allow []string `yaml:"allow"`
+}
+
+// 循環しているインポートの検査の設定
+type CyclesConfig struct {
+	// check コマンドで循環しているインポートを検査する
+	Check bool `yaml:"check"`
+	// 型のみのインポート（import type / export type）を無視する
+	IgnoreTypeOnly bool `yaml:"ignore_type_only"`
+}
+
+// 命名規則の設定
+type NamingConfig struct {
+	// check コマンドで検査する変換方向（camel-to-kebab: ケバブケースに統一、kebab-to-camel: キャメルケースに統一）
+	Direction string `yaml:"direction"`
+}
+
+// 移植性の検査の設定
+type PortabilityConfig struct {
+	// パスの長さの上限（プロジェクトルートからの相対パスの文字数）
+	MaxPathLength int `yaml:"max_path_length"`
+	// ファイル名の長さの上限（バイト数）
+	MaxNameLength int `yaml:"max_name_length"`
+	// 移植性の問題を生じる変換計画の実行を拒否するかどうか
+	Block bool `yaml:"block"`
+}
+
+// LoadExcludeConfig は除外設定ファイルを読み込みます
+func LoadExcludeConfig(path string) (*ExcludeConfig, error) {
+	// ファイルが存在するか確認
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// ファイルが存在しない場合はデフォルト設定を返す
+		return DefaultExcludeConfig(), nil
+	}
+
+	// ファイルを読み込む
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+	}
+
+	// YAMLを解析
+	var config ExcludeConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("設定ファイルの解析に失敗しました: %w", err)
+	}
+
+	return &config, nil
+}
+
+// DefaultExcludeConfig はデフォルトの除外設定を返します
+func DefaultExcludeConfig() *ExcludeConfig {
+	return &ExcludeConfig{
+		ExcludeFiles: []string{
+			"page.tsx",
+			"layout.tsx",
+			"loading.tsx",
+			"error.tsx",
+			"not-found.tsx",
+			"route.ts",
+		},
+		ExcludeImports: []string{
+			"@kit/ui",
+			"@kit/components",
+			"@/components/ui",
+			"@/ui",
+			"*/actions/",
+			"@/actions/",
+			"../actions/",
+			"./actions/",
+		},
+		ExcludeDirectories: []string{
+			"node_modules",
+			"dist",
+			"build",
+			".next",
+		},
+	}
+}
+
+// ExcludeConfigPath はプロジェクトルートから除外設定ファイルのパスを生成します
+func ExcludeConfigPath(projectRoot string) string {
+	return filepath.Join(projectRoot, "scripts", "rename", "excludes.yaml")
+}
+
+// 除外設定をオプションに反映する
+func (c *ExcludeConfig) ApplyTo(opts *Options) {
+	opts.ExcludePatterns = c.ExcludeFiles
+	opts.ExcludeImportPatterns = c.ExcludeImports
+	opts.ExcludeDirectories = c.ExcludeDirectories
+	opts.MaxPathLength = c.Portability.MaxPathLength
+	opts.MaxNameLength = c.Portability.MaxNameLength
+	opts.BlockUnportable = c.Portability.Block
+	opts.UnusedAllow = c.Unused.Allow
+	opts.CheckCycles = c.Cycles.Check
+	opts.CyclesIgnoreTypeOnly = c.Cycles.IgnoreTypeOnly
+	if c.Naming.Direction != "" {
+		opts.ConversionDirection = c.Naming.Direction
+	}
+}
@@ -0,0 +1,655 @@
+package renamer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ファイル内のインポート文を解析して、除外対象かどうかを判断する
+// モジュール指定子は索引から取得するため、同じファイルを繰り返し読み込むことはありません
+func (e *Engine) shouldExcludeByImports(filePath string, excludeImportPatterns []string) (bool, string, error) {
+	// _componentsディレクトリ内のファイルはインポートによる除外を適用しない
+	if strings.Contains(filePath, "/_components/") || strings.Contains(filePath, "\\_components\\") {
+		if strings.Contains(filePath, ".tsx") || strings.Contains(filePath, ".jsx") {
+			return false, "", nil
+		}
+	}
+
+	refs, err := e.Imports(filePath)
+	if err != nil {
+		return false, "", fmt.Errorf("ファイル読み込みエラー: %w", err)
+	}
+
+	// 各インポート文について、除外パターンと照合（import() と require() は対象外）
+	for _, ref := range refs {
+		if ref.Dynamic {
+			continue
+		}
+		for _, pattern := range excludeImportPatterns {
+			if strings.Contains(ref.Specifier, pattern) {
+				return true, ref.Specifier, nil
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+// ファイル名の変換を処理
+func (e *Engine) processFileName(filePath string) (*ConversionResult, error) {
+	// ファイル名のみを取得
+	dir, fileName := filepath.Split(filePath)
+	fileExt := filepath.Ext(fileName)
+	baseName := fileName[:len(fileName)-len(fileExt)]
+
+	// 特定のパターンに一致するファイルは除外
+	for _, pattern := range e.opts.ExcludePatterns {
+		if strings.Contains(fileName, pattern) {
+			if e.opts.DebugMode {
+				e.printf("スキップ: %s (除外パターンに一致: %s)\n", filePath, pattern)
+			} else {
+				e.printf("スキップ: %s\n", filePath)
+			}
+			return nil, fmt.Errorf("除外パターンに一致しました")
+		}
+	}
+
+	// 変換方向に基づいて処理
+	var newBaseName string
+	if e.opts.ConversionDirection == DirectionCamelToKebab {
+		// キャメルケースを確認
+		if !IsCamelCase(baseName) {
+			if e.opts.DebugMode {
+				e.printf("スキップ: %s (キャメルケースではない - 先頭は大文字ではないか、特殊文字を含むため)\n", filePath)
+				e.printf("  ファイル名: %s, 先頭文字: %c\n", baseName, baseName[0])
+				if strings.Contains(baseName, "-") {
+					e.printf("  ハイフン(-) を含んでいます\n")
+				}
+				if strings.Contains(baseName, "_") {
+					e.printf("  アンダースコア(_) を含んでいます\n")
+				}
+			} else {
+				e.printf("スキップ: %s\n", filePath)
+			}
+			return nil, fmt.Errorf("キャメルケースではありません")
+		}
+
+		// キャメルケースからケバブケースへ変換
+		newBaseName = camelToKebab(baseName, e.opts.PreserveAcronymCase)
+
+		// デバッグモードでの表示
+		if e.opts.DebugMode {
+			e.printf("処理: %s (キャメルケース → ケバブケース: %s → %s)\n", filePath, baseName, newBaseName)
+		} else {
+			e.printf("処理: %s\n", filePath)
+		}
+	} else {
+		// ケバブケースを確認
+		if !IsKebabCase(baseName) {
+			if e.opts.DebugMode {
+				e.printf("スキップ: %s (ケバブケースではない - 小文字とハイフンのみではないため)\n", filePath)
+				nonKebabChars := []rune{}
+				for _, c := range baseName {
+					if !((c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-') {
+						nonKebabChars = append(nonKebabChars, c)
+					}
+				}
+				if len(nonKebabChars) > 0 {
+					e.printf("  ケバブケースに適合しない文字: %v\n", string(nonKebabChars))
+				}
+			} else {
+				e.printf("スキップ: %s\n", filePath)
+			}
+			return nil, fmt.Errorf("ケバブケースではありません")
+		}
+
+		// ケバブケースからキャメルケースへ変換
+		newBaseName = KebabToCamel(baseName)
+
+		// デバッグモードでの表示
+		if e.opts.DebugMode {
+			e.printf("処理: %s (ケバブケース → キャメルケース: %s → %s)\n", filePath, baseName, newBaseName)
+		} else {
+			e.printf("処理: %s\n", filePath)
+		}
+	}
+
+	// 新しいファイル名を生成
+	newFileName := newBaseName + fileExt
+	newFilePath := filepath.Join(dir, newFileName)
+
+	// ファイルが既に存在する場合は処理をスキップ
+	if e.targetExists(filePath, newFilePath) {
+		if e.opts.DebugMode {
+			e.printf("スキップ: %s (変換後のファイル %s は既に存在します)\n", filePath, newFilePath)
+		} else {
+			e.printf("スキップ: %s\n", filePath)
+		}
+		return nil, fmt.Errorf("変換後のファイルは既に存在します")
+	}
+
+	// 結果オブジェクトを作成
+	result := &ConversionResult{
+		OldPath:     filePath,
+		NewPath:     newFilePath,
+		OldBaseName: baseName,
+		NewBaseName: newBaseName,
+	}
+
+	return result, nil
+}
+
+// 再帰的にディレクトリを走査してTypeScriptReactファイルを取得
+func (e *Engine) findTsxJsxFiles(rootDir string) ([]string, error) {
+	var files []string
+
+	err := e.walkIndexed(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			base := filepath.Base(path)
+			// 明示的に除外されたディレクトリのみをスキップ（基本的な除外ディレクトリ）
+			if isExcludedDir(base) {
+				if e.opts.DebugMode {
+					e.printf("除外: %s はシステム除外ディレクトリです\n", path)
+				}
+				return filepath.SkipDir
+			}
+
+			// e.opts.ExcludeDirectoriesに含まれるディレクトリも除外
+			for _, excludeDir := range e.opts.ExcludeDirectories {
+				if base == excludeDir {
+					if e.opts.DebugMode {
+						e.printf("除外: %s は構成ファイルで指定された除外ディレクトリです\n", path)
+					}
+					return filepath.SkipDir
+				}
+			}
+
+			return nil
+		}
+
+		ext := filepath.Ext(path)
+		if ext == ".tsx" || ext == ".jsx" {
+			files = append(files, path)
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// processDirComponent はディレクトリ型コンポーネント（Button/index.tsx）を処理します
+func (e *Engine) processDirComponent(dirPath string) (*ConversionResult, error) {
+	// ディレクトリ名を取得
+	dir, dirName := filepath.Split(strings.TrimSuffix(dirPath, "/"))
+
+	// .tsx か .jsx のどちらかが存在するか確認
+	tsxPath := filepath.Join(dirPath, "index.tsx")
+	jsxPath := filepath.Join(dirPath, "index.jsx")
+
+	if _, err := e.fs.Stat(tsxPath); err == nil {
+		// index.tsx が存在する
+	} else if _, err := e.fs.Stat(jsxPath); err == nil {
+		// index.jsx が存在する
+	} else {
+		return nil, fmt.Errorf("index.tsx/jsx ファイルが見つかりません")
+	}
+
+	// 特定のパターンに一致するディレクトリは除外
+	for _, pattern := range e.opts.ExcludePatterns {
+		if strings.Contains(dirName, pattern) {
+			if e.opts.DebugMode {
+				e.printf("スキップ: %s (除外パターンに一致)\n", dirPath)
+			} else {
+				e.printf("スキップ: %s\n", dirPath)
+			}
+			return nil, fmt.Errorf("除外パターンに一致しました")
+		}
+	}
+
+	// 変換方向に基づいて処理
+	var newDirName string
+	if e.opts.ConversionDirection == DirectionCamelToKebab {
+		// キャメルケースを確認
+		if !IsCamelCase(dirName) {
+			if e.opts.DebugMode {
+				e.printf("スキップ: %s (キャメルケースではない)\n", dirPath)
+			} else {
+				e.printf("スキップ: %s\n", dirPath)
+			}
+			return nil, fmt.Errorf("キャメルケースではありません")
+		}
+
+		// キャメルケースからケバブケースへ変換
+		newDirName = camelToKebab(dirName, e.opts.PreserveAcronymCase)
+
+		// デバッグモードでの表示
+		if e.opts.DebugMode {
+			e.printf("処理: %s (キャメルケース → ケバブケース)\n", dirPath)
+		} else {
+			e.printf("処理: %s\n", dirPath)
+		}
+	} else {
+		// ケバブケースを確認
+		if !IsKebabCase(dirName) {
+			if e.opts.DebugMode {
+				e.printf("スキップ: %s (ケバブケースではない)\n", dirPath)
+			} else {
+				e.printf("スキップ: %s\n", dirPath)
+			}
+			return nil, fmt.Errorf("ケバブケースではありません")
+		}
+
+		// ケバブケースからキャメルケースへ変換
+		newDirName = KebabToCamel(dirName)
+
+		// デバッグモードでの表示
+		if e.opts.DebugMode {
+			e.printf("処理: %s (ケバブケース → キャメルケース)\n", dirPath)
+		} else {
+			e.printf("処理: %s\n", dirPath)
+		}
+	}
+
+	// 新しいディレクトリパスを生成
+	newDirPath := filepath.Join(dir, newDirName)
+
+	// ディレクトリが既に存在する場合は処理をスキップ
+	if e.targetExists(dirPath, newDirPath) {
+		if e.opts.DebugMode {
+			e.printf("スキップ: %s (変換後のディレクトリ %s は既に存在します)\n", dirPath, newDirPath)
+		} else {
+			e.printf("スキップ: %s\n", dirPath)
+		}
+		return nil, fmt.Errorf("変換後のディレクトリは既に存在します")
+	}
+
+	// 結果オブジェクトを作成
+	result := &ConversionResult{
+		OldPath:     dirPath,
+		NewPath:     newDirPath,
+		OldBaseName: dirName,
+		NewBaseName: newDirName,
+		IsDir:       true,
+	}
+
+	return result, nil
+}
+
+// 変換後のパスに、変換元とは別のファイルまたはディレクトリが既に存在するかどうか
+// 大文字小文字を区別しないファイルシステムでは大文字小文字だけが異なる変換後のパスも Stat できるため、実際の名前で比べる
+func (e *Engine) targetExists(oldPath, newPath string) bool {
+	if newPath == oldPath {
+		return false
+	}
+	if _, err := e.fs.Stat(newPath); err != nil {
+		return false
+	}
+	if strings.EqualFold(oldPath, newPath) {
+		return e.hasEntry(filepath.Dir(newPath), filepath.Base(newPath))
+	}
+	return true
+}
+
+// findDirectoryComponents は指定されたディレクトリ内のディレクトリ型コンポーネントを検索します
+func (e *Engine) findDirectoryComponents(rootDir string) ([]string, error) {
+	var dirComponents []string
+
+	err := e.walkIndexed(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			// ファイルがindex.tsxまたはindex.jsxの場合、親ディレクトリをコンポーネントとして扱う
+			// （rename-script barrels で生成したバレルはコンポーネントではない）
+			if (filepath.Base(path) == "index.tsx" || filepath.Base(path) == "index.jsx") && !e.isGeneratedBarrel(path) {
+				parentDir := filepath.Dir(path)
+				// 既に追加されていなければリストに追加
+				if !contains(dirComponents, parentDir) {
+					dirComponents = append(dirComponents, parentDir)
+				}
+			}
+			return nil
+		}
+
+		// 明示的に除外されたディレクトリのみをスキップ
+		base := filepath.Base(path)
+		if isExcludedDir(base) {
+			if e.opts.DebugMode {
+				e.printf("除外: %s はシステム除外ディレクトリです\n", path)
+			}
+			return filepath.SkipDir
+		}
+
+		// e.opts.ExcludeDirectoriesに含まれるディレクトリも除外
+		for _, excludeDir := range e.opts.ExcludeDirectories {
+			if base == excludeDir {
+				if e.opts.DebugMode {
+					e.printf("除外: %s は構成ファイルで指定された除外ディレクトリです\n", path)
+				}
+				return filepath.SkipDir
+			}
+		}
+
+		return nil
+	})
+
+	return dirComponents, err
+}
+
+// Plan は指定されたディレクトリ（プロジェクトルートからの相対パス）の変換計画を作成します
+func (e *Engine) Plan(dirs []string) (*Plan, error) {
+	if e.opts.ConversionDirection != DirectionCamelToKebab && e.opts.ConversionDirection != DirectionKebabToCamel {
+		return nil, fmt.Errorf("変換方向が不正です: %q", e.opts.ConversionDirection)
+	}
+
+	plan := &Plan{ConversionDirection: e.opts.ConversionDirection}
+	for _, dir := range dirs {
+		dirPlan, err := e.planDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		plan.Dirs = append(plan.Dirs, dirPlan)
+	}
+	return plan, nil
+}
+
+// ディレクトリごとの変換計画を作成
+func (e *Engine) planDir(targetDir string) (DirPlan, error) {
+	e.printf("\n=== %s のファイル処理を開始します ===\n", targetDir)
+
+	// 対象ディレクトリの絶対パスを生成
+	fullTargetDir := e.abs(targetDir)
+	e.printf("対象ディレクトリ: %s\n", fullTargetDir)
+
+	dirPlan := DirPlan{TargetDir: targetDir}
+
+	// ディレクトリ内の.tsx/.jsxファイルを検索
+	files, err := e.findTsxJsxFiles(fullTargetDir)
+	if err != nil {
+		return dirPlan, fmt.Errorf("ファイル検索中にエラーが発生しました (%s): %w", targetDir, err)
+	}
+
+	// ディレクトリ型コンポーネントも検索
+	dirComponents, err := e.findDirectoryComponents(fullTargetDir)
+	if err != nil {
+		e.printf("エラー: ディレクトリ型コンポーネント検索中にエラーが発生しました: %v\n", err)
+	} else {
+		e.printf("ディレクトリ型コンポーネント: %d 個検出\n", len(dirComponents))
+	}
+
+	// 変換対象が限定されている場合は、対象外のファイルとディレクトリを除く
+	if e.opts.OnlyFiles != nil {
+		files = filterPaths(files, e.selectedFile)
+		dirComponents = filterPaths(dirComponents, e.selectedDir)
+	}
+
+	dirPlan.TotalFiles = len(files) + len(dirComponents)
+	if dirPlan.TotalFiles == 0 {
+		e.println("変換対象のファイルが見つかりませんでした。")
+		return dirPlan, nil
+	}
+
+	// 各ファイルを処理
+	for _, file := range files {
+		baseName := filepath.Base(file)
+
+		// index.tsxファイルはスキップ（ディレクトリ型コンポーネントで処理する）
+		if baseName == "index.tsx" || baseName == "index.jsx" {
+			continue
+		}
+
+		// 除外パターンに一致するファイルはスキップ
+		shouldExclude := false
+		for _, pattern := range e.opts.ExcludePatterns {
+			if strings.Contains(baseName, pattern) {
+				shouldExclude = true
+				break
+			}
+		}
+
+		if shouldExclude {
+			if e.opts.DebugMode {
+				e.printf("スキップ: %s (除外パターンに一致)\n", baseName)
+			} else {
+				e.printf("スキップ: %s\n", baseName)
+			}
+			dirPlan.SkippedFiles++
+			continue
+		}
+
+		// インポートパスによる除外
+		if len(e.opts.ExcludeImportPatterns) > 0 {
+			shouldExcludeImport, importPath, err := e.shouldExcludeByImports(file, e.opts.ExcludeImportPatterns)
+			if err != nil {
+				e.printf("警告: インポート解析中にエラーが発生しました: %v\n", err)
+			} else if shouldExcludeImport {
+				if e.opts.DebugMode {
+					e.printf("スキップ: %s (除外インポートパスに一致: %s)\n", baseName, importPath)
+				} else {
+					e.printf("スキップ: %s\n", baseName)
+				}
+				dirPlan.SkippedFiles++
+				continue
+			}
+		}
+
+		// ファイル名の変換処理
+		result, err := e.processFileName(file)
+		if err != nil {
+			if e.opts.DebugMode {
+				e.printf("スキップ: %s (変換の必要なし): %v\n", baseName, err)
+			} else {
+				e.printf("スキップ: %s\n", baseName)
+			}
+			dirPlan.SkippedFiles++
+			continue
+		}
+
+		// 変換結果を表示
+		e.printf("変換: %s -> %s\n", baseName, filepath.Base(result.NewPath))
+		result.TargetDir = targetDir
+		dirPlan.Renames = append(dirPlan.Renames, *result)
+	}
+
+	// ディレクトリ型コンポーネントを処理
+	for _, dirPath := range dirComponents {
+		dirName := filepath.Base(dirPath)
+
+		// ディレクトリ名の変換処理
+		result, err := e.processDirComponent(dirPath)
+		if err != nil {
+			if e.opts.DebugMode {
+				e.printf("スキップ: %s (変換の必要なし): %v\n", dirName, err)
+			} else {
+				e.printf("スキップ: %s/\n", dirName)
+			}
+			dirPlan.SkippedFiles++
+			continue
+		}
+
+		// 変換結果を表示
+		e.printf("変換 (ディレクトリ): %s/ -> %s/\n", dirName, result.NewBaseName)
+		result.TargetDir = targetDir
+		dirPlan.Renames = append(dirPlan.Renames, *result)
+	}
+
+	return dirPlan, nil
+}
+
+// Apply は変換計画を実行します
+// ドライランの場合は OverlayFS に対して同じ処理を実行するため、ファイルシステムは変更されません
+func (e *Engine) Apply(plan *Plan) ([]ConversionResult, error) {
+	results, _, err := e.ApplyRun(plan)
+	return results, err
+}
+
+// ApplyRun は変換計画を実行し、変換結果と実行の記録（ジャーナル、ドライランの場合は変更後の状態）を返します
+func (e *Engine) ApplyRun(plan *Plan) ([]ConversionResult, *Run, error) {
+	var results []ConversionResult
+	run, err := e.Execute("apply", func(worker *Engine) error {
+		var err error
+		results, err = worker.apply(plan)
+		return err
+	})
+	return results, run, err
+}
+
+// ApplyDryRun は変換計画を OverlayFS に対して実行し、変換結果と変更後の状態を保持した OverlayFS を返します
+func (e *Engine) ApplyDryRun(plan *Plan) ([]ConversionResult, *OverlayFS, error) {
+	dry := e.WithFS(e.fs)
+	dry.opts.DryRun = true
+	results, run, err := dry.ApplyRun(plan)
+	return results, run.Overlay, err
+}
+
+// 変換計画を現在の FS に対して実行
+func (e *Engine) apply(plan *Plan) ([]ConversionResult, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("変換計画が指定されていません")
+	}
+
+	if e.opts.BlockUnportable {
+		issues, err := e.AuditPlan(plan)
+		if err != nil {
+			return nil, err
+		}
+		if len(issues) > 0 {
+			return nil, &PortabilityError{Issues: issues}
+		}
+	}
+
+	var results []ConversionResult
+	for _, dirPlan := range plan.Dirs {
+		result := e.applyDir(dirPlan)
+		// リネームしたパスを含むディレクトリのバレルだけを更新する
+		barrels, err := e.updateRenamedBarrels()
+		result.Barrels = barrels
+		results = append(results, result)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// ディレクトリごとの変換計画を実行
+func (e *Engine) applyDir(dirPlan DirPlan) ConversionResult {
+	// 変換結果
+	conversionResult := ConversionResult{
+		TargetDir:    dirPlan.TargetDir,
+		TotalFiles:   dirPlan.TotalFiles,
+		SkippedFiles: dirPlan.SkippedFiles,
+	}
+
+	// 処理結果の詳細
+	var results []ConversionResult
+	var errorFiles []string
+
+	for _, rename := range dirPlan.Renames {
+		name := rename.OldBaseName
+		if !rename.IsDir {
+			name = filepath.Base(rename.OldPath)
+		}
+
+		// リネーム（ドライランの場合はオーバーレイ上で行われる）
+		var err error
+		if rename.IsDir {
+			err = renameDirectory(e.fs, rename.OldPath, rename.NewPath)
+			name += "/"
+		} else {
+			err = e.fs.Rename(rename.OldPath, rename.NewPath)
+		}
+		if err != nil {
+			e.printf("エラー: %s の名前変更中にエラーが発生しました: %v\n", name, err)
+			conversionResult.ErrorFiles++
+			errorFiles = append(errorFiles, fmt.Sprintf("%s: リネーム失敗 - %v", name, err))
+			continue
+		}
+
+		results = append(results, rename)
+		conversionResult.ProcessedFiles++
+	}
+
+	// インポートパスの更新
+	if len(results) > 0 {
+		// リネーム後のファイル構成で検索するため、索引を作り直す
+		e.InvalidateIndex()
+		e.println("\n--- インポートパスの更新 ---")
+
+		// プロジェクト内の全TSX/JSXファイルを検索（インポートパスの更新用）
+		projectDirForImports := e.importScope(dirPlan.TargetDir)
+		projectFiles, err := e.findTsxJsxFiles(projectDirForImports)
+		if err == nil && e.opts.Barrels != nil {
+			// バレル（index.ts）の手書きの部分の再エクスポートも更新する
+			projectFiles = append(projectFiles, e.barrelFiles(e.opts.Barrels)...)
+			slices.Sort(projectFiles)
+			projectFiles = slices.Compact(projectFiles)
+		}
+		if err != nil {
+			e.printf("インポートパス更新用のファイル検索中にエラーが発生しました: %v\n", err)
+		} else {
+			// 各ファイルを 1 回だけ読み込み、すべての変換対象のインポートパスをまとめて更新
+			conversionResult.ImportUpdateFiles = e.rewriteImports(projectFiles, results)
+			sort.Strings(conversionResult.ImportUpdateFiles)
+		}
+	}
+
+	// 処理結果の表示
+	e.println("\n--- 処理結果 ---")
+	e.printf("合計ファイル数: %d\n", conversionResult.TotalFiles)
+	e.printf("処理したファイル数: %d\n", conversionResult.ProcessedFiles)
+	e.printf("スキップしたファイル数: %d\n", conversionResult.SkippedFiles)
+	e.printf("エラーが発生したファイル数: %d\n", conversionResult.ErrorFiles)
+
+	// インポートパス更新対象ファイルの表示
+	if len(conversionResult.ImportUpdateFiles) > 0 {
+		e.printf("インポートパスの更新対象ファイル数: %d\n", len(conversionResult.ImportUpdateFiles))
+
+		if e.opts.DryRun && e.opts.DebugMode {
+			e.println("\n--- インポートパス更新対象ファイル ---")
+			for _, file := range conversionResult.ImportUpdateFiles {
+				e.printf("  %s\n", file)
+			}
+		}
+	}
+
+	// エラーファイルがあれば表示
+	conversionResult.Errors = errorFiles
+	if len(errorFiles) > 0 {
+		e.println("\n--- エラーが発生したファイル ---")
+		for _, errFile := range errorFiles {
+			e.println(errFile)
+		}
+	}
+
+	return conversionResult
+}
+
+// ディレクトリのリネームはファイルの移動よりも複雑なため、
+// 一時的なディレクトリ名を使って二段階で移動することで名前の衝突を回避する
+func renameDirectory(fsys FS, oldPath, newPath string) error {
+	tempDir := filepath.Join(filepath.Dir(oldPath), fmt.Sprintf("_temp_%s_%d", filepath.Base(oldPath), time.Now().UnixNano()))
+
+	// まず一時ディレクトリへ移動
+	if err := fsys.Rename(oldPath, tempDir); err != nil {
+		return err
+	}
+
+	// 目的のディレクトリ名に移動
+	if err := fsys.Rename(tempDir, newPath); err != nil {
+		// 失敗したら元に戻す
+		fsys.Rename(tempDir, oldPath)
+		return err
+	}
+	return nil
+}
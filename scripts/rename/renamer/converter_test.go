@@ -1,9 +1,11 @@
-package main
+package renamer
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -11,9 +13,9 @@ import (
 
 type ConverterTestSuite struct {
 	suite.Suite
-	tempDir   string
+	fs          *MemFS // テストはすべてメモリ上のファイルシステムで実行する
 	projectRoot string // converterが内部でprojectRootを使うため
-	originalDir string // 元のディレクトリに戻るため
+	originalDir string // エンジンがカレントディレクトリを変更しないことを確認するため
 }
 
 // SetupTest は各テストの前に実行されます
@@ -23,11 +25,9 @@ func (s *ConverterTestSuite) SetupTest() {
 	s.Require().NoError(err)
 	s.originalDir = originalDir
 
-	// テスト用の一時プロジェクトルートを作成
-	tempDir, err := os.MkdirTemp("", "converter-test-*")
-	s.Require().NoError(err)
-	s.tempDir = tempDir
-	s.projectRoot = tempDir // このテストスイートでは一時ディレクトリをルートとする
+	// テスト用のプロジェクトルートをメモリ上に作成
+	s.fs = NewMemFS()
+	s.projectRoot = filepath.FromSlash("/project")
 
 	// テスト用のディレクトリ構造とファイルを作成
 	dirs := []string{
@@ -43,7 +43,7 @@ func (s *ConverterTestSuite) SetupTest() {
 		"packages/ui/src",
 	}
 	for _, dir := range dirs {
-		err := os.MkdirAll(filepath.Join(s.tempDir, dir), 0755)
+		err := s.fs.MkdirAll(filepath.Join(s.projectRoot, dir), 0755)
 		s.Require().NoError(err)
 	}
 
@@ -61,8 +61,8 @@ import { InputField } from '../common/input-field';
 export const UserCard = () => <div><AvatarImage /><InputField /></div>;
 `,
 		"utils/helpers.ts": "export const formatName = (name: string) => name.toUpperCase();", // .tsファイル (対象外)
-		"README.md":        "# Test Project", // 対象外
-		
+		"README.md":        "# Test Project",                                                  // 対象外
+
 		// ディレクトリ型コンポーネント
 		"components/common/IconButton/index.tsx": `
 import { Button } from '../Button';
@@ -72,7 +72,7 @@ export const IconButton = ({ icon }) => <Button>{icon}</Button>;
 import { IconButton } from '../../common/IconButton';
 export const UserAvatar = ({ src }) => <div><img src={src} /><IconButton icon="edit" /></div>;
 `,
-		
+
 		// 新しい検索パターン用のファイル
 		"apps/web/app/AppLayout.tsx": `
 export const AppLayout = ({ children }) => <div className="app-layout">{children}</div>;
@@ -93,52 +93,74 @@ export const buttonStyles = { primary: "bg-blue-500", secondary: "bg-gray-500" }
 	}
 
 	for path, content := range files {
-		filePath := filepath.Join(s.tempDir, path)
-		err := os.WriteFile(filePath, []byte(content), 0644)
+		filePath := filepath.Join(s.projectRoot, path)
+		err := s.fs.WriteFile(filePath, []byte(content), 0644)
 		s.Require().NoError(err)
 	}
+}
 
-	// プロジェクトルートに移動 (converter内の関数がカレントディレクトリを基準にする場合があるため)
-	err = os.Chdir(s.tempDir)
+// 大文字小文字を区別しないファイルシステム（macOS や Windows の既定）のように、大文字小文字だけが異なるパスも Stat できる FS
+type caseFoldingFS struct {
+	*MemFS
+}
+
+func (f caseFoldingFS) Stat(name string) (fs.FileInfo, error) {
+	entries, err := f.MemFS.ReadDir(filepath.Dir(name))
+	if err == nil {
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Name(), filepath.Base(name)) {
+				return f.MemFS.Stat(filepath.Join(filepath.Dir(name), entry.Name()))
+			}
+		}
+	}
+	return f.MemFS.Stat(name)
+}
+
+// テスト用のエンジンを作成
+func (s *ConverterTestSuite) newEngine(opts Options) *Engine {
+	opts.FS = s.fs
+	engine, err := NewEngine(s.projectRoot, opts)
 	s.Require().NoError(err)
+	return engine
 }
 
-// TearDownTest は各テストの後に実行されます
-func (s *ConverterTestSuite) TearDownTest() {
-	// 元のディレクトリに戻る
-	err := os.Chdir(s.originalDir)
+// 計画と実行をまとめて行う（従来の processFiles 相当）
+func (s *ConverterTestSuite) processFiles(targetDir string, opts Options) []ConversionResult {
+	engine := s.newEngine(opts)
+	plan, err := engine.Plan([]string{targetDir})
+	s.Require().NoError(err)
+	results, err := engine.Apply(plan)
 	s.Require().NoError(err)
-	// テスト用の一時ディレクトリを削除
-	os.RemoveAll(s.tempDir)
+	return results
 }
 
 // findTsxJsxFiles のテスト
 func (s *ConverterTestSuite) TestFindTsxJsxFiles() {
 	// テスト用のダミーConfig
-	config := Config{
+	config := Options{
 		ExcludeDirectories: []string{},
 	}
-	
-	files, err := findTsxJsxFiles(s.projectRoot, config)
+
+	files, err := s.newEngine(config).findTsxJsxFiles(s.projectRoot)
 	s.Require().NoError(err)
 	s.Len(files, 11, "Should find 11 tsx/jsx files")
 
 	// 相対パスで期待されるファイルリスト (順不同で比較するためMapを使用)
 	expectedFiles := map[string]bool{
 		// 元のコンポーネントディレクトリ内のファイル
-		filepath.Join("components", "common", "Button.tsx"):      true,
-		filepath.Join("components", "common", "input-field.tsx"): true,
+		filepath.Join("components", "common", "Button.tsx"):                       true,
+		filepath.Join("components", "common", "input-field.tsx"):                  true,
 		filepath.Join("components", "features", "UserProfile", "AvatarImage.tsx"): true,
-		filepath.Join("components", "features", "UserProfile", "user-card.tsx"): true,
+		filepath.Join("components", "features", "UserProfile", "user-card.tsx"):   true,
 		// ディレクトリ型コンポーネントのファイル
-		filepath.Join("components", "common", "IconButton", "index.tsx"): true,
+		filepath.Join("components", "common", "IconButton", "index.tsx"):    true,
 		filepath.Join("components", "features", "user-avatar", "index.tsx"): true,
 		// 新しい検索パターン (apps/*/app) のファイル
-		filepath.Join("apps", "web", "app", "AppLayout.tsx"): true,
-		filepath.Join("apps", "web", "app", "page-container.tsx"): true,
+		filepath.Join("apps", "web", "app", "AppLayout.tsx"):        true,
+		filepath.Join("apps", "web", "app", "page-container.tsx"):   true,
 		filepath.Join("apps", "admin", "app", "AdminDashboard.tsx"): true,
 		// 新しい検索パターン (packages/ui/src) のファイル
-		filepath.Join("packages", "ui", "src", "Theme.tsx"): true,
+		filepath.Join("packages", "ui", "src", "Theme.tsx"):         true,
 		filepath.Join("packages", "ui", "src", "button-styles.tsx"): true,
 	}
 
@@ -152,210 +174,229 @@ func (s *ConverterTestSuite) TestFindTsxJsxFiles() {
 	s.Equal(expectedFiles, foundFiles)
 }
 
-// updateImportPaths のテスト (CamelCase -> kebab-case)
-func (s *ConverterTestSuite) TestUpdateImportPaths_CamelToKebab() {
-	// 更新対象のファイルと変更内容
-	fileToUpdate := filepath.Join(s.projectRoot, "components", "features", "UserProfile", "AvatarImage.tsx") // Button.tsx をインポートしている
-	oldBaseName := "Button"
-	newBaseName := "button"
-	config := Config{ ConversionDirection: "camel-to-kebab" } // ダミーのConfig
-
-	// 更新処理を実行
-	updateImportPaths(fileToUpdate, oldBaseName, newBaseName, config)
+// 変換したファイルをリネームして、そのファイルを指すインポートパスを書き換える
+func (s *ConverterTestSuite) renameAndRewrite(config Options, file, oldRel, newRel string) string {
+	oldPath := filepath.Join(s.projectRoot, filepath.FromSlash(oldRel))
+	newPath := filepath.Join(s.projectRoot, filepath.FromSlash(newRel))
+	s.Require().NoError(s.fs.Rename(oldPath, newPath))
+	result := ConversionResult{
+		OldPath:     oldPath,
+		NewPath:     newPath,
+		OldBaseName: strings.TrimSuffix(filepath.Base(oldPath), filepath.Ext(oldPath)),
+		NewBaseName: strings.TrimSuffix(filepath.Base(newPath), filepath.Ext(newPath)),
+	}
+	s.newEngine(config).rewriteImports([]string{file}, []ConversionResult{result})
 
-	// ファイル内容を読み取って検証
-	content, err := os.ReadFile(fileToUpdate)
+	content, err := s.fs.ReadFile(file)
 	s.Require().NoError(err)
-	s.Contains(string(content), "from '../common/button'")
+	return string(content)
+}
 
-	// 別のファイル/変更でテスト
-	fileToUpdate2 := filepath.Join(s.projectRoot, "components", "features", "UserProfile", "user-card.tsx") // AvatarImage.tsx をインポート
-	oldBaseName2 := "AvatarImage"
-	newBaseName2 := "avatar-image"
+// rewriteImports のテスト (CamelCase -> kebab-case)
+func (s *ConverterTestSuite) TestRewriteImports_CamelToKebab() {
+	config := Options{ConversionDirection: "camel-to-kebab"}
 
-	updateImportPaths(fileToUpdate2, oldBaseName2, newBaseName2, config)
+	// IconButton/index.tsx は ../Button をインポートしている
+	fileToUpdate := filepath.Join(s.projectRoot, "components", "common", "IconButton", "index.tsx")
+	content := s.renameAndRewrite(config, fileToUpdate, "components/common/Button.tsx", "components/common/button.tsx")
+	s.Contains(content, "from '../button'")
 
-	content2, err := os.ReadFile(fileToUpdate2)
-	s.Require().NoError(err)
-	s.Contains(string(content2), "from './avatar-image'")
+	// user-card.tsx は ./AvatarImage をインポートしている
+	fileToUpdate2 := filepath.Join(s.projectRoot, "components", "features", "UserProfile", "user-card.tsx")
+	content2 := s.renameAndRewrite(config, fileToUpdate2, "components/features/UserProfile/AvatarImage.tsx", "components/features/UserProfile/avatar-image.tsx")
+	s.Contains(content2, "from './avatar-image'")
 	// input-field は変更していないので、元のままか確認
-	s.Contains(string(content2), "from '../common/input-field'")
+	s.Contains(content2, "from '../common/input-field'")
 }
 
-// updateImportPaths のテスト (kebab-case -> CamelCase)
-func (s *ConverterTestSuite) TestUpdateImportPaths_KebabToCamel() {
-    // 更新対象のファイルと変更内容
-    fileToUpdate := filepath.Join(s.projectRoot, "components", "features", "UserProfile", "user-card.tsx") // input-field.tsx をインポート
-    oldBaseName := "input-field"
-    newBaseName := "InputField"
-	config := Config{ ConversionDirection: "kebab-to-camel" } // ダミーのConfig
-
-    // 更新処理を実行
-    updateImportPaths(fileToUpdate, oldBaseName, newBaseName, config)
-
-    // ファイル内容を読み取って検証
-    content, err := os.ReadFile(fileToUpdate)
-    s.Require().NoError(err)
-    s.Contains(string(content), "from '../common/InputField'")
-    // AvatarImage は変更していないので、元のままか確認
-    s.Contains(string(content), "from './AvatarImage'")
+// rewriteImports のテスト (kebab-case -> CamelCase)
+func (s *ConverterTestSuite) TestRewriteImports_KebabToCamel() {
+	config := Options{ConversionDirection: "kebab-to-camel"}
+	fileToUpdate := filepath.Join(s.projectRoot, "components", "common", "form.tsx")
+	s.Require().NoError(s.fs.WriteFile(fileToUpdate, []byte("import { InputField } from './input-field';\nimport { Button } from './Button';\n"), 0644))
+
+	content := s.renameAndRewrite(config, fileToUpdate, "components/common/input-field.tsx", "components/common/InputField.tsx")
+	s.Contains(content, "from './InputField'")
+	// Button は変更していないので、元のままか確認
+	s.Contains(content, "from './Button'")
 }
 
-// processFiles のテスト
-func (s *ConverterTestSuite) TestProcessFiles() {
-	// 元のfindProjectRoot関数を保存
-	origFindProjectRoot := findProjectRoot
+// 同じ名前のファイルが別のディレクトリにある場合は、リネームしたファイルを指すインポートだけを書き換える
+func (s *ConverterTestSuite) TestRewriteImports_SameNameInOtherDirectory() {
+	s.Require().NoError(s.fs.MkdirAll(filepath.Join(s.projectRoot, "components", "legacy"), 0755))
+	s.Require().NoError(s.fs.WriteFile(filepath.Join(s.projectRoot, "components", "legacy", "Button.tsx"), []byte("export const Button = () => null;\n"), 0644))
+	fileToUpdate := filepath.Join(s.projectRoot, "components", "page.tsx")
+	s.Require().NoError(s.fs.WriteFile(fileToUpdate, []byte("import { Button } from './common/Button';\nimport { Button as Legacy } from './legacy/Button';\n"), 0644))
+
+	content := s.renameAndRewrite(Options{ConversionDirection: "camel-to-kebab"}, fileToUpdate, "components/common/Button.tsx", "components/common/button.tsx")
+	s.Equal("import { Button } from './common/button';\nimport { Button as Legacy } from './legacy/Button';\n", content)
+}
+
+// ApplyDryRun のテスト
+func (s *ConverterTestSuite) TestApplyDryRun() {
+	engine := s.newEngine(Options{ConversionDirection: "camel-to-kebab"})
+	plan, err := engine.Plan([]string{"components/common"})
+	s.Require().NoError(err)
+
+	_, overlay, err := engine.ApplyDryRun(plan)
+	s.Require().NoError(err)
+
+	// 実際のファイルシステムは変更されない
+	s.True(exists(s.fs, filepath.Join(s.projectRoot, "components/common/Button.tsx")))
+	s.False(exists(s.fs, filepath.Join(s.projectRoot, "components/common/button.tsx")))
+
+	// オーバーレイ上では変換後の状態を確認できる
+	s.True(exists(overlay, filepath.Join(s.projectRoot, "components/common/button.tsx")))
+	s.True(exists(overlay, filepath.Join(s.projectRoot, "components/common/icon-button/index.tsx")))
+	s.False(exists(overlay, filepath.Join(s.projectRoot, "components/common/IconButton")))
+
+	content, err := overlay.ReadFile(filepath.Join(s.projectRoot, "components/features/user-avatar/index.tsx"))
+	s.Require().NoError(err)
+	s.Contains(string(content), "from '../../common/icon-button'")
+
+	s.Contains(overlay.RemovedPaths(), filepath.Join(s.projectRoot, "components/common/Button.tsx"))
+}
 
-	// テスト用のモック関数
-	mockFindProjectRoot := func() (string, error) {
-		return s.projectRoot, nil
+// processFiles のテスト
+// 大文字小文字を区別しないファイルシステムでも、大文字小文字だけが異なるリネームを衝突とみなさない
+func (s *ConverterTestSuite) TestCaseOnlyRenameOnCaseInsensitiveFS() {
+	for path, content := range map[string]string{
+		"components/common/Header/index.tsx": "export const Header = () => null;",
+		"components/common/Card.tsx":         "export const Card = () => null;",
+		"components/common/card.tsx":         "export const card = null;",
+	} {
+		s.Require().NoError(s.fs.MkdirAll(filepath.Dir(filepath.Join(s.projectRoot, path)), 0755))
+		s.Require().NoError(s.fs.WriteFile(filepath.Join(s.projectRoot, path), []byte(content), 0644))
 	}
+	engine, err := NewEngine(s.projectRoot, Options{FS: caseFoldingFS{s.fs}, ConversionDirection: DirectionCamelToKebab})
+	s.Require().NoError(err)
+	plan, err := engine.Plan([]string{"components/common"})
+	s.Require().NoError(err)
 
-	// テスト用の関数を設定
-	findProjectRoot = mockFindProjectRoot
-	// テスト後に元の関数に戻す
-	defer func() { findProjectRoot = origFindProjectRoot }()
+	var renames []string
+	for _, rename := range plan.Dirs[0].Renames {
+		renames = append(renames, engine.Rel(rename.OldPath)+" → "+engine.Rel(rename.NewPath))
+	}
+	s.ElementsMatch([]string{
+		"components/common/Button.tsx → components/common/button.tsx",
+		"components/common/Header → components/common/header",
+		"components/common/IconButton → components/common/icon-button",
+	}, renames, "Card.tsx は card.tsx が別に存在するためスキップする")
+}
 
+func (s *ConverterTestSuite) TestProcessFiles() {
 	// configファイルのクローンを作成し、ドライラン用とファイル変換用に分ける
-	configDryRun := Config{
-		TargetDir: "components/common",
+	configDryRun := Options{
 		ConversionDirection: "camel-to-kebab",
-		ExcludePatterns: []string{},
-		DryRun: true, // 実際のファイル変更はしない
+		ExcludePatterns:     []string{},
+		DryRun:              true, // 実際のファイル変更はしない
 	}
 
-	configActual := Config{
-		TargetDir: "components/common",
+	configActual := Options{
 		ConversionDirection: "camel-to-kebab",
-		ExcludePatterns: []string{},
-		DryRun: false, // 実際にファイルを変換
+		ExcludePatterns:     []string{},
+		DryRun:              false, // 実際にファイルを変換
 	}
 
 	// 変換前の状態を確認
-	buttonPath := filepath.Join(s.tempDir, "components/common/Button.tsx")
-	_, err := os.Stat(buttonPath)
+	buttonPath := filepath.Join(s.projectRoot, "components/common/Button.tsx")
+	_, err := s.fs.Stat(buttonPath)
 	s.Require().NoError(err, "Button.tsx ファイルが存在する必要があります")
 
 	// アイコンボタンのディレクトリを確認
-	iconButtonPath := filepath.Join(s.tempDir, "components/common/IconButton")
-	_, err = os.Stat(iconButtonPath)
+	iconButtonPath := filepath.Join(s.projectRoot, "components/common/IconButton")
+	_, err = s.fs.Stat(iconButtonPath)
 	s.Require().NoError(err, "IconButton ディレクトリが存在する必要があります")
 
 	// 最初にドライランで確認する
-	processFiles(configDryRun)
+	s.processFiles("components/common", configDryRun)
 
 	// ドライランでは元のファイルとディレクトリは維持されるはず
-	_, err = os.Stat(buttonPath)
+	_, err = s.fs.Stat(buttonPath)
 	s.Require().NoError(err, "ドライラン後も Button.tsx ファイルが存在する必要があります")
-	_, err = os.Stat(iconButtonPath)
+	_, err = s.fs.Stat(iconButtonPath)
 	s.Require().NoError(err, "ドライラン後も IconButton ディレクトリが存在する必要があります")
 
 	// icon-button ディレクトリは存在しないはず
-	iconButtonKebabPath := filepath.Join(s.tempDir, "components/common/icon-button")
-	_, err = os.Stat(iconButtonKebabPath)
+	iconButtonKebabPath := filepath.Join(s.projectRoot, "components/common/icon-button")
+	_, err = s.fs.Stat(iconButtonKebabPath)
 	s.True(os.IsNotExist(err), "ドライラン段階では icon-button ディレクトリは存在しないはず")
 
 	// 実際にファイルを変換する
-	processFiles(configActual)
+	s.processFiles("components/common", configActual)
 
-	// Button.tsx は SkipRenameList に含まれているはずなので変化なし
-	_, err = os.Stat(buttonPath)
-	s.Require().NoError(err, "Button.tsx ファイルはスキップリストに含まれるため存在する必要があります")
+	// Button.tsx は button.tsx に変換されるはず（スキップリストは存在しない）
+	_, err = s.fs.Stat(filepath.Join(s.projectRoot, "components/common/button.tsx"))
+	s.Require().NoError(err, "変換後は button.tsx ファイルが存在する必要があります")
 
 	// IconButton ディレクトリは icon-button に変換されるはず
-	_, err = os.Stat(iconButtonKebabPath)
+	_, err = s.fs.Stat(iconButtonKebabPath)
 	s.Require().NoError(err, "変換後は icon-button ディレクトリが存在する必要があります")
-	
+
 	// 元のディレクトリは存在しないはず
-	_, err = os.Stat(iconButtonPath)
+	_, err = s.fs.Stat(iconButtonPath)
 	s.True(os.IsNotExist(err), "変換後は IconButton ディレクトリは存在しないはずです")
 
-	// パスを標準化して比較（MacOSの/privateプレフィックスに対応）
-	expectedDir, err := filepath.EvalSymlinks(s.projectRoot)
-	s.Require().NoError(err)
+	// エンジンはカレントディレクトリを変更しない
 	currentDir, err := os.Getwd()
 	s.Require().NoError(err)
-	actualDir, err := filepath.EvalSymlinks(currentDir)
-	s.Require().NoError(err)
-	
-	// テスト後のクリーンアップ: プロジェクトルートへの移動を確認
-	s.Equal(expectedDir, actualDir, "カレントディレクトリがプロジェクトルートのままであることを確認")
+	s.Equal(s.originalDir, currentDir, "カレントディレクトリが変更されていないことを確認")
 }
 
 // processFiles のテスト (複数ディレクトリのケース)
 func (s *ConverterTestSuite) TestProcessFilesMultipleTargets() {
-	// 元のfindProjectRoot関数を保存
-	origFindProjectRoot := findProjectRoot
-	
-	// テスト用のモック関数
-	mockFindProjectRoot := func() (string, error) {
-		return s.projectRoot, nil
-	}
-	
-	// テスト用の関数を設定
-	findProjectRoot = mockFindProjectRoot
-	// テスト後に元の関数に戻す
-	defer func() { findProjectRoot = origFindProjectRoot }()
-
 	// UserProfile ディレクトリ内のケバブケースファイルをキャメルケースに変換
-	configKebabToCamel := Config{
-		TargetDir: "components/features/UserProfile",
+	configKebabToCamel := Options{
 		ConversionDirection: "kebab-to-camel",
-		ExcludePatterns: []string{},
-		DryRun: false, // 実際にファイルを変換
+		ExcludePatterns:     []string{},
+		DryRun:              false, // 実際にファイルを変換
 	}
 
 	// 変換前のファイルパス
-	userCardPath := filepath.Join(s.tempDir, "components/features/UserProfile/user-card.tsx")
-	_, err := os.Stat(userCardPath)
+	userCardPath := filepath.Join(s.projectRoot, "components/features/UserProfile/user-card.tsx")
+	_, err := s.fs.Stat(userCardPath)
 	s.Require().NoError(err, "user-card.tsx ファイルが存在する必要があります")
 
 	// 変換を実行
-	processFiles(configKebabToCamel)
+	s.processFiles("components/features/UserProfile", configKebabToCamel)
 
 	// user-card.tsx は UserCard.tsx になるはず
-	userCardCamelPath := filepath.Join(s.tempDir, "components/features/UserProfile/UserCard.tsx")
-	_, err = os.Stat(userCardCamelPath)
+	userCardCamelPath := filepath.Join(s.projectRoot, "components/features/UserProfile/UserCard.tsx")
+	_, err = s.fs.Stat(userCardCamelPath)
 	s.Require().NoError(err, "変換後は UserCard.tsx ファイルが存在する必要があります")
 
 	// 元のファイルは存在しないはず
-	_, err = os.Stat(userCardPath)
+	_, err = s.fs.Stat(userCardPath)
 	if !os.IsNotExist(err) {
 		s.Fail("変換後は user-card.tsx ファイルは存在しないはずです")
 	}
 
 	// インポートパスも自動的に更新されていることを確認
-	content, err := os.ReadFile(userCardCamelPath)
+	content, err := s.fs.ReadFile(userCardCamelPath)
 	s.Require().NoError(err)
 	contentStr := string(content)
 	s.Contains(contentStr, "import { AvatarImage } from './AvatarImage'", "インポートパスが正しく保持されているか確認")
 
-	// パスを標準化して比較（MacOSの/privateプレフィックスに対応）
-	expectedDir, err := filepath.EvalSymlinks(s.projectRoot)
-	s.Require().NoError(err)
+	// エンジンはカレントディレクトリを変更しない
 	currentDir, err := os.Getwd()
 	s.Require().NoError(err)
-	actualDir, err := filepath.EvalSymlinks(currentDir)
-	s.Require().NoError(err)
-	
-	// テスト後のクリーンアップ: プロジェクトルートへの移動を確認
-	s.Equal(expectedDir, actualDir, "カレントディレクトリがプロジェクトルートのままであることを確認")
+	s.Equal(s.originalDir, currentDir, "カレントディレクトリが変更されていないことを確認")
 }
 
 // findDirectoryComponents のテスト
 func (s *ConverterTestSuite) TestFindDirectoryComponents() {
 	// テスト用のダミーConfig
-	config := Config{
+	config := Options{
 		ExcludeDirectories: []string{},
 	}
-	
-	dirs, err := findDirectoryComponents(s.projectRoot, config)
+
+	dirs, err := s.newEngine(config).findDirectoryComponents(s.projectRoot)
 	s.Require().NoError(err)
 	s.Len(dirs, 2, "Should find 2 directory components")
 
 	// 期待されるディレクトリパス（相対）
 	expectedDirs := map[string]bool{
-		filepath.Join(s.projectRoot, "components", "common", "IconButton"):     true,
+		filepath.Join(s.projectRoot, "components", "common", "IconButton"):    true,
 		filepath.Join(s.projectRoot, "components", "features", "user-avatar"): true,
 	}
 
@@ -370,18 +411,18 @@ func (s *ConverterTestSuite) TestFindDirectoryComponents() {
 func (s *ConverterTestSuite) TestProcessDirComponent_CamelToKebab() {
 	// IconButton ディレクトリを処理（キャメルケース→ケバブケース）
 	dirPath := filepath.Join(s.projectRoot, "components", "common", "IconButton")
-	config := Config{
+	config := Options{
 		ConversionDirection: "camel-to-kebab",
 		ExcludePatterns:     []string{},
 		DryRun:              false,
 	}
 
 	// 処理前にディレクトリが存在することを確認
-	_, err := os.Stat(dirPath)
+	_, err := s.fs.Stat(dirPath)
 	s.Require().NoError(err)
 
 	// ディレクトリコンポーネントを処理
-	result, err := processDirComponent(dirPath, config)
+	result, err := s.newEngine(config).processDirComponent(dirPath)
 	s.Require().NoError(err)
 	s.Equal("IconButton", result.OldBaseName)
 	s.Equal("icon-button", result.NewBaseName)
@@ -395,18 +436,18 @@ func (s *ConverterTestSuite) TestProcessDirComponent_CamelToKebab() {
 func (s *ConverterTestSuite) TestProcessDirComponent_KebabToCamel() {
 	// user-avatar ディレクトリを処理（ケバブケース→キャメルケース）
 	dirPath := filepath.Join(s.projectRoot, "components", "features", "user-avatar")
-	config := Config{
+	config := Options{
 		ConversionDirection: "kebab-to-camel",
 		ExcludePatterns:     []string{},
 		DryRun:              false,
 	}
 
 	// 処理前にディレクトリが存在することを確認
-	_, err := os.Stat(dirPath)
+	_, err := s.fs.Stat(dirPath)
 	s.Require().NoError(err)
 
 	// ディレクトリコンポーネントを処理
-	result, err := processDirComponent(dirPath, config)
+	result, err := s.newEngine(config).processDirComponent(dirPath)
 	s.Require().NoError(err)
 	s.Equal("user-avatar", result.OldBaseName)
 	s.Equal("UserAvatar", result.NewBaseName)
@@ -417,145 +458,103 @@ func (s *ConverterTestSuite) TestProcessDirComponent_KebabToCamel() {
 
 // ディレクトリコンポーネントの実際の変換テスト
 func (s *ConverterTestSuite) TestProcessFilesWithDirectoryComponents() {
-	// 元のfindProjectRoot関数を保存
-	origFindProjectRoot := findProjectRoot
-
-	// テスト用のモック関数
-	mockFindProjectRoot := func() (string, error) {
-		return s.projectRoot, nil
-	}
-
-	// テスト用の関数を設定
-	findProjectRoot = mockFindProjectRoot
-	// テスト後に元の関数に戻す
-	defer func() { findProjectRoot = origFindProjectRoot }()
-
 	// ディレクトリコンポーネントの変換（キャメルケース→ケバブケース）
-	config := Config{
-		TargetDir:           "components/common",
+	config := Options{
 		ConversionDirection: "camel-to-kebab",
 		ExcludePatterns:     []string{},
 		DryRun:              false,
 	}
 
 	// 変換前の状態を確認
-	iconButtonPath := filepath.Join(s.tempDir, "components/common/IconButton")
-	_, err := os.Stat(iconButtonPath)
+	iconButtonPath := filepath.Join(s.projectRoot, "components/common/IconButton")
+	_, err := s.fs.Stat(iconButtonPath)
 	s.Require().NoError(err, "IconButton ディレクトリが存在する必要があります")
 
 	// 変換を実行
-	processFiles(config)
+	s.processFiles("components/common", config)
 
 	// 変換後の状態をチェック
-	iconButtonKebabPath := filepath.Join(s.tempDir, "components/common/icon-button")
-	_, err = os.Stat(iconButtonKebabPath)
+	iconButtonKebabPath := filepath.Join(s.projectRoot, "components/common/icon-button")
+	_, err = s.fs.Stat(iconButtonKebabPath)
 	s.Require().NoError(err, "変換後は icon-button ディレクトリが存在する必要があります")
 
 	// 元のディレクトリは存在しないはず
-	_, err = os.Stat(iconButtonPath)
+	_, err = s.fs.Stat(iconButtonPath)
 	s.True(os.IsNotExist(err), "変換後は IconButton ディレクトリは存在しないはずです")
 
 	// index.tsx ファイルも新しいディレクトリ内に存在するか確認
 	indexFilePath := filepath.Join(iconButtonKebabPath, "index.tsx")
-	_, err = os.Stat(indexFilePath)
+	_, err = s.fs.Stat(indexFilePath)
 	s.Require().NoError(err, "変換後の index.tsx ファイルが存在する必要があります")
 
 	// インポートパスも自動的に更新されていることを確認
-	userAvatarIndexPath := filepath.Join(s.tempDir, "components/features/user-avatar/index.tsx")
-	content, err := os.ReadFile(userAvatarIndexPath)
+	userAvatarIndexPath := filepath.Join(s.projectRoot, "components/features/user-avatar/index.tsx")
+	content, err := s.fs.ReadFile(userAvatarIndexPath)
 	s.Require().NoError(err)
 	s.Contains(string(content), "import { IconButton } from '../../common/icon-button'", "インポートパスが更新されているはず")
 }
 
 // 新しい検索パターン（apps/*/app）のテスト
 func (s *ConverterTestSuite) TestProcessFilesWithAppsAppPattern() {
-	// 元のfindProjectRoot関数を保存
-	origFindProjectRoot := findProjectRoot
-
-	// テスト用のモック関数
-	mockFindProjectRoot := func() (string, error) {
-		return s.projectRoot, nil
-	}
-
-	// テスト用の関数を設定
-	findProjectRoot = mockFindProjectRoot
-	// テスト後に元の関数に戻す
-	defer func() { findProjectRoot = origFindProjectRoot }()
-
 	// apps/web/app ディレクトリを処理（キャメルケース→ケバブケース）
-	config := Config{
-		TargetDir:           "apps/web/app",
+	config := Options{
 		ConversionDirection: "camel-to-kebab",
 		ExcludePatterns:     []string{},
 		DryRun:              false,
 	}
 
 	// 変換前の状態を確認
-	appLayoutPath := filepath.Join(s.tempDir, "apps/web/app/AppLayout.tsx")
-	_, err := os.Stat(appLayoutPath)
+	appLayoutPath := filepath.Join(s.projectRoot, "apps/web/app/AppLayout.tsx")
+	_, err := s.fs.Stat(appLayoutPath)
 	s.Require().NoError(err, "AppLayout.tsx ファイルが存在する必要があります")
 
 	// 変換を実行
-	processFiles(config)
+	s.processFiles("apps/web/app", config)
 
 	// 変換後の状態をチェック
-	appLayoutKebabPath := filepath.Join(s.tempDir, "apps/web/app/app-layout.tsx")
-	_, err = os.Stat(appLayoutKebabPath)
+	appLayoutKebabPath := filepath.Join(s.projectRoot, "apps/web/app/app-layout.tsx")
+	_, err = s.fs.Stat(appLayoutKebabPath)
 	s.Require().NoError(err, "変換後は app-layout.tsx ファイルが存在する必要があります")
 
 	// 元のファイルは存在しないはず
-	_, err = os.Stat(appLayoutPath)
+	_, err = s.fs.Stat(appLayoutPath)
 	s.True(os.IsNotExist(err), "変換後は AppLayout.tsx ファイルは存在しないはずです")
 
 	// インポートパスも自動的に更新されていることを確認
-	pageContainerPath := filepath.Join(s.tempDir, "apps/web/app/page-container.tsx")
-	content, err := os.ReadFile(pageContainerPath)
+	pageContainerPath := filepath.Join(s.projectRoot, "apps/web/app/page-container.tsx")
+	content, err := s.fs.ReadFile(pageContainerPath)
 	s.Require().NoError(err)
 	s.Contains(string(content), "import { AppLayout } from './app-layout'", "インポートパスが更新されているはず")
 }
 
 // 新しい検索パターン（packages/ui/src）のテスト
 func (s *ConverterTestSuite) TestProcessFilesWithPackagesUIPattern() {
-	// 元のfindProjectRoot関数を保存
-	origFindProjectRoot := findProjectRoot
-
-	// テスト用のモック関数
-	mockFindProjectRoot := func() (string, error) {
-		return s.projectRoot, nil
-	}
-
-	// テスト用の関数を設定
-	findProjectRoot = mockFindProjectRoot
-	// テスト後に元の関数に戻す
-	defer func() { findProjectRoot = origFindProjectRoot }()
-
 	// packages/ui/src ディレクトリを処理（ケバブケース→キャメルケース）
-	config := Config{
-		TargetDir:           "packages/ui/src",
+	config := Options{
 		ConversionDirection: "kebab-to-camel",
 		ExcludePatterns:     []string{},
 		DryRun:              false,
 	}
 
 	// 変換前の状態を確認
-	buttonStylesPath := filepath.Join(s.tempDir, "packages/ui/src/button-styles.tsx")
-	_, err := os.Stat(buttonStylesPath)
+	buttonStylesPath := filepath.Join(s.projectRoot, "packages/ui/src/button-styles.tsx")
+	_, err := s.fs.Stat(buttonStylesPath)
 	s.Require().NoError(err, "button-styles.tsx ファイルが存在する必要があります")
 
 	// 変換を実行
-	processFiles(config)
+	s.processFiles("packages/ui/src", config)
 
 	// 変換後の状態をチェック
-	buttonStylesCamelPath := filepath.Join(s.tempDir, "packages/ui/src/ButtonStyles.tsx")
-	_, err = os.Stat(buttonStylesCamelPath)
+	buttonStylesCamelPath := filepath.Join(s.projectRoot, "packages/ui/src/ButtonStyles.tsx")
+	_, err = s.fs.Stat(buttonStylesCamelPath)
 	s.Require().NoError(err, "変換後は ButtonStyles.tsx ファイルが存在する必要があります")
 
 	// 元のファイルは存在しないはず
-	_, err = os.Stat(buttonStylesPath)
+	_, err = s.fs.Stat(buttonStylesPath)
 	s.True(os.IsNotExist(err), "変換後は button-styles.tsx ファイルは存在しないはずです")
 }
 
 // テストスイートを実行
 func TestConverterSuite(t *testing.T) {
 	suite.Run(t, new(ConverterTestSuite))
-} 
\ No newline at end of file
+}
@@ -0,0 +1,165 @@
+package renamer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ImportCycle は循環しているインポート（依存グラフの強連結成分）です
+type ImportCycle struct {
+	// 循環に含まれるファイル（プロジェクトルートからの相対パス、スラッシュ区切り、昇順）
+	Files []string `json:"files"`
+	// 循環に含まれるファイルの間の依存（インポートするファイルと行の順）
+	Edges []GraphEdge `json:"edges"`
+}
+
+// Key はベースラインに記録する循環の識別子（含まれるファイルをカンマ区切りで並べたもの）を返します
+// 循環にファイルが加わった場合は別の循環として扱います
+func (c ImportCycle) Key() string {
+	return strings.Join(c.Files, ", ")
+}
+
+// String は循環に含まれる依存を 1 行ずつ表します
+func (c ImportCycle) String() string {
+	lines := make([]string, 0, len(c.Edges))
+	for _, edge := range c.Edges {
+		lines = append(lines, fmt.Sprintf("%s:%d → %s", edge.From, edge.Line, edge.To))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FindCycles はプロジェクト全体の依存グラフから循環しているインポートを検出します
+// 依存グラフの強連結成分のうち、2 つ以上のファイルを含むものと、自分自身をインポートしているファイルを循環として返します
+// Options.CyclesIgnoreTypeOnly が true の場合は、実行時に読み込まれない型のみのインポートを無視します
+// 変換対象を限定している場合（--staged など）は、限定したファイルを含む循環だけを返します
+func (e *Engine) FindCycles() ([]ImportCycle, error) {
+	graph, err := e.BuildGraph()
+	if err != nil {
+		return nil, err
+	}
+	var cycles []ImportCycle
+	for _, cycle := range graph.Cycles(e.opts.CyclesIgnoreTypeOnly) {
+		if e.selectedCycle(cycle.Files) {
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles, nil
+}
+
+// Cycles は依存グラフの強連結成分のうち、循環しているものを返します（Tarjan のアルゴリズム）
+func (g *Graph) Cycles(ignoreTypeOnly bool) []ImportCycle {
+	follow := func(edge GraphEdge) bool {
+		return !ignoreTypeOnly || !edge.TypeOnly
+	}
+
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var visit func(file string)
+	visit = func(file string) {
+		index[file] = len(index)
+		lowlink[file] = index[file]
+		stack = append(stack, file)
+		onStack[file] = true
+
+		for _, i := range g.imports[file] {
+			edge := g.Edges[i]
+			if !follow(edge) {
+				continue
+			}
+			if _, visited := index[edge.To]; !visited {
+				visit(edge.To)
+				lowlink[file] = min(lowlink[file], lowlink[edge.To])
+			} else if onStack[edge.To] {
+				lowlink[file] = min(lowlink[file], index[edge.To])
+			}
+		}
+
+		if lowlink[file] != index[file] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == file {
+				break
+			}
+		}
+		components = append(components, component)
+	}
+	for _, node := range g.Nodes {
+		if _, visited := index[node.Path]; !visited {
+			visit(node.Path)
+		}
+	}
+
+	var cycles []ImportCycle
+	for _, component := range components {
+		members := make(map[string]bool, len(component))
+		for _, file := range component {
+			members[file] = true
+		}
+		sort.Strings(component)
+		cycle := ImportCycle{Files: component}
+		for _, file := range component {
+			for _, i := range g.imports[file] {
+				if edge := g.Edges[i]; follow(edge) && members[edge.To] {
+					cycle.Edges = append(cycle.Edges, edge)
+				}
+			}
+		}
+		// 自分自身をインポートしていない単独のファイルは循環ではない
+		if len(cycle.Edges) == 0 {
+			continue
+		}
+		cycles = append(cycles, cycle)
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].Files[0] < cycles[j].Files[0] })
+	return cycles
+}
+
+// CompareCycleBaseline は検出した循環をベースラインと照合し、ベースラインにない新たな循環と、
+// 解消されたためベースラインから削除できる循環の識別子を返します
+// 変換対象を限定している場合は、限定したファイルを含まないベースラインの循環を解消とみなしません
+func (e *Engine) CompareCycleBaseline(baseline *Baseline, cycles []ImportCycle) (introduced []ImportCycle, fixed []string) {
+	if baseline == nil {
+		return cycles, nil
+	}
+
+	known := make(map[string]bool, len(baseline.Cycles))
+	for _, key := range baseline.Cycles {
+		known[key] = true
+	}
+	found := make(map[string]bool, len(cycles))
+	for _, cycle := range cycles {
+		found[cycle.Key()] = true
+		if !known[cycle.Key()] {
+			introduced = append(introduced, cycle)
+		}
+	}
+
+	for _, key := range baseline.Cycles {
+		if !found[key] && e.selectedCycle(strings.Split(key, ", ")) {
+			fixed = append(fixed, key)
+		}
+	}
+	return introduced, fixed
+}
+
+// 循環に変換対象のファイルが含まれるかどうか
+func (e *Engine) selectedCycle(files []string) bool {
+	for _, file := range files {
+		if e.selectedFile(e.abs(filepath.FromSlash(file))) {
+			return true
+		}
+	}
+	return false
+}
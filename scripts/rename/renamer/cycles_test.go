@@ -0,0 +1,138 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CyclesTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *CyclesTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		// a → b → c → a の循環
+		"apps/web/lib/a.ts": "import { b } from './b';\n",
+		"apps/web/lib/b.ts": "import { c } from './c';\n",
+		"apps/web/lib/c.ts": "import { utils } from './utils';\nimport { a } from './a';\n",
+		// 型のみのインポートを含む循環
+		"apps/web/lib/store.ts": "import type { User } from './user';\n",
+		"apps/web/lib/user.ts":  "import { store } from './store';\n",
+		// 自分自身をインポートしているファイル
+		"apps/web/lib/self.ts": "export * from './self';\n",
+		// ワークスペースをまたぐ循環
+		"apps/web/components/Button.tsx":               "import { header } from '@kit/ui/page-header';\n",
+		"packages/ui/src/custom/page-header/index.tsx": "import { Button } from '../../../../../apps/web/components/Button';\n",
+	})
+}
+
+func (s *CyclesTestSuite) newEngine(opts Options) *Engine {
+	opts.FS = s.fs
+	engine, err := NewEngine(s.root, opts)
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *CyclesTestSuite) keys(cycles []ImportCycle) []string {
+	var keys []string
+	for _, cycle := range cycles {
+		keys = append(keys, cycle.Key())
+	}
+	return keys
+}
+
+func (s *CyclesTestSuite) TestFindCycles() {
+	cycles, err := s.newEngine(Options{}).FindCycles()
+	s.Require().NoError(err)
+	s.Equal([]string{
+		"apps/web/components/Button.tsx, packages/ui/src/custom/page-header/index.tsx",
+		"apps/web/lib/a.ts, apps/web/lib/b.ts, apps/web/lib/c.ts",
+		"apps/web/lib/self.ts",
+		"apps/web/lib/store.ts, apps/web/lib/user.ts",
+	}, s.keys(cycles))
+
+	s.Equal("apps/web/lib/a.ts:1 → apps/web/lib/b.ts\n"+
+		"apps/web/lib/b.ts:1 → apps/web/lib/c.ts\n"+
+		"apps/web/lib/c.ts:2 → apps/web/lib/a.ts", cycles[1].String(),
+		"循環の外への依存（c.ts → utils.ts）は含めない")
+}
+
+func (s *CyclesTestSuite) TestIgnoreTypeOnly() {
+	cycles, err := s.newEngine(Options{CyclesIgnoreTypeOnly: true}).FindCycles()
+	s.Require().NoError(err)
+	s.NotContains(s.keys(cycles), "apps/web/lib/store.ts, apps/web/lib/user.ts", "型のみのインポートを無視すると循環しない")
+	s.Len(cycles, 3)
+}
+
+func (s *CyclesTestSuite) TestOnlyFiles() {
+	cycles, err := s.newEngine(Options{OnlyFiles: []string{"apps/web/lib/b.ts"}}).FindCycles()
+	s.Require().NoError(err)
+	s.Equal([]string{"apps/web/lib/a.ts, apps/web/lib/b.ts, apps/web/lib/c.ts"}, s.keys(cycles),
+		"変換対象を限定した場合は限定したファイルを含む循環だけを返す")
+}
+
+func (s *CyclesTestSuite) TestCompareCycleBaseline() {
+	engine := s.newEngine(Options{})
+	cycles, err := engine.FindCycles()
+	s.Require().NoError(err)
+
+	introduced, fixed := engine.CompareCycleBaseline(nil, cycles)
+	s.Len(introduced, 4, "ベースラインがない場合はすべて新たな循環")
+	s.Empty(fixed)
+
+	baseline := &Baseline{}
+	baseline.RecordCycles(cycles[1:3])
+	baseline.Cycles = append(baseline.Cycles, "apps/web/lib/old.ts, apps/web/lib/older.ts")
+	introduced, fixed = engine.CompareCycleBaseline(baseline, cycles)
+	s.Equal([]string{
+		"apps/web/components/Button.tsx, packages/ui/src/custom/page-header/index.tsx",
+		"apps/web/lib/store.ts, apps/web/lib/user.ts",
+	}, s.keys(introduced))
+	s.Equal([]string{"apps/web/lib/old.ts, apps/web/lib/older.ts"}, fixed)
+
+	s.Equal(1, baseline.RemoveCycles(fixed))
+	s.Len(baseline.Cycles, 2)
+
+	// 循環にファイルが加わった場合は新たな循環として扱う
+	s.Require().NoError(s.fs.WriteFile(s.abs("apps/web/lib/d.ts"), []byte("import { a } from './a';\n"), 0644))
+	s.Require().NoError(s.fs.WriteFile(s.abs("apps/web/lib/b.ts"), []byte("import { c } from './c';\nimport { d } from './d';\n"), 0644))
+	engine.InvalidateIndex()
+	cycles, err = engine.FindCycles()
+	s.Require().NoError(err)
+	introduced, fixed = engine.CompareCycleBaseline(baseline, cycles)
+	s.Contains(s.keys(introduced), "apps/web/lib/a.ts, apps/web/lib/b.ts, apps/web/lib/c.ts, apps/web/lib/d.ts")
+	s.Equal([]string{"apps/web/lib/a.ts, apps/web/lib/b.ts, apps/web/lib/c.ts"}, fixed)
+}
+
+func (s *CyclesTestSuite) TestCompareCycleBaselineOnlyFiles() {
+	baseline := &Baseline{Cycles: []string{"apps/web/lib/old.ts, apps/web/lib/older.ts"}}
+	engine := s.newEngine(Options{OnlyFiles: []string{"apps/web/lib/b.ts"}})
+	_, fixed := engine.CompareCycleBaseline(baseline, nil)
+	s.Empty(fixed, "限定したファイルを含まない循環は解消とみなさない")
+}
+
+func (s *CyclesTestSuite) TestSaveBaseline() {
+	path := BaselinePath(s.root)
+	baseline := NewNamingBaseline(DirectionCamelToKebab, nil)
+	baseline.Cycles = []string{"b.ts, c.ts", "a.ts, b.ts", "a.ts, b.ts"}
+	s.Require().NoError(baseline.Save(s.fs, path))
+
+	loaded, err := LoadBaseline(s.fs, path)
+	s.Require().NoError(err)
+	s.Equal([]string{"a.ts, b.ts", "b.ts, c.ts"}, loaded.Cycles, "並べ替えて重複を除く")
+}
+
+func (s *CyclesTestSuite) abs(rel string) string {
+	return filepath.Join(s.root, filepath.FromSlash(rel))
+}
+
+func TestCyclesSuite(t *testing.T) {
+	suite.Run(t, new(CyclesTestSuite))
+}
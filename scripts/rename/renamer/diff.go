@@ -0,0 +1,296 @@
+package renamer
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 差分の前後に表示する変更のない行の数
+const diffContextLines = 3
+
+// 行単位の差分を計算する行数の上限（変更のない先頭と末尾を除いた行数の積）
+// 超えた場合は、その範囲全体を置き換えたものとして扱う
+const maxDiffCells = 1 << 24
+
+// Diff はドライランの実行結果を、git apply で適用できる git 形式の unified diff で返します
+// リネームは rename from / rename to のヘッダーで、インポートパスの書き換えはハンクで表します
+func (e *Engine) Diff(run *Run) (string, error) {
+	if run == nil || !run.DryRun() {
+		return "", fmt.Errorf("差分はドライランの実行結果からのみ作成できます")
+	}
+	before, after := run.Overlay.Base(), run.Overlay
+	journal := run.Journal
+
+	// 実行後のパス → 実行前のパス（リネームされたファイルのみ）
+	origins := make(map[string]string)
+	renamed := make(map[string]bool)
+	for _, op := range journal.Ops {
+		if op.Op != JournalOpRename || !exists(before, e.abs(filepath.FromSlash(op.From))) {
+			continue
+		}
+		err := Walk(before, e.abs(filepath.FromSlash(op.From)), func(path string, info fs.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			rel := e.Rel(path)
+			origins[journal.MapPath(rel)] = rel
+			renamed[rel] = true
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// 実行後に作成・変更・移動されたファイル
+	finals := make(map[string]bool)
+	for _, path := range journal.ChangedPaths() {
+		err := Walk(after, e.abs(filepath.FromSlash(path)), func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				if isNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !info.IsDir() {
+				finals[e.Rel(path)] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var changes []fileChange
+	for final := range finals {
+		change := fileChange{oldPath: final, newPath: final, newExists: true}
+		if origin, ok := origins[final]; ok {
+			change.oldPath = origin
+		}
+		var err error
+		if change.before, err = before.ReadFile(e.abs(filepath.FromSlash(change.oldPath))); err == nil {
+			change.oldExists = true
+		} else if !isNotExist(err) {
+			return "", err
+		}
+		if change.after, err = after.ReadFile(e.abs(filepath.FromSlash(final))); err != nil {
+			return "", err
+		}
+		changes = append(changes, change)
+	}
+
+	// 削除されたファイル
+	for _, op := range journal.Ops {
+		if op.Op != JournalOpRemove || renamed[op.Path] {
+			continue
+		}
+		path := e.abs(filepath.FromSlash(op.Path))
+		data, err := before.ReadFile(path)
+		if err != nil || exists(after, path) {
+			continue
+		}
+		changes = append(changes, fileChange{oldPath: op.Path, newPath: op.Path, before: data, oldExists: true})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].newPath < changes[j].newPath })
+	var b strings.Builder
+	for _, change := range changes {
+		change.write(&b)
+	}
+	return b.String(), nil
+}
+
+// 1 ファイルの変更
+type fileChange struct {
+	// プロジェクトルートからの相対パス（スラッシュ区切り）
+	oldPath, newPath     string
+	before, after        []byte
+	oldExists, newExists bool
+}
+
+// git 形式の差分を出力する（変更がない場合は何も出力しない）
+func (c fileChange) write(b *strings.Builder) {
+	renamed := c.oldPath != c.newPath
+	modified := !bytes.Equal(c.before, c.after) || c.oldExists != c.newExists
+	if !renamed && !modified {
+		return
+	}
+
+	fmt.Fprintf(b, "diff --git a/%s b/%s\n", c.oldPath, c.newPath)
+	oldName, newName := "a/"+c.oldPath, "b/"+c.newPath
+	switch {
+	case !c.oldExists:
+		b.WriteString("new file mode 100644\n")
+		oldName = "/dev/null"
+	case !c.newExists:
+		b.WriteString("deleted file mode 100644\n")
+		newName = "/dev/null"
+	case renamed:
+		if !modified {
+			b.WriteString("similarity index 100%\n")
+		}
+		fmt.Fprintf(b, "rename from %s\nrename to %s\n", c.oldPath, c.newPath)
+	}
+	if !modified {
+		return
+	}
+	fmt.Fprintf(b, "--- %s\n+++ %s\n", oldName, newName)
+	writeHunks(b, diffLines(splitLines(c.before), splitLines(c.after)))
+}
+
+// 行単位の差分の 1 行
+type diffLine struct {
+	// ' '（変更なし）、'-'（削除）、'+'（追加）
+	op   byte
+	text string
+}
+
+// 改行を含めて行に分割する（最後の行は改行で終わらない場合がある）
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// 最長共通部分列による行単位の差分
+func diffLines(a, b []string) []diffLine {
+	// 変更のない先頭と末尾は比較の対象から除く
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var lines []diffLine
+	for _, line := range a[:prefix] {
+		lines = append(lines, diffLine{' ', line})
+	}
+	lines = append(lines, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', line})
+	}
+	return lines
+}
+
+// 先頭と末尾を除いた範囲の差分
+func diffMiddle(a, b []string) []diffLine {
+	var lines []diffLine
+	n, m := len(a), len(b)
+	if n*m > maxDiffCells {
+		for _, line := range a {
+			lines = append(lines, diffLine{'-', line})
+		}
+		for _, line := range b {
+			lines = append(lines, diffLine{'+', line})
+		}
+		return lines
+	}
+
+	// lcs[i][j] は a[i:] と b[j:] の最長共通部分列の長さ
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	return lines
+}
+
+// 差分を前後の行を含むハンクに分けて出力する
+func writeHunks(b *strings.Builder, lines []diffLine) {
+	for start := 0; start < len(lines); {
+		// 次の変更を探す
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			return
+		}
+
+		// 変更のない行が前後の行数の 2 倍を超えて続くまでを 1 つのハンクにする
+		last := first
+		for i := first; i < len(lines); i++ {
+			if lines[i].op != ' ' {
+				last = i
+			} else if i-last > 2*diffContextLines {
+				break
+			}
+		}
+		from := max(first-diffContextLines, 0)
+		to := min(last+diffContextLines+1, len(lines))
+
+		// ハンクの開始行（1 から始まる）を数える
+		oldLine, newLine := 1, 1
+		for _, line := range lines[:from] {
+			if line.op != '+' {
+				oldLine++
+			}
+			if line.op != '-' {
+				newLine++
+			}
+		}
+		var oldCount, newCount int
+		for _, line := range lines[from:to] {
+			if line.op != '+' {
+				oldCount++
+			}
+			if line.op != '-' {
+				newCount++
+			}
+		}
+		// 行数が 0 の場合は直前の行番号を使う
+		if oldCount == 0 {
+			oldLine--
+		}
+		if newCount == 0 {
+			newLine--
+		}
+
+		fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+		for _, line := range lines[from:to] {
+			b.WriteByte(line.op)
+			b.WriteString(line.text)
+			if !strings.HasSuffix(line.text, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = to
+	}
+}
@@ -0,0 +1,120 @@
+package renamer
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *DiffTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/components/Button.tsx":            "export const Button = () => null;\n",
+		"apps/web/components/UserCard/index.tsx":    "export * from './UserCard';\n",
+		"apps/web/components/UserCard/UserCard.tsx": "export const UserCard = () => null;",
+		"apps/web/app/page.tsx": strings.Join([]string{
+			"import { Button } from '../components/Button';",
+			"import { UserCard } from '../components/UserCard';",
+			"export default function Page() {",
+			"  return null;",
+			"}",
+			"",
+		}, "\n"),
+	})
+}
+
+func (s *DiffTestSuite) dryRun() (*Engine, *Run) {
+	engine, err := NewEngine(s.root, Options{FS: s.fs, ConversionDirection: DirectionCamelToKebab, DryRun: true})
+	s.Require().NoError(err)
+	plan, err := engine.Plan([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	_, run, err := engine.ApplyRun(plan)
+	s.Require().NoError(err)
+	return engine, run
+}
+
+func (s *DiffTestSuite) TestDiff() {
+	engine, run := s.dryRun()
+	diff, err := engine.Diff(run)
+	s.Require().NoError(err)
+
+	s.Equal(`diff --git a/apps/web/app/page.tsx b/apps/web/app/page.tsx
+--- a/apps/web/app/page.tsx
++++ b/apps/web/app/page.tsx
+@@ -1,5 +1,5 @@
+-import { Button } from '../components/Button';
+-import { UserCard } from '../components/UserCard';
++import { Button } from '../components/button';
++import { UserCard } from '../components/user-card';
+ export default function Page() {
+   return null;
+ }
+diff --git a/apps/web/components/Button.tsx b/apps/web/components/button.tsx
+similarity index 100%
+rename from apps/web/components/Button.tsx
+rename to apps/web/components/button.tsx
+diff --git a/apps/web/components/UserCard/index.tsx b/apps/web/components/user-card/index.tsx
+rename from apps/web/components/UserCard/index.tsx
+rename to apps/web/components/user-card/index.tsx
+--- a/apps/web/components/UserCard/index.tsx
++++ b/apps/web/components/user-card/index.tsx
+@@ -1,1 +1,1 @@
+-export * from './UserCard';
++export * from './user-card';
+diff --git a/apps/web/components/UserCard/UserCard.tsx b/apps/web/components/user-card/user-card.tsx
+similarity index 100%
+rename from apps/web/components/UserCard/UserCard.tsx
+rename to apps/web/components/user-card/user-card.tsx
+`, diff)
+
+	// 実際のファイルシステムは変更されていない
+	s.True(exists(s.fs, filepath.Join(s.root, "apps/web/components/Button.tsx")))
+}
+
+func (s *DiffTestSuite) TestDiffRequiresDryRun() {
+	engine, err := NewEngine(s.root, Options{FS: s.fs, ConversionDirection: DirectionCamelToKebab})
+	s.Require().NoError(err)
+	_, err = engine.Diff(&Run{Journal: NewJournal(s.root, "apply")})
+	s.Error(err)
+}
+
+func (s *DiffTestSuite) TestDiffLines() {
+	var b strings.Builder
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nM"
+	writeHunks(&b, diffLines(splitLines([]byte(before)), splitLines([]byte(after))))
+	s.Equal(`@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -10,4 +10,4 @@
+ j
+ k
+ l
+-m
+\ No newline at end of file
++M
+\ No newline at end of file
+`, b.String())
+
+	// 新規ファイル
+	b.Reset()
+	writeHunks(&b, diffLines(nil, splitLines([]byte("x\n"))))
+	s.Equal("@@ -0,0 +1,1 @@\n+x\n", b.String())
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
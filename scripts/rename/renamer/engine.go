@@ -0,0 +1,216 @@
+// Package renamer は Reactコンポーネントのファイル名をキャメルケースとケバブケースの間で変換し、
+// インポートパスを更新するためのライブラリです。
+//
+// すべての処理は明示的なプロジェクトルートとオプションを受け取る Engine を通して行い、
+// カレントディレクトリやグローバル変数には依存しません。
+package renamer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// 変換エンジン
+type Engine struct {
+	root string
+	opts Options
+	fs   FS
+	out  io.Writer
+	// プロジェクトの索引（WithOutput による複製と共有する）
+	index *indexHolder
+}
+
+// 新しいエンジンを作成する
+func NewEngine(root string, opts Options) (*Engine, error) {
+	if root == "" {
+		return nil, fmt.Errorf("プロジェクトルートが指定されていません")
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクトルートの絶対パスの取得に失敗しました: %w", err)
+	}
+	fsys := opts.FS
+	if fsys == nil {
+		fsys = NewOSFS()
+	}
+	info, err := fsys.Stat(absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクトルートの確認に失敗しました: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("プロジェクトルートがディレクトリではありません: %s", absRoot)
+	}
+
+	out := opts.Output
+	if out == nil {
+		out = io.Discard
+	}
+	if opts.RewriteWorkers <= 0 {
+		opts.RewriteWorkers = runtime.NumCPU()
+	}
+
+	return &Engine{root: absRoot, opts: opts, fs: fsys, out: out, index: &indexHolder{}}, nil
+}
+
+// WithFS は別の FS を使用するエンジンの複製を返します
+func (e *Engine) WithFS(fsys FS) *Engine {
+	clone := *e
+	clone.fs = fsys
+	clone.opts.FS = fsys
+	// 索引はファイルシステムごとに作成し、読み込んだモジュール指定子だけを引き継ぐ
+	clone.index = &indexHolder{previous: e.currentIndex()}
+	return &clone
+}
+
+// WithOutput は進捗メッセージの出力先を変更したエンジンの複製を返します
+func (e *Engine) WithOutput(out io.Writer) *Engine {
+	clone := *e
+	if out == nil {
+		out = io.Discard
+	}
+	clone.out = out
+	clone.opts.Output = out
+	return &clone
+}
+
+// FS はエンジンが使用するファイルシステムを返します
+func (e *Engine) FS() FS {
+	return e.fs
+}
+
+// プロジェクトルートを返す
+func (e *Engine) Root() string {
+	return e.root
+}
+
+// オプションを返す
+func (e *Engine) Options() Options {
+	return e.opts
+}
+
+// 変換方向を設定する
+func (e *Engine) SetConversionDirection(direction string) {
+	e.opts.ConversionDirection = direction
+}
+
+// ドライランを設定する
+func (e *Engine) SetDryRun(dryRun bool) {
+	e.opts.DryRun = dryRun
+}
+
+// 移植性の問題を生じる変換計画の実行を拒否するかどうかを設定する
+func (e *Engine) SetBlockUnportable(block bool) {
+	e.opts.BlockUnportable = block
+}
+
+// パスとファイル名の長さの上限を設定する（0 の場合は現在の設定を維持する）
+func (e *Engine) SetPortabilityLimits(maxPathLength, maxNameLength int) {
+	if maxPathLength > 0 {
+		e.opts.MaxPathLength = maxPathLength
+	}
+	if maxNameLength > 0 {
+		e.opts.MaxNameLength = maxNameLength
+	}
+}
+
+// 未使用のモジュールの検出で報告しないファイルのパターンを追加する
+func (e *Engine) AllowUnused(patterns ...string) {
+	e.opts.UnusedAllow = append(e.opts.UnusedAllow, patterns...)
+}
+
+// check で循環しているインポートを検査するかどうかと、型のみのインポートを無視するかどうかを設定する
+func (e *Engine) SetCycleCheck(check, ignoreTypeOnly bool) {
+	e.opts.CheckCycles = check
+	e.opts.CyclesIgnoreTypeOnly = ignoreTypeOnly
+}
+
+// リネームや移動の後に更新するバレルの設定を設定する（nil の場合は更新しない）
+func (e *Engine) SetBarrels(config *BarrelConfig) {
+	e.opts.Barrels = config
+}
+
+// 索引のキャッシュファイルのパスを設定する（空の場合は保存しない）
+func (e *Engine) SetIndexCachePath(path string) {
+	e.opts.IndexCachePath = path
+}
+
+// 進捗メッセージを出力する
+func (e *Engine) printf(format string, args ...interface{}) {
+	fmt.Fprintf(e.out, format, args...)
+}
+
+// 進捗メッセージを改行付きで出力する
+func (e *Engine) println(args ...interface{}) {
+	fmt.Fprintln(e.out, args...)
+}
+
+// デバッグモードのときだけ出力する
+func (e *Engine) debugf(format string, args ...interface{}) {
+	if e.opts.DebugMode {
+		fmt.Fprintf(e.out, format, args...)
+	}
+}
+
+// プロジェクトルートからの相対パスを絶対パスに変換する
+func (e *Engine) abs(rel string) string {
+	if filepath.IsAbs(rel) {
+		return rel
+	}
+	return filepath.Join(e.root, rel)
+}
+
+// Rel は絶対パスをプロジェクトルートからの相対パス（スラッシュ区切り）に変換します
+func (e *Engine) Rel(path string) string {
+	rel, err := filepath.Rel(e.root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// FindProjectRoot は start から上位ディレクトリに向かってプロジェクトルートを探します
+func FindProjectRoot(start string) (string, error) {
+	currentDir, err := filepath.Abs(start)
+	if err != nil {
+		return "", fmt.Errorf("ディレクトリの絶対パスの取得に失敗しました: %v", err)
+	}
+
+	// 開始ディレクトリが既にプロジェクトルートである可能性も考慮
+	if hasProjectRootMarkers(currentDir) {
+		return currentDir, nil
+	}
+
+	// 親ディレクトリをチェック
+	for {
+		parentDir := filepath.Dir(currentDir)
+		if parentDir == currentDir {
+			// これ以上上位ディレクトリがない
+			return "", fmt.Errorf("プロジェクトルートが見つかりませんでした")
+		}
+
+		currentDir = parentDir
+		if hasProjectRootMarkers(currentDir) {
+			return currentDir, nil
+		}
+	}
+}
+
+// プロジェクトルートかどうかを判断するヘルパー関数
+func hasProjectRootMarkers(dir string) bool {
+	// プロジェクトルートを判断するマーカー
+	markers := []string{
+		filepath.Join(dir, "apps"),
+		filepath.Join(dir, "packages"),
+		filepath.Join(dir, "package.json"),
+	}
+
+	for _, marker := range markers {
+		if _, err := os.Stat(marker); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+package renamer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ExportedSymbol はモジュールがエクスポートしている識別子です
+type ExportedSymbol struct {
+	// エクスポートしている識別子（export { A as B } の場合は A）
+	Name string `json:"name"`
+	// 1 から始まる行番号
+	Line int `json:"line"`
+}
+
+// ModuleExports はモジュールの default エクスポートと名前付きエクスポートです
+type ModuleExports struct {
+	// default エクスポートの識別子（ない場合と無名の関数・クラス・式の場合は nil）
+	Default *ExportedSymbol `json:"default,omitempty"`
+	// 名前付きの値のエクスポート（出現順、型と他のモジュールからの再エクスポートを除く）
+	Named []ExportedSymbol `json:"named,omitempty"`
+}
+
+var (
+	// export default function Name / export default class Name（無名の場合はグループが空）
+	defaultDeclarationRegex = regexp.MustCompile(`(?m)^[ \t]*export\s+default\s+(?:async\s+)?(?:function\b\s*\*?|class\b)\s*([A-Za-z_$][\w$]*)?`)
+	// export default Name; / export default memo(Name);
+	defaultExpressionRegex = regexp.MustCompile(`(?m)^[ \t]*export\s+default\s+(?:[A-Za-z_$][\w$.]*\(\s*)?([A-Za-z_$][\w$]*)\s*\)?\s*;?[ \t]*$`)
+	// export function Name / export class Name / export const Name / export enum Name
+	namedDeclarationRegex = regexp.MustCompile(`(?m)^[ \t]*export\s+(?:declare\s+)?(?:(?:async\s+)?function\b\s*\*?\s*|(?:abstract\s+)?class\s+|(?:const|let|var)\s+|(?:const\s+)?enum\s+)([A-Za-z_$][\w$]*)`)
+	// export { A, B as C }（export type { } と export { } from '...' はグループで判定する）
+	exportListRegex = regexp.MustCompile(`(?m)^[ \t]*export\s*(type\s*)?\{([^}]*)\}(\s*from\b)?`)
+)
+
+// ScanExports はソースコードから default エクスポートと名前付きの値のエクスポートの識別子を取り出します
+// コメントや文字列リテラルの中に書かれたエクスポートは無視します
+func ScanExports(content []byte) ModuleExports {
+	masked := maskNonCode(content)
+	lines := lineStarts(content)
+	symbol := func(start, end int) ExportedSymbol {
+		return ExportedSymbol{Name: string(content[start:end]), Line: lineAt(lines, start)}
+	}
+
+	var exports ModuleExports
+	anonymous := false
+	for _, match := range defaultDeclarationRegex.FindAllSubmatchIndex(masked, -1) {
+		if match[2] < 0 {
+			anonymous = true
+			continue
+		}
+		s := symbol(match[2], match[3])
+		exports.Default = &s
+	}
+	if exports.Default == nil && !anonymous {
+		for _, match := range defaultExpressionRegex.FindAllSubmatchIndex(masked, -1) {
+			s := symbol(match[2], match[3])
+			exports.Default = &s
+		}
+	}
+
+	type named struct {
+		ExportedSymbol
+		offset int
+	}
+	var names []named
+	for _, match := range namedDeclarationRegex.FindAllSubmatchIndex(masked, -1) {
+		names = append(names, named{symbol(match[2], match[3]), match[2]})
+	}
+	for _, match := range exportListRegex.FindAllSubmatchIndex(masked, -1) {
+		if match[2] >= 0 || match[6] >= 0 {
+			continue
+		}
+		offset := match[4]
+		for _, item := range strings.Split(string(masked[match[4]:match[5]]), ",") {
+			start := offset + len(item) - len(strings.TrimLeft(item, " \t\r\n"))
+			offset += len(item) + 1
+			fields := strings.Fields(item)
+			if len(fields) == 0 || fields[0] == "type" {
+				continue
+			}
+			local := ExportedSymbol{Name: fields[0], Line: lineAt(lines, start)}
+			if len(fields) == 3 && fields[1] == "as" && fields[2] == "default" {
+				exports.Default = &local
+				continue
+			}
+			names = append(names, named{local, start})
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i].offset < names[j].offset })
+	for _, name := range names {
+		exports.Named = append(exports.Named, name.ExportedSymbol)
+	}
+	return exports
+}
+
+// ExportNameMismatch は主なエクスポートの識別子がファイル名と一致しないモジュールです
+type ExportNameMismatch struct {
+	// プロジェクトルートからの相対パス（スラッシュ区切り）
+	Path string `json:"path"`
+	// 主なエクスポートの識別子と行番号
+	Symbol string `json:"symbol"`
+	Line   int    `json:"line"`
+	// default エクスポートかどうか（false の場合は主な名前付きの値のエクスポート）
+	Default bool `json:"default,omitempty"`
+	// ファイル名（index の場合はディレクトリ名）をパスカルケースにした名前
+	Expected string `json:"expected"`
+	// ファイル名を識別子に合わせて変更した場合のパス（ディレクトリ型コンポーネントの場合はディレクトリのパス）
+	SuggestedPath string `json:"suggestedPath"`
+	// 識別子をファイル名に合わせて変更した場合の名前
+	SuggestedSymbol string `json:"suggestedSymbol"`
+	// ディレクトリ型コンポーネント（index）かどうか
+	IsDir bool `json:"isDir,omitempty"`
+}
+
+// String は "パス:行: 識別子（ファイル名から期待する名前: 名前）" の形式で不一致を表します
+func (m ExportNameMismatch) String() string {
+	kind := "export"
+	if m.Default {
+		kind = "export default"
+	}
+	return fmt.Sprintf("%s:%d: %s %s（ファイル名から期待する名前: %s）", m.Path, m.Line, kind, m.Symbol, m.Expected)
+}
+
+// FindExportMismatches は dirs（プロジェクトルートからの相対パス）配下のモジュールのうち、
+// 主なエクスポートの識別子とファイル名をパスカルケースにした名前が一致しないものを返します
+// 主なエクスポートは default エクスポート（識別子のあるもの）で、ない場合は最初のパスカルケースの名前付きの値のエクスポート、
+// それもない場合は唯一の名前付きの値のエクスポートです（型のエクスポートは数えず、大文字の定数は検査しません）
+// 大文字小文字と区切り（"-" / "_"）は区別しないため、use-mobile.ts の useMobile や json-ld.tsx の JsonLd は一致とみなします
+// index のファイルはディレクトリ名と比べ、Next.js のエントリ・ストーリー・テスト・型定義ファイルと除外パターンに一致するファイルは検査しません
+func (e *Engine) FindExportMismatches(dirs []string) ([]ExportNameMismatch, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+	apps := e.nextApps(resolver)
+	seen := make(map[string]bool)
+	var mismatches []ExportNameMismatch
+	for _, dir := range dirs {
+		files, err := e.findSourceFiles(e.abs(dir))
+		if err != nil {
+			return nil, fmt.Errorf("%s のファイル一覧の取得に失敗しました: %w", dir, err)
+		}
+		for _, file := range files {
+			rel := e.Rel(file)
+			if seen[rel] || !isUnusedCandidate(rel) || isEntryFile(rel, apps) || e.excludedFileName(filepath.Base(file)) {
+				continue
+			}
+			seen[rel] = true
+			content, err := e.fs.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", rel, err)
+			}
+			if mismatch, ok := e.exportMismatch(rel, ScanExports(content)); ok {
+				mismatches = append(mismatches, mismatch)
+			}
+		}
+	}
+	sort.Slice(mismatches, func(i, j int) bool { return mismatches[i].Path < mismatches[j].Path })
+	return mismatches, nil
+}
+
+// 除外パターン（除外設定の exclude_files）に一致するファイル名かどうか
+func (e *Engine) excludedFileName(name string) bool {
+	for _, pattern := range e.opts.ExcludePatterns {
+		if strings.Contains(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// エクスポートとファイル名を比べ、一致しない場合は修正の候補を返す
+// default エクスポートまたはいずれかの名前付きエクスポートがファイル名と一致する場合は一致とみなし、
+// どれも一致しない場合だけ主なエクスポートを選んで修正の候補を作成する
+func (e *Engine) exportMismatch(rel string, exports ModuleExports) (ExportNameMismatch, bool) {
+	mismatch := ExportNameMismatch{Path: rel}
+
+	// ファイル名の最初の "." より前（index の場合はディレクトリ名）と比べる
+	dir, base := path.Split(rel)
+	name, suffix, found := strings.Cut(base, ".")
+	if found {
+		suffix = "." + suffix
+	}
+	if name == "index" {
+		dir, name = path.Split(strings.TrimSuffix(dir, "/"))
+		suffix, mismatch.IsDir = "", true
+	}
+	if name == "" {
+		return mismatch, false
+	}
+	if exports.Default != nil && normalizeSymbolName(exports.Default.Name) == normalizeSymbolName(name) {
+		return mismatch, false
+	}
+	for _, symbol := range exports.Named {
+		if normalizeSymbolName(symbol.Name) == normalizeSymbolName(name) {
+			return mismatch, false
+		}
+	}
+
+	if exports.Default != nil {
+		mismatch.Symbol, mismatch.Line, mismatch.Default = exports.Default.Name, exports.Default.Line, true
+	} else if primary, ok := primaryNamedExport(exports.Named); ok {
+		mismatch.Symbol, mismatch.Line = primary.Name, primary.Line
+	} else {
+		return mismatch, false
+	}
+	// 定数（CATEGORIES など）はモジュールの名前を表さない
+	if strings.ToUpper(mismatch.Symbol) == mismatch.Symbol {
+		return mismatch, false
+	}
+
+	mismatch.Expected = pascalCase(name)
+	mismatch.SuggestedSymbol = mismatch.Expected
+	if first := []rune(mismatch.Symbol)[0]; unicode.IsLower(first) {
+		mismatch.SuggestedSymbol = lowerFirst(mismatch.Expected)
+	}
+	// ファイル名は現在の書き方（ケバブケース・パスカルケース・キャメルケース）に合わせる
+	var newName string
+	switch first := []rune(name)[0]; {
+	case IsKebabCase(name):
+		newName = camelToKebab(mismatch.Symbol, e.opts.PreserveAcronymCase)
+	case unicode.IsUpper(first):
+		newName = pascalCase(mismatch.Symbol)
+	default:
+		newName = lowerFirst(mismatch.Symbol)
+	}
+	mismatch.SuggestedPath = dir + newName + suffix
+	return mismatch, true
+}
+
+// ファイル名と一致する名前付きエクスポートがない場合に、モジュールの名前を表すとみなすエクスポート
+// 最初のパスカルケースの識別子（コンポーネントやクラス）で、ない場合は唯一のエクスポート（useMobile などのフック）
+func primaryNamedExport(named []ExportedSymbol) (ExportedSymbol, bool) {
+	for _, symbol := range named {
+		if first := []rune(symbol.Name)[0]; unicode.IsUpper(first) && strings.ToUpper(symbol.Name) != symbol.Name {
+			return symbol, true
+		}
+	}
+	if len(named) == 1 {
+		return named[0], true
+	}
+	return ExportedSymbol{}, false
+}
+
+// 比較のため、大文字小文字と区切り（"-" / "_"）を取り除く
+func normalizeSymbolName(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+}
+
+// ケバブケース・スネークケース・キャメルケースの名前をパスカルケースにする（"user-card" → "UserCard"）
+func pascalCase(name string) string {
+	pascal := KebabToCamel(strings.ReplaceAll(name, "_", "-"))
+	if pascal == "" {
+		return pascal
+	}
+	runes := []rune(pascal)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// 先頭の 1 文字を小文字にする
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,108 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportsTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *ExportsTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"package.json":                               `{"name": "root", "workspaces": ["apps/*"]}`,
+		"apps/web/package.json":                      `{"name": "web"}`,
+		"apps/web/components/user-card.tsx":          "export default function ProfileTile() {}\n",
+		"apps/web/components/UserAvatar.tsx":         "const Avatar = () => null;\nexport default memo(Avatar);\n",
+		"apps/web/components/site-header.tsx":        "export function SiteHeader() {}\nexport type Props = {};\n",
+		"apps/web/components/json-ld.tsx":            "export const JsonLd = () => null;\n",
+		"apps/web/components/footer.tsx":             "export { Bottom as default };\nfunction Bottom() {}\n",
+		"apps/web/components/user-menu/index.tsx":    "export function AccountMenu() {}\n",
+		"apps/web/components/Button.stories.tsx":     "export default { title: 'Button' };\nexport const Primary = {};\n",
+		"apps/web/components/anonymous.tsx":          "export default function () {}\n",
+		"apps/web/lib/use-mobile.ts":                 "export function useMobile() {}\n",
+		"apps/web/lib/format.ts":                     "export function formatDate() {}\nexport function formatTime() {}\n",
+		"apps/web/lib/use-toast.ts":                  "export const useNotify = () => {};\n",
+		"apps/web/lib/types.ts":                      "export type Category = string;\nexport const CATEGORIES = [];\n",
+		"apps/web/app/dashboard/page.tsx":            "export default function DashboardPage() {}\n",
+		"apps/web/components/profile/EditForm.tsx":   "export default class ProfileEditor {}\n",
+		"apps/web/components/profile/edit-form.d.ts": "export declare const Anything: string;\n",
+		"apps/web/components/profile-card.tsx":       "export type ProfileCardProps = {};\nexport const formatName = () => '';\nexport function UserBadge() {}\n",
+		"apps/web/components/user-list.tsx":          "export const userListKeys = {};\nexport function UserList() {}\nexport type Row = {};\n",
+		"apps/web/components/captcha-provider.tsx":   "export const CaptchaContext = createContext(null);\nexport function CaptchaProvider() {}\n",
+	})
+}
+
+func (s *ExportsTestSuite) find(dirs ...string) []ExportNameMismatch {
+	engine, err := NewEngine(s.root, Options{FS: s.fs, ExcludePatterns: []string{"page.tsx"}})
+	s.Require().NoError(err)
+	mismatches, err := engine.FindExportMismatches(dirs)
+	s.Require().NoError(err)
+	return mismatches
+}
+
+func (s *ExportsTestSuite) TestScanExports() {
+	exports := ScanExports([]byte("// export default function Commented() {}\n" +
+		"const text = 'export const Quoted = 1';\n" +
+		"export default async function Page() {}\n" +
+		"export const a = 1, b = 2;\n" +
+		"export async function load() {}\n" +
+		"export abstract class Base {}\n" +
+		"export enum Color {}\n" +
+		"export interface Props {}\n" +
+		"export { c, d as e, type F };\n" +
+		"export type { G };\n" +
+		"export { h } from './h';\n" +
+		"export * from './i';\n"))
+	s.Equal(&ExportedSymbol{Name: "Page", Line: 3}, exports.Default)
+	s.Equal([]ExportedSymbol{
+		{Name: "a", Line: 4},
+		{Name: "load", Line: 5},
+		{Name: "Base", Line: 6},
+		{Name: "Color", Line: 7},
+		{Name: "c", Line: 9},
+		{Name: "d", Line: 9},
+	}, exports.Named, "型と再エクスポートは含めない")
+
+	s.Equal(&ExportedSymbol{Name: "Tile", Line: 2}, ScanExports([]byte("function Tile() {}\nexport default Tile;\n")).Default)
+	s.Equal(&ExportedSymbol{Name: "Tile", Line: 1}, ScanExports([]byte("export default forwardRef(Tile);\n")).Default)
+	s.Equal(&ExportedSymbol{Name: "Tile", Line: 2}, ScanExports([]byte("export {\n  Tile as default,\n};\n")).Default)
+	s.Nil(ScanExports([]byte("export default function () {}\n")).Default)
+	s.Nil(ScanExports([]byte("export default {\n  title: 'Button',\n};\n")).Default)
+}
+
+// ファイル名と一致しない default エクスポートと唯一の名前付きエクスポートを検出する
+func (s *ExportsTestSuite) TestFindExportMismatches() {
+	mismatches := s.find("apps/web")
+	s.Equal([]ExportNameMismatch{
+		{Path: "apps/web/components/UserAvatar.tsx", Symbol: "Avatar", Line: 2, Default: true, Expected: "UserAvatar", SuggestedPath: "apps/web/components/Avatar.tsx", SuggestedSymbol: "UserAvatar"},
+		{Path: "apps/web/components/footer.tsx", Symbol: "Bottom", Line: 1, Default: true, Expected: "Footer", SuggestedPath: "apps/web/components/bottom.tsx", SuggestedSymbol: "Footer"},
+		{Path: "apps/web/components/profile-card.tsx", Symbol: "UserBadge", Line: 3, Expected: "ProfileCard", SuggestedPath: "apps/web/components/user-badge.tsx", SuggestedSymbol: "ProfileCard"},
+		{Path: "apps/web/components/profile/EditForm.tsx", Symbol: "ProfileEditor", Line: 1, Default: true, Expected: "EditForm", SuggestedPath: "apps/web/components/profile/ProfileEditor.tsx", SuggestedSymbol: "EditForm"},
+		{Path: "apps/web/components/user-card.tsx", Symbol: "ProfileTile", Line: 1, Default: true, Expected: "UserCard", SuggestedPath: "apps/web/components/profile-tile.tsx", SuggestedSymbol: "UserCard"},
+		{Path: "apps/web/components/user-menu/index.tsx", Symbol: "AccountMenu", Line: 1, Expected: "UserMenu", SuggestedPath: "apps/web/components/account-menu", SuggestedSymbol: "UserMenu", IsDir: true},
+		{Path: "apps/web/lib/use-toast.ts", Symbol: "useNotify", Line: 1, Expected: "UseToast", SuggestedPath: "apps/web/lib/use-notify.ts", SuggestedSymbol: "useToast"},
+	}, mismatches, "一致するもの・主なエクスポートがないもの・定数・ストーリー・Next.js のエントリ・型定義ファイルは報告しない（型やヘルパーと一緒にエクスポートしたコンポーネントは検査し、ファイル名と一致するエクスポートが後にある場合は一致とみなす）")
+	s.Equal("apps/web/components/user-card.tsx:1: export default ProfileTile（ファイル名から期待する名前: UserCard）", mismatches[4].String())
+
+	s.Len(s.find("apps/web/lib", "apps/web/lib/use-toast.ts"), 1, "同じファイルは 1 回だけ報告する")
+}
+
+func (s *ExportsTestSuite) TestPascalCase() {
+	s.Equal("UserCard", pascalCase("user-card"))
+	s.Equal("UserCard", pascalCase("user_card"))
+	s.Equal("UseMobile", pascalCase("useMobile"))
+	s.Equal("UserCard", pascalCase("UserCard"))
+	s.Equal("useMobile", lowerFirst("UseMobile"))
+}
+
+func TestExportsSuite(t *testing.T) {
+	suite.Run(t, new(ExportsTestSuite))
+}
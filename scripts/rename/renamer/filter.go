@@ -0,0 +1,99 @@
+package renamer
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// ParseFileList は NUL 区切りまたは改行区切りのファイル一覧を分割します
+// NUL を含む場合は NUL 区切りとみなし、空の要素は無視します
+func ParseFileList(data []byte) []string {
+	sep := []byte("\n")
+	if bytes.IndexByte(data, 0) >= 0 {
+		sep = []byte{0}
+	}
+	var files []string
+	for _, item := range bytes.Split(data, sep) {
+		file := strings.TrimRight(string(item), "\r")
+		if strings.TrimSpace(file) != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// SetOnlyFiles は変換対象を files（プロジェクトルートからの相対パスまたは絶対パス）に限定します
+// nil を指定すると限定を解除します
+func (e *Engine) SetOnlyFiles(files []string) {
+	if files == nil {
+		e.opts.OnlyFiles = nil
+		return
+	}
+	e.opts.OnlyFiles = make([]string, 0, len(files))
+	for _, file := range files {
+		e.opts.OnlyFiles = append(e.opts.OnlyFiles, e.Rel(e.abs(filepath.FromSlash(file))))
+	}
+}
+
+// FilterDirs は dirs（プロジェクトルートからの相対パス）のうち、変換対象のファイルを含むものを返します
+// 変換対象を限定していない場合は dirs をそのまま返します
+func (e *Engine) FilterDirs(dirs []string) []string {
+	if e.opts.OnlyFiles == nil {
+		return dirs
+	}
+	var filtered []string
+	for _, dir := range dirs {
+		if e.selectedDir(e.abs(dir)) {
+			filtered = append(filtered, dir)
+		}
+	}
+	return filtered
+}
+
+// ファイルが変換対象かどうか
+func (e *Engine) selectedFile(path string) bool {
+	if e.opts.OnlyFiles == nil {
+		return true
+	}
+	return contains(e.opts.OnlyFiles, e.Rel(path))
+}
+
+// ディレクトリ配下に変換対象のファイルがあるかどうか
+func (e *Engine) selectedDir(path string) bool {
+	if e.opts.OnlyFiles == nil {
+		return true
+	}
+	prefix := e.Rel(path) + "/"
+	for _, file := range e.opts.OnlyFiles {
+		if strings.HasPrefix(file, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// 条件を満たすパスだけを返す
+func filterPaths(paths []string, keep func(string) bool) []string {
+	var filtered []string
+	for _, path := range paths {
+		if keep(path) {
+			filtered = append(filtered, path)
+		}
+	}
+	return filtered
+}
+
+// インポートパスを更新する範囲を求める
+// 従来どおり対象ディレクトリの親ディレクトリを基本とし、それがワークスペースの内側にある場合はワークスペース全体に広げる
+func (e *Engine) importScope(targetDir string) string {
+	scope := e.abs(filepath.Dir(targetDir))
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return scope
+	}
+	if ws := resolver.WorkspaceOf(scope); ws != nil {
+		return ws.Path
+	}
+	return scope
+}
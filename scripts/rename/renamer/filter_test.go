@@ -0,0 +1,104 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestParseFileList(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"改行区切り", "a/Button.tsx\nb/card.tsx\n", []string{"a/Button.tsx", "b/card.tsx"}},
+		{"CRLF", "a/Button.tsx\r\nb/card.tsx\r\n", []string{"a/Button.tsx", "b/card.tsx"}},
+		{"NUL 区切り（改行を含む名前）", "a/Button.tsx\x00b/odd\nname.tsx\x00", []string{"a/Button.tsx", "b/odd\nname.tsx"}},
+		{"空の要素", "\n\na.tsx\n  \n", []string{"a.tsx"}},
+		{"空", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseFileList([]byte(tt.data)))
+		})
+	}
+}
+
+type FilterTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *FilterTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"package.json":                           `{"name": "root", "workspaces": ["apps/*"]}`,
+		"apps/web/package.json":                  `{"name": "web"}`,
+		"apps/web/components/Button.tsx":         "export const Button = () => null;",
+		"apps/web/components/UserCard/index.tsx": "export const UserCard = () => null;",
+		"apps/web/components/NavBar.tsx":         "export const NavBar = () => null;",
+		"apps/web/lib/LegacyCard.tsx":            "export const LegacyCard = () => null;",
+		"apps/web/app/dashboard/page.tsx":        "import { Button } from '../../components/Button';\nimport { NavBar } from '../../components/NavBar';\n",
+	})
+}
+
+func (s *FilterTestSuite) newEngine() *Engine {
+	engine, err := NewEngine(s.root, Options{FS: s.fs, ConversionDirection: DirectionCamelToKebab})
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *FilterTestSuite) TestPlanOnlyFiles() {
+	engine := s.newEngine()
+	engine.SetOnlyFiles([]string{"apps/web/components/Button.tsx", filepath.Join(s.root, "apps/web/components/UserCard/index.tsx")})
+	s.Equal([]string{"apps/web/components"}, engine.FilterDirs([]string{"apps/web/components", "apps/web/lib"}))
+
+	plan, err := engine.Plan([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	var names []string
+	for _, rename := range plan.Dirs[0].Renames {
+		names = append(names, rename.NewBaseName)
+	}
+	s.Equal([]string{"button", "user-card"}, names, "NavBar.tsx は対象外")
+
+	// 限定を解除するとすべて対象になる
+	engine.SetOnlyFiles(nil)
+	s.Equal([]string{"apps/web/components", "apps/web/lib"}, engine.FilterDirs([]string{"apps/web/components", "apps/web/lib"}))
+	plan, err = engine.Plan([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	s.Equal(3, plan.RenameCount())
+}
+
+func (s *FilterTestSuite) TestImportsUpdatedAcrossWorkspace() {
+	engine := s.newEngine()
+	engine.SetOnlyFiles([]string{"apps/web/components/Button.tsx"})
+	plan, err := engine.Plan([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	s.Require().Equal(1, plan.RenameCount())
+
+	_, err = engine.Apply(plan)
+	s.Require().NoError(err)
+
+	// 対象ディレクトリの外（ワークスペース内）のインポートも更新される
+	data, err := s.fs.ReadFile(filepath.Join(s.root, "apps/web/app/dashboard/page.tsx"))
+	s.Require().NoError(err)
+	s.Equal("import { Button } from '../../components/button';\nimport { NavBar } from '../../components/NavBar';\n", string(data))
+}
+
+func (s *FilterTestSuite) TestImportScope() {
+	engine := s.newEngine()
+	s.Equal(filepath.Join(s.root, "apps/web"), engine.importScope("apps/web/components/forms"))
+	s.Equal(filepath.Join(s.root, "apps/web"), engine.importScope("apps/web/components"))
+	// ワークスペースの外は従来どおり親ディレクトリ
+	s.Equal(filepath.Join(s.root, "apps"), engine.importScope("apps/web"))
+	s.Equal(filepath.Join(s.root, "scripts"), engine.importScope("scripts/tools"))
+}
+
+func TestFilterSuite(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
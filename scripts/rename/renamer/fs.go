@@ -0,0 +1,109 @@
+package renamer
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FS はエンジンが使用するファイルシステムの抽象化です
+// パスはすべて絶対パス（OS のパス区切り）で扱います
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	Rename(oldpath, newpath string) error
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(name string) error
+}
+
+// OSFS は実際のファイルシステムを操作する FS の実装です
+type OSFS struct{}
+
+// NewOSFS は実際のファイルシステムを操作する FS を返します
+func NewOSFS() FS {
+	return OSFS{}
+}
+
+func (OSFS) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+
+func (OSFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (OSFS) Rename(oldpath, newpath string) error { return os.Rename(oldpath, newpath) }
+
+func (OSFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+func (OSFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+
+func (OSFS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+
+func (OSFS) Remove(name string) error { return os.Remove(name) }
+
+// Walk は filepath.Walk と同じ規則で FS 上のファイルツリーを走査します
+// エントリは名前順に走査され、filepath.SkipDir と filepath.SkipAll に対応します
+func Walk(fsys FS, root string, fn filepath.WalkFunc) error {
+	info, err := fsys.Stat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walk(fsys, root, info, fn)
+	}
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+// Walk の再帰処理
+func walk(fsys FS, path string, info fs.FileInfo, fn filepath.WalkFunc) error {
+	if !info.IsDir() {
+		return fn(path, info, nil)
+	}
+
+	entries, err := fsys.ReadDir(path)
+	err1 := fn(path, info, err)
+	// ディレクトリの読み込みに失敗した場合、またはコールバックがスキップを指示した場合は子を走査しない
+	if err != nil || err1 != nil {
+		return err1
+	}
+
+	return walkEntries(fsys, path, entries, fn)
+}
+
+// ディレクトリ path の子 entries を名前順に走査する
+func walkEntries(fsys FS, path string, entries []fs.DirEntry, fn filepath.WalkFunc) error {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, entry := range entries {
+		childPath := filepath.Join(path, entry.Name())
+		childInfo, err := fsys.Stat(childPath)
+		if err != nil {
+			if err := fn(childPath, childInfo, err); err != nil && err != filepath.SkipDir {
+				return err
+			}
+			continue
+		}
+		err = walk(fsys, childPath, childInfo, fn)
+		if err != nil {
+			if !childInfo.IsDir() || err != filepath.SkipDir {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ファイルまたはディレクトリが存在するかどうか
+func exists(fsys FS, path string) bool {
+	_, err := fsys.Stat(path)
+	return err == nil
+}
+
+// ファイルが存在しないエラーかどうか
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
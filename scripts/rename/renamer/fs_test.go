@@ -0,0 +1,205 @@
+package renamer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FSTestSuite struct {
+	suite.Suite
+	base *MemFS
+	root string
+}
+
+func (s *FSTestSuite) SetupTest() {
+	s.base = NewMemFS()
+	s.root = filepath.FromSlash("/project")
+
+	files := map[string]string{
+		"components/UserCard/index.tsx":         "export * from './UserCard';",
+		"components/UserCard/UserCard.tsx":      "export function UserCard() {}",
+		"components/Button.tsx":                 "export function Button() {}",
+		"app/page.tsx":                          "import { Button } from '../components/Button';",
+		"app/(auth)/layout.tsx":                 "export default function Layout() {}",
+		"lib/utils.ts":                          "export const noop = () => {};",
+		"lib/server/action.ts":                  "'use server';",
+		"node_modules/react/package.json":       "{}",
+		"components/UserCard/styles.module.css": ".root {}",
+	}
+	for path, content := range files {
+		full := filepath.Join(s.root, filepath.FromSlash(path))
+		s.Require().NoError(s.base.MkdirAll(filepath.Dir(full), 0755))
+		s.Require().NoError(s.base.WriteFile(full, []byte(content), 0644))
+	}
+}
+
+func (s *FSTestSuite) path(rel string) string {
+	return filepath.Join(s.root, filepath.FromSlash(rel))
+}
+
+// FS 上のファイル一覧をルートからの相対パスで返す
+func (s *FSTestSuite) files(fsys FS) []string {
+	var files []string
+	err := Walk(fsys, s.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			rel, _ := filepath.Rel(s.root, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+	return files
+}
+
+func (s *FSTestSuite) TestMemFSReadWrite() {
+	data, err := s.base.ReadFile(s.path("lib/utils.ts"))
+	s.NoError(err)
+	s.Equal("export const noop = () => {};", string(data))
+
+	// 親ディレクトリが存在しない場合は書き込めない
+	err = s.base.WriteFile(s.path("missing/file.ts"), []byte(""), 0644)
+	s.True(os.IsNotExist(err))
+
+	_, err = s.base.ReadFile(s.path("missing.ts"))
+	s.True(os.IsNotExist(err))
+
+	// ディレクトリは読み込めない
+	_, err = s.base.ReadFile(s.path("lib"))
+	s.Error(err)
+}
+
+func (s *FSTestSuite) TestMemFSRenameDirectory() {
+	err := s.base.Rename(s.path("components/UserCard"), s.path("components/user-card"))
+	s.Require().NoError(err)
+
+	s.False(exists(s.base, s.path("components/UserCard")))
+	data, err := s.base.ReadFile(s.path("components/user-card/UserCard.tsx"))
+	s.NoError(err)
+	s.Equal("export function UserCard() {}", string(data))
+
+	// 空でないディレクトリへの移動は失敗する
+	err = s.base.Rename(s.path("components/user-card"), s.path("lib"))
+	s.Error(err)
+
+	// 自身の配下への移動は失敗する
+	err = s.base.Rename(s.path("lib"), s.path("lib/server/lib"))
+	s.Error(err)
+}
+
+func (s *FSTestSuite) TestMemFSRemove() {
+	// 空でないディレクトリは削除できない
+	s.Error(s.base.Remove(s.path("lib/server")))
+
+	s.NoError(s.base.Remove(s.path("lib/server/action.ts")))
+	s.NoError(s.base.Remove(s.path("lib/server")))
+	s.False(exists(s.base, s.path("lib/server")))
+
+	entries, err := s.base.ReadDir(s.path("lib"))
+	s.NoError(err)
+	s.Len(entries, 1)
+	s.Equal("utils.ts", entries[0].Name())
+}
+
+func (s *FSTestSuite) TestWalkSkipDir() {
+	var visited []string
+	err := Walk(s.base, s.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == "node_modules" {
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(s.root, path)
+		visited = append(visited, filepath.ToSlash(rel))
+		return nil
+	})
+	s.NoError(err)
+	s.NotContains(visited, "node_modules/react/package.json")
+	s.Contains(visited, "app/(auth)/layout.tsx")
+
+	// 名前順に走査される
+	s.Equal([]string{".", "app", "app/(auth)", "app/(auth)/layout.tsx", "app/page.tsx"}, visited[:5])
+}
+
+func (s *FSTestSuite) TestOverlayDoesNotModifyBase() {
+	overlay := NewOverlayFS(s.base)
+	before := s.files(s.base)
+
+	s.Require().NoError(overlay.WriteFile(s.path("app/page.tsx"), []byte("updated"), 0644))
+	s.Require().NoError(overlay.Rename(s.path("components/Button.tsx"), s.path("components/button.tsx")))
+	s.Require().NoError(overlay.Remove(s.path("lib/utils.ts")))
+
+	// 下層は変更されない
+	s.Equal(before, s.files(s.base))
+	data, err := s.base.ReadFile(s.path("app/page.tsx"))
+	s.NoError(err)
+	s.Equal("import { Button } from '../components/Button';", string(data))
+
+	// オーバーレイからは変更後の状態が見える
+	data, err = overlay.ReadFile(s.path("app/page.tsx"))
+	s.NoError(err)
+	s.Equal("updated", string(data))
+	s.True(exists(overlay, s.path("components/button.tsx")))
+	s.False(exists(overlay, s.path("components/Button.tsx")))
+	s.False(exists(overlay, s.path("lib/utils.ts")))
+
+	s.Equal([]string{s.path("app/page.tsx"), s.path("components/button.tsx")}, overlay.ChangedFiles())
+	s.Equal([]string{s.path("components/Button.tsx"), s.path("lib/utils.ts")}, overlay.RemovedPaths())
+}
+
+func (s *FSTestSuite) TestOverlayRenameDirectory() {
+	overlay := NewOverlayFS(s.base)
+
+	s.Require().NoError(overlay.Rename(s.path("components/UserCard"), s.path("components/user-card")))
+
+	s.False(exists(overlay, s.path("components/UserCard/index.tsx")))
+	data, err := overlay.ReadFile(s.path("components/user-card/index.tsx"))
+	s.NoError(err)
+	s.Equal("export * from './UserCard';", string(data))
+
+	entries, err := overlay.ReadDir(s.path("components"))
+	s.NoError(err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	s.Equal([]string{"Button.tsx", "user-card"}, names)
+
+	// 移動元と同じ名前で作り直したディレクトリには下層の内容が見えない
+	s.Require().NoError(overlay.MkdirAll(s.path("components/UserCard"), 0755))
+	entries, err = overlay.ReadDir(s.path("components/UserCard"))
+	s.NoError(err)
+	s.Empty(entries)
+}
+
+func (s *FSTestSuite) TestOverlayCaseOnlyRename() {
+	overlay := NewOverlayFS(s.base)
+
+	// 大文字小文字のみの変更は一時名を経由する
+	temp := s.path("components/_temp_UserCard")
+	s.Require().NoError(overlay.Rename(s.path("components/UserCard"), temp))
+	s.Require().NoError(overlay.Rename(temp, s.path("components/usercard")))
+
+	s.Equal([]string{
+		"app/(auth)/layout.tsx",
+		"app/page.tsx",
+		"components/Button.tsx",
+		"components/usercard/UserCard.tsx",
+		"components/usercard/index.tsx",
+		"components/usercard/styles.module.css",
+		"lib/server/action.ts",
+		"lib/utils.ts",
+		"node_modules/react/package.json",
+	}, s.files(overlay))
+	s.NotContains(overlay.RemovedPaths(), temp)
+}
+
+func TestFSSuite(t *testing.T) {
+	suite.Run(t, new(FSTestSuite))
+}
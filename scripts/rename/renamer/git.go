@@ -0,0 +1,350 @@
+package renamer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Git は作業ツリー内で git コマンドを実行します
+type Git struct {
+	// git コマンドを実行するディレクトリ（絶対パス）
+	dir string
+}
+
+// OpenGit は dir を含む git の作業ツリーを開きます
+func OpenGit(dir string) (*Git, error) {
+	if _, err := runGit(dir, "rev-parse", "--show-toplevel"); err != nil {
+		return nil, fmt.Errorf("git リポジトリではありません: %w", err)
+	}
+	return &Git{dir: dir}, nil
+}
+
+// Run は git コマンドを実行し、標準出力を返します
+func (g *Git) Run(args ...string) (string, error) {
+	return runGit(g.dir, args...)
+}
+
+// IsTracked は path（ディレクトリの場合は配下のいずれか）が git で追跡されているかどうかを返します
+func (g *Git) IsTracked(path string) (bool, error) {
+	out, err := g.Run("ls-files", "-z", "--", path)
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// Move は git mv でリネームし、インデックスに記録します
+// 大文字小文字だけが異なるリネームは、core.ignorecase が有効な環境でも記録されるよう一時的な名前を経由します
+func (g *Git) Move(oldpath, newpath string) error {
+	if !strings.EqualFold(oldpath, newpath) || oldpath == newpath {
+		_, err := g.Run("mv", "--", oldpath, newpath)
+		return err
+	}
+
+	tempPath := filepath.Join(filepath.Dir(oldpath), fmt.Sprintf("_temp_%s_%d", filepath.Base(oldpath), time.Now().UnixNano()))
+	if _, err := g.Run("mv", "--", oldpath, tempPath); err != nil {
+		return err
+	}
+	if _, err := g.Run("mv", "--", tempPath, newpath); err != nil {
+		// 失敗したら元に戻す
+		g.Run("mv", "--", tempPath, oldpath)
+		return err
+	}
+	return nil
+}
+
+// Add は paths の変更（削除を含む）をインデックスに記録します
+// trackedOnly が true の場合は、既に追跡されているファイルの変更だけを記録します
+func (g *Git) Add(paths []string, trackedOnly bool) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	args := []string{"add", "-A", "--"}
+	if trackedOnly {
+		args = []string{"add", "-u", "--"}
+	}
+	_, err := g.Run(append(args, paths...)...)
+	return err
+}
+
+// DirtyFiles はコミットされていない変更（ステージ済みを含む）がある追跡中のファイルを返します
+// 追跡されていないファイルは含めません
+func (g *Git) DirtyFiles() ([]string, error) {
+	out, err := g.Run("status", "--porcelain", "-z", "--untracked-files=no")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	entries := strings.Split(out, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		files = append(files, entry[3:])
+		// リネームとコピーは元のパスが続く
+		if strings.ContainsAny(entry[:2], "RC") {
+			i++
+		}
+	}
+	return files, nil
+}
+
+// ChangedFiles は ref 以降に追加・変更されたファイル（作業ツリーの変更と追跡されていないファイルを含む）を、
+// git コマンドを実行するディレクトリからの相対パス（スラッシュ区切り）で返します
+// 削除されたファイルは含めません
+func (g *Git) ChangedFiles(ref string) ([]string, error) {
+	diff, err := g.Run("diff", "--name-only", "-z", "--relative", "--diff-filter=ACMR", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := g.Run("ls-files", "-z", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	files := append(ParseFileList([]byte(diff)), ParseFileList([]byte(untracked))...)
+	sort.Strings(files)
+	return dedupeSorted(files), nil
+}
+
+// StagedFiles はインデックスに追加・変更されたファイルを、git コマンドを実行するディレクトリからの相対パス（スラッシュ区切り）で返します
+func (g *Git) StagedFiles() ([]string, error) {
+	out, err := g.Run("diff", "--cached", "--name-only", "-z", "--relative", "--diff-filter=ACMR")
+	if err != nil {
+		return nil, err
+	}
+	return ParseFileList([]byte(out)), nil
+}
+
+// HooksDir は git フックを配置するディレクトリ（core.hooksPath を反映）の絶対パスを返します
+func (g *Git) HooksDir() (string, error) {
+	out, err := g.Run("rev-parse", "--path-format=absolute", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.FromSlash(strings.TrimSpace(out)), nil
+}
+
+// CurrentBranch は現在のブランチ名を返します
+func (g *Git) CurrentBranch() (string, error) {
+	out, err := g.Run("rev-parse", "--abbrev-ref", "HEAD")
+	return strings.TrimSpace(out), err
+}
+
+// CreateBranch は現在のコミットから新しいブランチを作成して切り替えます
+func (g *Git) CreateBranch(name string) error {
+	_, err := g.Run("switch", "--quiet", "-c", name)
+	return err
+}
+
+// HasStagedChanges はコミットされていない変更がインデックスにあるかどうかを返します
+func (g *Git) HasStagedChanges() (bool, error) {
+	_, err := g.Run("diff", "--cached", "--quiet")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return true, nil
+	}
+	return false, err
+}
+
+// IndexChanges はインデックスに記録された変更があるファイルを、作業ツリー内の dir からの相対パス（スラッシュ区切り）で返します
+// StagedFiles と違い削除を含め、リネームは元と先のパスに分けて返します（dir の外のファイルは ":/" を付けた作業ツリーのルートからのパス）
+func (g *Git) IndexChanges() ([]string, error) {
+	prefix, err := g.Run("rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+	prefix = strings.TrimSpace(prefix)
+	out, err := g.Run("diff", "--cached", "--name-only", "--no-renames", "-z")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, name := range strings.Split(out, "\x00") {
+		if name == "" {
+			continue
+		}
+		if rel, ok := strings.CutPrefix(name, prefix); ok {
+			files = append(files, rel)
+		} else {
+			files = append(files, ":/"+name)
+		}
+	}
+	return files, nil
+}
+
+// Commit はインデックスの内容をコミットし、コミットのハッシュを返します
+// インデックスに変更がない場合は何もせず空文字列を返します
+func (g *Git) Commit(message string) (string, error) {
+	staged, err := g.HasStagedChanges()
+	if err != nil || !staged {
+		return "", err
+	}
+	if _, err := g.Run("commit", "--quiet", "-m", message); err != nil {
+		return "", err
+	}
+	out, err := g.Run("rev-parse", "HEAD")
+	return strings.TrimSpace(out), err
+}
+
+// git コマンドを実行する
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("git %s: %s: %w", args[0], msg, err)
+		}
+		return stdout.String(), fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// GitFS は git で追跡されているファイルとディレクトリのリネームを git mv で行う FS の実装です
+// 追跡されていないパスのリネームとその他の操作は下層の FS に委譲します
+type GitFS struct {
+	base FS
+	git  *Git
+}
+
+// NewGitFS は base に対する操作のうち、リネームを git mv で行う FS を作成します
+func NewGitFS(base FS, git *Git) *GitFS {
+	return &GitFS{base: base, git: git}
+}
+
+// Git はリネームに使用する git の作業ツリーを返します
+func (f *GitFS) Git() *Git {
+	return f.git
+}
+
+func (f *GitFS) ReadFile(name string) ([]byte, error) { return f.base.ReadFile(name) }
+
+func (f *GitFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return f.base.WriteFile(name, data, perm)
+}
+
+func (f *GitFS) Stat(name string) (fs.FileInfo, error) { return f.base.Stat(name) }
+
+func (f *GitFS) ReadDir(name string) ([]fs.DirEntry, error) { return f.base.ReadDir(name) }
+
+func (f *GitFS) MkdirAll(path string, perm fs.FileMode) error { return f.base.MkdirAll(path, perm) }
+
+func (f *GitFS) Remove(name string) error { return f.base.Remove(name) }
+
+func (f *GitFS) Rename(oldpath, newpath string) error {
+	tracked, err := f.git.IsTracked(oldpath)
+	if err != nil {
+		return err
+	}
+	if !tracked {
+		return f.base.Rename(oldpath, newpath)
+	}
+	return f.git.Move(oldpath, newpath)
+}
+
+// CommitRun は実行した変更を、リネームと内容の変更の 2 つのコミットに分けて記録し、作成したコミットのハッシュを返します
+// 実行で変更していないパスの変更がインデックスにある場合は、コミットに混ざらないようエラーを返します
+// git mv によるリネームは内容を変更する前の状態でインデックスに記録されているため、
+// 最初のコミットは内容が同一のリネームだけになり、git log --follow で履歴をたどれます
+func (e *Engine) CommitRun(run *Run, renameMessage, editMessage string) ([]string, error) {
+	gitFS, ok := e.fs.(*GitFS)
+	if !ok {
+		return nil, fmt.Errorf("git モードで実行していません")
+	}
+	if run == nil || run.DryRun() {
+		return nil, nil
+	}
+	git := gitFS.Git()
+
+	// git commit はインデックス全体を記録するため、実行前からステージされていた変更があればコミットしない
+	staged, err := git.IndexChanges()
+	if err != nil {
+		return nil, fmt.Errorf("インデックスの状態の取得に失敗しました: %w", err)
+	}
+	for _, path := range staged {
+		if !run.Journal.Touches(path) {
+			return nil, fmt.Errorf("実行とは関係のない変更がステージされています: %s（先にコミットするか git restore --staged で取り消してください）", path)
+		}
+	}
+
+	var commits []string
+	hash, err := git.Commit(renameMessage)
+	if err != nil {
+		return commits, fmt.Errorf("リネームのコミットに失敗しました: %w", err)
+	}
+	if hash != "" {
+		commits = append(commits, hash)
+	}
+
+	// 変更したファイルのうち追跡されているものと、新たに作成したファイルをステージする
+	// リネームしたディレクトリにある追跡されていないファイルは対象にしない
+	if err := git.Add(e.existingPaths(run.Journal.ChangedPaths()), true); err != nil {
+		return commits, fmt.Errorf("変更のステージに失敗しました: %w", err)
+	}
+	if err := git.Add(e.existingPaths(run.Journal.CreatedPaths()), false); err != nil {
+		return commits, fmt.Errorf("変更のステージに失敗しました: %w", err)
+	}
+	hash, err = git.Commit(editMessage)
+	if err != nil {
+		return commits, fmt.Errorf("内容の変更のコミットに失敗しました: %w", err)
+	}
+	if hash != "" {
+		commits = append(commits, hash)
+	}
+	return commits, nil
+}
+
+// CommitMessages は変換結果から、リネームのコミットと内容の変更のコミットのメッセージを生成します
+// 件名には変換方向と件数を、本文には対象ディレクトリごとの件数を記載します
+func (e *Engine) CommitMessages(direction string, results []ConversionResult) (renameMessage, editMessage string) {
+	var processed, skipped, failed int
+	importFiles := make(map[string]bool)
+	var renameBody, editBody strings.Builder
+	for _, result := range results {
+		processed += result.ProcessedFiles
+		skipped += result.SkippedFiles
+		failed += result.ErrorFiles
+		fmt.Fprintf(&renameBody, "- %s: 変換 %d 件、スキップ %d 件", result.TargetDir, result.ProcessedFiles, result.SkippedFiles)
+		if result.ErrorFiles > 0 {
+			fmt.Fprintf(&renameBody, "、エラー %d 件", result.ErrorFiles)
+		}
+		renameBody.WriteString("\n")
+		if len(result.ImportUpdateFiles) > 0 {
+			fmt.Fprintf(&editBody, "- %s: %d ファイル\n", result.TargetDir, len(result.ImportUpdateFiles))
+		}
+		for _, file := range result.ImportUpdateFiles {
+			importFiles[file] = true
+		}
+	}
+
+	renameMessage = fmt.Sprintf("ファイル名を %s に変換（%d ディレクトリ、%d 件）\n\n対象ディレクトリ:\n%s",
+		direction, len(results), processed, renameBody.String())
+	renameMessage += fmt.Sprintf("\n合計: 変換 %d 件、スキップ %d 件、エラー %d 件\n", processed, skipped, failed)
+
+	editMessage = fmt.Sprintf("インポートパスを更新（%s、%d ファイル）\n", direction, len(importFiles))
+	if editBody.Len() > 0 {
+		editMessage += "\nディレクトリごとの更新対象ファイル数:\n" + editBody.String()
+	}
+	return renameMessage, editMessage
+}
+
+// 相対パスのうち存在するものを絶対パスで返す
+func (e *Engine) existingPaths(paths []string) []string {
+	var existing []string
+	for _, path := range paths {
+		if abs := e.abs(filepath.FromSlash(path)); exists(e.fs, abs) {
+			existing = append(existing, abs)
+		}
+	}
+	return existing
+}
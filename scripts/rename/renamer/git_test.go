@@ -0,0 +1,244 @@
+package renamer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GitTestSuite struct {
+	suite.Suite
+	root string
+	git  *Git
+}
+
+func (s *GitTestSuite) SetupTest() {
+	if _, err := exec.LookPath("git"); err != nil {
+		s.T().Skip("git が見つかりません")
+	}
+	s.T().Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	s.T().Setenv("GIT_AUTHOR_NAME", "test")
+	s.T().Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	s.T().Setenv("GIT_COMMITTER_NAME", "test")
+	s.T().Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	s.root = s.T().TempDir()
+	writeTestFiles(&s.Suite, NewOSFS(), s.root, map[string]string{
+		"package.json":                     `{"name": "root"}`,
+		"components/Header.tsx":            "export const Header = () => null;\n",
+		"components/UserCard/index.tsx":    "export * from './UserCard';\n",
+		"components/UserCard/UserCard.tsx": "export const UserCard = () => null;\n",
+		"app/page.tsx":                     "import { Header } from '../components/Header';\nimport { UserCard } from '../components/UserCard';\n",
+	})
+	_, err := runGit(s.root, "init", "--quiet")
+	s.Require().NoError(err)
+	s.gitRun("add", "-A")
+	s.gitRun("commit", "--quiet", "-m", "initial")
+
+	s.git, err = OpenGit(s.root)
+	s.Require().NoError(err)
+}
+
+func (s *GitTestSuite) gitRun(args ...string) string {
+	out, err := runGit(s.root, args...)
+	s.Require().NoError(err)
+	return strings.TrimSpace(out)
+}
+
+func (s *GitTestSuite) path(rel string) string {
+	return filepath.Join(s.root, filepath.FromSlash(rel))
+}
+
+func (s *GitTestSuite) newEngine() *Engine {
+	engine, err := NewEngine(s.root, Options{
+		FS:                  NewGitFS(NewOSFS(), s.git),
+		ConversionDirection: DirectionCamelToKebab,
+	})
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *GitTestSuite) TestOpenGitOutsideRepository() {
+	_, err := OpenGit(s.T().TempDir())
+	s.Error(err)
+}
+
+func (s *GitTestSuite) TestCaseOnlyMoveWithIgnoreCase() {
+	s.gitRun("config", "core.ignorecase", "true")
+	s.Require().NoError(s.git.Move(s.path("components/Header.tsx"), s.path("components/header.tsx")))
+
+	s.Equal("R  components/Header.tsx -> components/header.tsx", s.gitRun("status", "--porcelain"))
+	s.True(exists(NewOSFS(), s.path("components/header.tsx")))
+}
+
+func (s *GitTestSuite) TestRenameUntrackedFile() {
+	s.Require().NoError(os.WriteFile(s.path("components/Draft.tsx"), nil, 0644))
+	fsys := NewGitFS(NewOSFS(), s.git)
+	s.Require().NoError(fsys.Rename(s.path("components/Draft.tsx"), s.path("components/draft.tsx")))
+
+	s.Equal("?? components/draft.tsx", s.gitRun("status", "--porcelain"))
+}
+
+func (s *GitTestSuite) TestApplyAndCommitRun() {
+	engine := s.newEngine()
+	plan, err := engine.Plan([]string{"components"})
+	s.Require().NoError(err)
+	s.Require().Equal(3, plan.RenameCount())
+
+	_, run, err := engine.ApplyRun(plan)
+	s.Require().NoError(err)
+	s.True(run.Journal.Git)
+
+	// 作成したファイルはステージされ、追跡されていないファイルは対象にならない
+	s.Require().NoError(os.WriteFile(s.path("components/user-card/notes.txt"), nil, 0644))
+	run.Journal.record(JournalOp{Op: JournalOpWrite, Path: "components/new.ts"})
+	s.Require().NoError(os.WriteFile(s.path("components/new.ts"), nil, 0644))
+
+	commits, err := engine.CommitRun(run, "rename files", "update imports")
+	s.Require().NoError(err)
+	s.Len(commits, 2)
+
+	// 1 つ目のコミットは内容が同一のリネームだけ
+	s.Equal(strings.Join([]string{
+		"R100\tcomponents/Header.tsx\tcomponents/header.tsx",
+		"R100\tcomponents/UserCard/index.tsx\tcomponents/user-card/index.tsx",
+		"R100\tcomponents/UserCard/UserCard.tsx\tcomponents/user-card/user-card.tsx",
+	}, "\n"), s.gitRun("show", "--format=", "--name-status", "-M", commits[0]))
+
+	// 2 つ目のコミットは内容の変更
+	s.Equal(strings.Join([]string{
+		"M\tapp/page.tsx",
+		"A\tcomponents/new.ts",
+		"M\tcomponents/user-card/index.tsx",
+	}, "\n"), s.gitRun("show", "--format=", "--name-status", commits[1]))
+	s.Equal("?? components/user-card/notes.txt", s.gitRun("status", "--porcelain"))
+
+	// 履歴をたどれる
+	s.Equal("update imports\nrename files\ninitial", s.gitRun("log", "--follow", "--format=%s", "--", "components/user-card/index.tsx"))
+}
+
+// 実行前からステージされていた変更があるとコミットしない
+func (s *GitTestSuite) TestCommitRunRefusesUnrelatedStagedChanges() {
+	s.Require().NoError(os.WriteFile(s.path("package.json"), []byte(`{"name": "changed"}`), 0644))
+	s.gitRun("add", "package.json")
+
+	engine := s.newEngine()
+	plan, err := engine.Plan([]string{"components"})
+	s.Require().NoError(err)
+	_, run, err := engine.ApplyRun(plan)
+	s.Require().NoError(err)
+
+	commits, err := engine.CommitRun(run, "rename files", "update imports")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "package.json")
+	s.Empty(commits)
+	s.Equal("initial", s.gitRun("log", "--format=%s"))
+}
+
+func (s *GitTestSuite) TestRollbackRestoresIndex() {
+	engine := s.newEngine()
+	plan, err := engine.Plan([]string{"components"})
+	s.Require().NoError(err)
+	_, run, err := engine.ApplyRun(plan)
+	s.Require().NoError(err)
+	s.NotEmpty(s.gitRun("status", "--porcelain"))
+
+	s.Require().NoError(engine.Rollback(run))
+	s.Empty(s.gitRun("status", "--porcelain"))
+}
+
+func (s *GitTestSuite) TestDirtyFiles() {
+	files, err := s.git.DirtyFiles()
+	s.Require().NoError(err)
+	s.Empty(files)
+
+	// 追跡されていないファイルは含めない
+	s.Require().NoError(os.WriteFile(s.path("components/Draft.tsx"), nil, 0644))
+	s.Require().NoError(os.WriteFile(s.path("app/page.tsx"), []byte("changed"), 0644))
+	s.gitRun("mv", "components/Header.tsx", "components/header.tsx")
+
+	files, err = s.git.DirtyFiles()
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"app/page.tsx", "components/header.tsx"}, files)
+}
+
+func (s *GitTestSuite) TestChangedFiles() {
+	s.Require().NoError(os.WriteFile(s.path("components/Header.tsx"), []byte("changed"), 0644))
+	s.gitRun("commit", "--quiet", "-am", "change header")
+	s.Require().NoError(os.WriteFile(s.path("app/page.tsx"), []byte("changed"), 0644))
+	s.Require().NoError(os.WriteFile(s.path("components/Draft.tsx"), nil, 0644))
+	s.gitRun("rm", "--quiet", "components/UserCard/UserCard.tsx")
+
+	files, err := s.git.ChangedFiles("HEAD~1")
+	s.Require().NoError(err)
+	s.Equal([]string{"app/page.tsx", "components/Draft.tsx", "components/Header.tsx"}, files, "削除されたファイルは含めない")
+
+	// サブディレクトリで実行した場合はそのディレクトリからの相対パス
+	git, err := OpenGit(s.path("components"))
+	s.Require().NoError(err)
+	files, err = git.ChangedFiles("HEAD")
+	s.Require().NoError(err)
+	s.Equal([]string{"Draft.tsx"}, files)
+}
+
+func (s *GitTestSuite) TestStagedFiles() {
+	s.Require().NoError(os.WriteFile(s.path("components/Header.tsx"), []byte("changed"), 0644))
+	s.Require().NoError(os.WriteFile(s.path("components/Draft.tsx"), nil, 0644))
+	s.Require().NoError(os.WriteFile(s.path("app/page.tsx"), []byte("changed"), 0644))
+	s.gitRun("add", "components")
+
+	files, err := s.git.StagedFiles()
+	s.Require().NoError(err)
+	s.Equal([]string{"components/Draft.tsx", "components/Header.tsx"}, files)
+}
+
+func (s *GitTestSuite) TestHooksDir() {
+	dir, err := s.git.HooksDir()
+	s.Require().NoError(err)
+	s.Equal(s.path(".git/hooks"), dir)
+
+	s.gitRun("config", "core.hooksPath", ".githooks")
+	dir, err = s.git.HooksDir()
+	s.Require().NoError(err)
+	s.Equal(s.path(".githooks"), dir)
+}
+
+func (s *GitTestSuite) TestCreateBranch() {
+	s.Require().NoError(s.git.CreateBranch("rename/test"))
+	branch, err := s.git.CurrentBranch()
+	s.Require().NoError(err)
+	s.Equal("rename/test", branch)
+
+	s.Error(s.git.CreateBranch("rename/test"), "既に存在するブランチは作成できない")
+}
+
+func (s *GitTestSuite) TestCommitMessages() {
+	engine := s.newEngine()
+	renameMessage, editMessage := engine.CommitMessages(DirectionCamelToKebab, []ConversionResult{
+		{TargetDir: "apps/web/components", ProcessedFiles: 3, SkippedFiles: 1, ImportUpdateFiles: []string{"/a.tsx", "/b.tsx"}},
+		{TargetDir: "packages/ui/src", ProcessedFiles: 2, ErrorFiles: 1, ImportUpdateFiles: []string{"/b.tsx"}},
+	})
+
+	s.Equal(`ファイル名を camel-to-kebab に変換（2 ディレクトリ、5 件）
+
+対象ディレクトリ:
+- apps/web/components: 変換 3 件、スキップ 1 件
+- packages/ui/src: 変換 2 件、スキップ 0 件、エラー 1 件
+
+合計: 変換 5 件、スキップ 1 件、エラー 1 件
+`, renameMessage)
+	s.Equal(`インポートパスを更新（camel-to-kebab、2 ファイル）
+
+ディレクトリごとの更新対象ファイル数:
+- apps/web/components: 2 ファイル
+- packages/ui/src: 1 ファイル
+`, editMessage)
+}
+
+func TestGitSuite(t *testing.T) {
+	suite.Run(t, new(GitTestSuite))
+}
@@ -0,0 +1,318 @@
+package renamer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 依存グラフの出力形式
+const (
+	GraphFormatDOT  = "dot"
+	GraphFormatJSON = "json"
+)
+
+// GraphFormats は依存グラフの出力形式の一覧です
+var GraphFormats = []string{GraphFormatDOT, GraphFormatJSON}
+
+// GraphNode は依存グラフのファイルです
+type GraphNode struct {
+	// プロジェクトルートからの相対パス（スラッシュ区切り）
+	Path string `json:"path"`
+	// ファイルを含むワークスペースのディレクトリ（どのワークスペースにも含まれない場合は空）
+	Workspace string `json:"workspace,omitempty"`
+}
+
+// GraphEdge は解決できたモジュール指定子による依存です
+type GraphEdge struct {
+	// インポートするファイル（プロジェクトルートからの相対パス、スラッシュ区切り）
+	From string `json:"from"`
+	// インポートされるファイル（プロジェクトルートからの相対パス、スラッシュ区切り）
+	To string `json:"to"`
+	// モジュール指定子
+	Specifier string `json:"specifier"`
+	// 1 から始まる行番号
+	Line int `json:"line"`
+	// 解決方法（relative / alias / workspace / baseUrl）
+	Kind string `json:"kind"`
+	// import type / export type による型のみのインポートかどうか
+	TypeOnly bool `json:"typeOnly,omitempty"`
+	// import() または require() によるインポートかどうか
+	Dynamic bool `json:"dynamic,omitempty"`
+	// ワークスペースをまたぐ依存かどうか
+	CrossWorkspace bool `json:"crossWorkspace,omitempty"`
+}
+
+// String は "インポートするファイル:行: 'モジュール指定子'" の形式で依存を表します
+func (e GraphEdge) String() string {
+	return fmt.Sprintf("%s:%d: '%s'", e.From, e.Line, e.Specifier)
+}
+
+// GraphImpact は変更の影響を受けるファイルです
+type GraphImpact struct {
+	// プロジェクトルートからの相対パス（スラッシュ区切り）
+	Path string `json:"path"`
+	// 変更したファイルからの依存の段数（直接インポートしている場合は 1）
+	Depth int `json:"depth"`
+}
+
+// Graph はプロジェクト内のソースファイルをノード、解決できたモジュール指定子を辺とする依存グラフです
+// 外部パッケージと node_modules 内のファイルへの依存は含めません
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+
+	// ファイル → そのファイルをインポートする辺 / そのファイルがインポートする辺（Edges のインデックス）
+	importers map[string][]int
+	imports   map[string][]int
+}
+
+// BuildGraph はプロジェクト全体のソースファイルのモジュール指定子を解決し、依存グラフを作成します
+// 大文字小文字が一致しない指定子も、解決先のファイルへの依存として扱います
+func (e *Engine) BuildGraph() (*Graph, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+	files, err := e.findSourceFiles(e.root)
+	if err != nil {
+		return nil, fmt.Errorf("ファイル一覧の取得に失敗しました: %w", err)
+	}
+
+	workspaces := make(map[string]string)
+	workspaceOf := func(path string) string {
+		rel := e.Rel(path)
+		if dir, ok := workspaces[rel]; ok {
+			return dir
+		}
+		var dir string
+		if ws := resolver.WorkspaceOf(path); ws != nil {
+			dir = ws.Dir
+		}
+		workspaces[rel] = dir
+		return dir
+	}
+
+	var edges []GraphEdge
+	for _, file := range files {
+		from := workspaceOf(file)
+		refs, err := e.Imports(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+		}
+		for _, ref := range refs {
+			res := resolver.Resolve(file, ref.Specifier)
+			if res.Status != ResolveOK && res.Status != ResolveCaseMismatch {
+				continue
+			}
+			if res.Path == "" || inNodeModules([]string{res.Path}) {
+				continue
+			}
+			to := e.Rel(res.Path)
+			if to == ".." || strings.HasPrefix(to, "../") {
+				continue
+			}
+			edges = append(edges, GraphEdge{
+				From:           e.Rel(file),
+				To:             to,
+				Specifier:      ref.Specifier,
+				Line:           ref.Line,
+				Kind:           res.Kind,
+				TypeOnly:       ref.TypeOnly,
+				Dynamic:        ref.Dynamic,
+				CrossWorkspace: from != workspaceOf(res.Path),
+			})
+		}
+	}
+	return newGraph(workspaces, edges), nil
+}
+
+// ノードと辺から依存グラフを作成する
+func newGraph(workspaces map[string]string, edges []GraphEdge) *Graph {
+	g := &Graph{
+		Nodes:     []GraphNode{},
+		Edges:     edges,
+		importers: make(map[string][]int),
+		imports:   make(map[string][]int),
+	}
+	for path, workspace := range workspaces {
+		g.Nodes = append(g.Nodes, GraphNode{Path: path, Workspace: workspace})
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].Path < g.Nodes[j].Path })
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].Line < g.Edges[j].Line
+	})
+	if g.Edges == nil {
+		g.Edges = []GraphEdge{}
+	}
+	for i, edge := range g.Edges {
+		g.importers[edge.To] = append(g.importers[edge.To], i)
+		g.imports[edge.From] = append(g.imports[edge.From], i)
+	}
+	return g
+}
+
+// path（プロジェクトルートからの相対パス）がファイルの場合はそのファイル、
+// ディレクトリの場合は配下のファイルに一致するかどうかを判定する関数を返す
+func graphTarget(path string) func(string) bool {
+	path = strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(path), "./"), "/")
+	return func(file string) bool {
+		return file == path || path == "." || strings.HasPrefix(file, path+"/")
+	}
+}
+
+// Has は path（ファイルまたはディレクトリ）に一致するノードがあるかどうかを返します
+func (g *Graph) Has(path string) bool {
+	match := graphTarget(path)
+	for _, node := range g.Nodes {
+		if match(node.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ImportersOf は path（ファイルまたはディレクトリ）を直接インポートしている依存を返します
+// ディレクトリの場合は、配下のファイルどうしの依存を含めません
+func (g *Graph) ImportersOf(path string) []GraphEdge {
+	match := graphTarget(path)
+	var edges []GraphEdge
+	for _, edge := range g.Edges {
+		if match(edge.To) && !match(edge.From) {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// ImportsOf は path（ファイルまたはディレクトリ）が直接インポートしている依存を返します
+// ディレクトリの場合は、配下のファイルどうしの依存を含めません
+func (g *Graph) ImportsOf(path string) []GraphEdge {
+	match := graphTarget(path)
+	var edges []GraphEdge
+	for _, edge := range g.Edges {
+		if match(edge.From) && !match(edge.To) {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// Impact は path（ファイルまたはディレクトリ）を直接または間接にインポートしているファイルを、
+// 依存の段数の順（同じ段数の場合はパスの順）に返します
+func (g *Graph) Impact(path string) []GraphImpact {
+	match := graphTarget(path)
+	depths := make(map[string]int)
+	var queue []string
+	for _, node := range g.Nodes {
+		if match(node.Path) {
+			depths[node.Path] = 0
+			queue = append(queue, node.Path)
+		}
+	}
+	var impacts []GraphImpact
+	for len(queue) > 0 {
+		file := queue[0]
+		queue = queue[1:]
+		for _, i := range g.importers[file] {
+			from := g.Edges[i].From
+			if _, ok := depths[from]; ok {
+				continue
+			}
+			depths[from] = depths[file] + 1
+			impacts = append(impacts, GraphImpact{Path: from, Depth: depths[from]})
+			queue = append(queue, from)
+		}
+	}
+	sort.Slice(impacts, func(i, j int) bool {
+		if impacts[i].Depth != impacts[j].Depth {
+			return impacts[i].Depth < impacts[j].Depth
+		}
+		return impacts[i].Path < impacts[j].Path
+	})
+	return impacts
+}
+
+// Importers は path（ファイルまたはディレクトリ）を直接インポートしているファイルを重複なく名前順に返します
+func (g *Graph) Importers(path string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, edge := range g.ImportersOf(path) {
+		if !seen[edge.From] {
+			seen[edge.From] = true
+			files = append(files, edge.From)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// WriteGraph は依存グラフを指定した形式で出力します
+func WriteGraph(w io.Writer, format string, g *Graph) error {
+	switch format {
+	case GraphFormatDOT:
+		return writeGraphDOT(w, g)
+	case GraphFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(g)
+	}
+	return fmt.Errorf("不明な出力形式です: %s（%s のいずれかを指定してください）", format, strings.Join(GraphFormats, " / "))
+}
+
+// Graphviz の DOT 形式で出力する
+// ワークスペースごとにクラスタにまとめ、型のみの依存は破線、動的な依存は点線で表す
+func writeGraphDOT(w io.Writer, g *Graph) error {
+	var b strings.Builder
+	b.WriteString("digraph imports {\n  rankdir=LR;\n  node [shape=box, fontsize=10];\n")
+
+	clusters := make(map[string][]string)
+	var names []string
+	for _, node := range g.Nodes {
+		if _, ok := clusters[node.Workspace]; !ok {
+			names = append(names, node.Workspace)
+		}
+		clusters[node.Workspace] = append(clusters[node.Workspace], node.Path)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		indent := "  "
+		if name != "" {
+			fmt.Fprintf(&b, "  subgraph cluster_%d {\n    label=%q;\n", i, name)
+			indent = "    "
+		}
+		for _, path := range clusters[name] {
+			fmt.Fprintf(&b, "%s%q;\n", indent, path)
+		}
+		if name != "" {
+			b.WriteString("  }\n")
+		}
+	}
+
+	// 同じファイルどうしの依存は 1 本の辺にまとめる
+	seen := make(map[[2]string]bool)
+	for _, edge := range g.Edges {
+		key := [2]string{edge.From, edge.To}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		fmt.Fprintf(&b, "  %q -> %q", edge.From, edge.To)
+		switch {
+		case edge.TypeOnly:
+			b.WriteString(" [style=dashed]")
+		case edge.Dynamic:
+			b.WriteString(" [style=dotted]")
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,123 @@
+package renamer
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GraphTestSuite struct {
+	suite.Suite
+	fs    *MemFS
+	root  string
+	graph *Graph
+}
+
+func (s *GraphTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/app/page.tsx": strings.Join([]string{
+			"import { Button } from '~/components/Button';",
+			"import type { Props } from './layout';",
+			"import { PageHeader } from '@kit/ui/page-header';",
+			"import Link from 'next/link';",
+			"import { missing } from './missing';",
+			"const Lazy = import('../lib/utils');",
+		}, "\n"),
+		"apps/web/app/layout.tsx":                      "import { Button } from '../components/Button';\n",
+		"apps/web/components/Button.tsx":               "import { cn } from '@kit/ui/utils';\n",
+		"packages/ui/src/custom/page-header/index.tsx": "import { cn } from '../../lib/utils';\n",
+	})
+
+	engine, err := NewEngine(s.root, Options{FS: s.fs})
+	s.Require().NoError(err)
+	s.graph, err = engine.BuildGraph()
+	s.Require().NoError(err)
+}
+
+func (s *GraphTestSuite) TestBuildGraph() {
+	var edges []string
+	for _, edge := range s.graph.Edges {
+		edges = append(edges, edge.From+" -> "+edge.To)
+	}
+	s.Equal([]string{
+		"apps/web/app/layout.tsx -> apps/web/components/Button.tsx",
+		"apps/web/app/page.tsx -> apps/web/components/Button.tsx",
+		"apps/web/app/page.tsx -> apps/web/app/layout.tsx",
+		"apps/web/app/page.tsx -> packages/ui/src/custom/page-header/index.tsx",
+		"apps/web/app/page.tsx -> apps/web/lib/utils.ts",
+		"apps/web/components/Button.tsx -> packages/ui/src/lib/utils.ts",
+		"packages/ui/src/custom/page-header/index.tsx -> packages/ui/src/lib/utils.ts",
+	}, edges, "外部パッケージと解決できない指定子は含めない")
+
+	page := s.graph.ImportsOf("apps/web/app/page.tsx")
+	s.Equal(ResolveKindAlias, page[0].Kind)
+	s.True(page[1].TypeOnly)
+	s.True(page[2].CrossWorkspace)
+	s.Equal(ResolveKindWorkspace, page[2].Kind)
+	s.True(page[3].Dynamic)
+	s.False(page[0].CrossWorkspace)
+
+	node := s.graph.Nodes[0]
+	s.Equal("apps/web/app/layout.tsx", node.Path)
+	s.Equal("apps/web", node.Workspace)
+}
+
+func (s *GraphTestSuite) TestQueries() {
+	s.Equal([]string{"apps/web/app/layout.tsx", "apps/web/app/page.tsx"}, s.graph.Importers("apps/web/components/Button.tsx"))
+
+	// ディレクトリを指定した場合は配下のファイルどうしの依存を含めない
+	s.Equal([]string{"apps/web/app/page.tsx", "apps/web/components/Button.tsx"}, s.graph.Importers("packages/ui/src"))
+	s.Empty(s.graph.Importers("apps/web/app"))
+	s.True(s.graph.Has("packages/ui"))
+	s.False(s.graph.Has("packages/missing"))
+
+	s.Equal([]GraphImpact{
+		{Path: "apps/web/components/Button.tsx", Depth: 1},
+		{Path: "packages/ui/src/custom/page-header/index.tsx", Depth: 1},
+		{Path: "apps/web/app/layout.tsx", Depth: 2},
+		{Path: "apps/web/app/page.tsx", Depth: 2},
+	}, s.graph.Impact("packages/ui/src/lib/utils.ts"))
+}
+
+func (s *GraphTestSuite) TestWriteGraph() {
+	var b bytes.Buffer
+	s.Require().NoError(WriteGraph(&b, GraphFormatDOT, s.graph))
+	dot := b.String()
+	s.True(strings.HasPrefix(dot, "digraph imports {\n"))
+	s.Contains(dot, "label=\"apps/web\";")
+	s.Contains(dot, "  \"apps/web/app/page.tsx\" -> \"apps/web/app/layout.tsx\" [style=dashed];\n")
+	s.Contains(dot, "  \"apps/web/app/page.tsx\" -> \"apps/web/lib/utils.ts\" [style=dotted];\n")
+
+	b.Reset()
+	s.Require().NoError(WriteGraph(&b, GraphFormatJSON, s.graph))
+	var decoded struct {
+		Nodes []GraphNode `json:"nodes"`
+		Edges []GraphEdge `json:"edges"`
+	}
+	s.Require().NoError(json.Unmarshal(b.Bytes(), &decoded))
+	s.Equal(s.graph.Nodes, decoded.Nodes)
+	s.Equal(s.graph.Edges, decoded.Edges)
+
+	s.Error(WriteGraph(&b, "svg", s.graph))
+}
+
+func (s *GraphTestSuite) TestReportImporters() {
+	report := &Report{Command: "plan", Dirs: []DirReport{{Renames: []RenameReport{
+		{From: "apps/web/components/Button.tsx", To: "apps/web/components/button.tsx"},
+		{From: "apps/web/lib/utils.ts", To: "apps/web/lib/utils.ts"},
+	}}}}
+	report.AddImporters(s.graph)
+	s.Equal([]string{"apps/web/app/layout.tsx", "apps/web/app/page.tsx"}, report.Dirs[0].Renames[0].Importers)
+	s.Equal([]string{"apps/web/app/page.tsx"}, report.Dirs[0].Renames[1].Importers)
+}
+
+func TestGraphSuite(t *testing.T) {
+	suite.Run(t, new(GraphTestSuite))
+}
@@ -0,0 +1,354 @@
+package renamer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ホイスト先のディレクトリ（パッケージのディレクトリからの相対パス）
+const hoistDir = "src/custom"
+
+// HoistResult は Hoist によるコンポーネントのホイストの結果です
+// パスはすべてプロジェクトルートからの相対パス（スラッシュ区切り）です
+type HoistResult struct {
+	// ホイストしたコンポーネントと、ホイスト先のディレクトリ
+	From string `json:"from"`
+	To   string `json:"to"`
+	// ホイスト先のパッケージと、コンポーネントを参照する指定子（"@kit/ui/app-logo" など）
+	Package   string `json:"package"`
+	Specifier string `json:"specifier"`
+	// 移動したファイル（一緒に移動したストーリーやテストなどを含む）
+	Files []HoistedFile `json:"files"`
+	// 追加した exports のエントリ（package.json と merge-exports が読み込む config/exports/*.json）
+	Exports []string `json:"exports"`
+	// 書き換えたモジュール指定子
+	Rewrites []SpecifierRewrite `json:"rewrites"`
+	// 確認が必要な書き換えや依存
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// HoistedFile は移動した 1 件のファイルまたはディレクトリです
+type HoistedFile struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ホイストの対象
+type hoistTarget struct {
+	// ホイスト先のパッケージ
+	ws *Workspace
+	// コンポーネントのあるアプリ
+	app *Workspace
+	// コンポーネントの名前（ケバブケース）と、ホイスト先のディレクトリ
+	name, dir string
+	// コンポーネントの本体のファイル（移動前と移動後）
+	entry, entryTo string
+	// 移動するファイルまたはディレクトリ
+	paths []movePath
+}
+
+// Hoist はアプリのコンポーネント src をパッケージ pkg の src/custom/<name> に移動し、exports に追加して参照を "<pkg>/<name>" に書き換えます
+func (e *Engine) Hoist(src, pkg, name string) (*HoistResult, *Run, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := e.hoistTarget(resolver, e.pathArg(src), pkg, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings, err := e.checkHoistImports(resolver, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	exportKey := "./" + target.name
+	exportValue := "./" + filepath.ToSlash(filepath.Join(hoistDir, target.name, filepath.Base(target.entryTo)))
+	if _, ok := target.ws.Exports[exportKey]; ok {
+		return nil, nil, fmt.Errorf("%s の exports に %s が既に登録されています", target.ws.Name, exportKey)
+	}
+	sources, err := e.exportSources(target.ws, exportKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &HoistResult{
+		From:      e.Rel(e.pathArg(src)),
+		To:        e.Rel(target.dir),
+		Package:   target.ws.Name,
+		Specifier: target.specifier(),
+		Exports:   []string{},
+		Rewrites:  []SpecifierRewrite{},
+		Warnings:  warnings,
+	}
+	for _, p := range target.paths {
+		result.Files = append(result.Files, HoistedFile{From: e.Rel(p.from), To: e.Rel(p.to)})
+	}
+	run, err := e.Execute("hoist", func(worker *Engine) error {
+		// 移動の前に exports に登録して、移動したコンポーネントをパッケージ名で参照できるようにする
+		manifest := filepath.Join(target.ws.Path, "package.json")
+		for _, file := range append(sources, manifest) {
+			if err := worker.addExport(file, exportKey, exportValue); err != nil {
+				return err
+			}
+			result.Exports = append(result.Exports, fmt.Sprintf("%s: \"%s\": \"%s\"", e.Rel(file), exportKey, exportValue))
+		}
+
+		moved := &MoveResult{Rewrites: []SpecifierRewrite{}}
+		if err := worker.move(moved, target.paths); err != nil {
+			return err
+		}
+		result.Rewrites = append(result.Rewrites, moved.Rewrites...)
+		result.Rewrites = append(result.Rewrites, moved.PackageEntries...)
+		result.Warnings = append(result.Warnings, moved.Warnings...)
+
+		rewrites, err := worker.relativizeSelfImports(target)
+		result.Rewrites = append(result.Rewrites, rewrites...)
+		return err
+	})
+	return result, run, err
+}
+
+// コンポーネントを参照する指定子（exports の "./<name>" に対応する）
+// ワイルドカードの exports（"./*"）がある場合も、参照はこの指定子に揃える
+func (t *hoistTarget) specifier() string {
+	return t.ws.Name + "/" + t.name
+}
+
+// ホイストするファイルとホイスト先を求め、ホイストできるかどうかを確認する
+func (e *Engine) hoistTarget(resolver *Resolver, src, pkg, name string) (*hoistTarget, error) {
+	info, err := e.fs.Stat(src)
+	if err != nil {
+		return nil, fmt.Errorf("コンポーネントが見つかりません: %s", e.Rel(src))
+	}
+	ws := resolver.WorkspaceByName(pkg)
+	if ws == nil {
+		return nil, fmt.Errorf("ワークスペースのパッケージが見つかりません: %s", pkg)
+	}
+	app := resolver.WorkspaceOf(src)
+	if app == nil || app == ws {
+		return nil, fmt.Errorf("%s は %s の外にあるワークスペースのコンポーネントを指定してください", e.Rel(src), pkg)
+	}
+
+	// index を指定した場合はディレクトリをホイストする
+	base := filepath.Base(src)
+	if !info.IsDir() && strings.TrimSuffix(base, filepath.Ext(base)) == "index" {
+		src = filepath.Dir(src)
+		info, base = nil, filepath.Base(src)
+	}
+	stem := base
+	if info != nil && !info.IsDir() {
+		if !contains(sourceExtensions, filepath.Ext(base)) {
+			return nil, fmt.Errorf("ソースファイルではありません: %s", e.Rel(src))
+		}
+		stem = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	if name == "" {
+		name = CamelToKebab(stem)
+	}
+	if !IsKebabCase(name) {
+		return nil, fmt.Errorf("コンポーネントの名前はケバブケースで指定してください: %s", name)
+	}
+
+	target := &hoistTarget{ws: ws, app: app, name: name, dir: filepath.Join(ws.Path, filepath.FromSlash(hoistDir), name)}
+	if _, err := e.fs.Stat(target.dir); err == nil {
+		return nil, fmt.Errorf("ホイスト先が既に存在します: %s", e.Rel(target.dir))
+	}
+
+	if info == nil || info.IsDir() {
+		// ディレクトリはそのまま移動する（index が必要）
+		for _, ext := range sourceExtensions {
+			if index := filepath.Join(src, "index"+ext); exists(e.fs, index) {
+				target.entry, target.entryTo = index, filepath.Join(target.dir, "index"+ext)
+				break
+			}
+		}
+		if target.entry == "" {
+			return nil, fmt.Errorf("index のないディレクトリはホイストできません: %s", e.Rel(src))
+		}
+		target.paths = []movePath{{from: src, to: target.dir, specifier: target.specifier()}}
+		return target, nil
+	}
+
+	// ファイルは index に、同じ名前で始まるファイルは <name>.<接尾辞> に名前を変更する
+	target.entry, target.entryTo = src, filepath.Join(target.dir, "index"+filepath.Ext(base))
+	target.paths = []movePath{{from: src, to: target.entryTo, specifier: target.specifier()}}
+	entries, err := e.fs.ReadDir(filepath.Dir(src))
+	if err != nil {
+		return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(filepath.Dir(src)), err)
+	}
+	for _, entry := range entries {
+		suffix, ok := strings.CutPrefix(entry.Name(), stem+".")
+		if !ok || entry.IsDir() || entry.Name() == base {
+			continue
+		}
+		companion := filepath.Join(filepath.Dir(src), entry.Name())
+		target.paths = append(target.paths, movePath{from: companion, to: filepath.Join(target.dir, name+"."+suffix)})
+	}
+	return target, nil
+}
+
+// ホイストするファイルのインポートを確認する
+// アプリのエイリアス（tsconfig の paths・baseUrl）やアプリの他のファイルをインポートしている場合はエラーを返し、
+// ホイスト先のパッケージが依存していないワークスペースのパッケージをインポートしている場合は警告を返す
+func (e *Engine) checkHoistImports(resolver *Resolver, target *hoistTarget) ([]string, error) {
+	moved := func(file string) bool {
+		for _, p := range target.paths {
+			if file == p.from || strings.HasPrefix(file, p.from+string(filepath.Separator)) {
+				return true
+			}
+		}
+		return false
+	}
+	var files []string
+	for _, p := range target.paths {
+		if !exists(e.fs, p.from) {
+			continue
+		}
+		found, err := e.findSourceFiles(p.from)
+		if err != nil {
+			return nil, fmt.Errorf("ファイル一覧の取得に失敗しました: %w", err)
+		}
+		files = append(files, found...)
+	}
+	sort.Strings(files)
+
+	var appOnly, warnings []string
+	for _, file := range files {
+		refs, err := e.Imports(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+		}
+		for _, ref := range refs {
+			res := resolver.Resolve(file, ref.Specifier)
+			location := fmt.Sprintf("%s:%d: '%s'", e.Rel(file), ref.Line, ref.Specifier)
+			switch {
+			case res.Status == ResolveOK && res.Path != "" && moved(res.Path):
+			case res.Kind == ResolveKindAlias || res.Kind == ResolveKindBaseURL:
+				appOnly = append(appOnly, location)
+			case res.Status == ResolveOK && res.Path != "" && resolver.WorkspaceOf(res.Path) == target.app:
+				appOnly = append(appOnly, location+"（アプリのファイル）")
+			case res.Status == ResolveOK && res.Kind == ResolveKindWorkspace && res.Path != "":
+				if dep := resolver.WorkspaceOf(res.Path); dep != nil && dep != target.ws && !target.ws.DependsOn(dep.Name) {
+					warnings = append(warnings, fmt.Sprintf("%s（%s は %s に依存していません）", location, target.ws.Name, dep.Name))
+				}
+			}
+		}
+	}
+	if len(appOnly) > 0 {
+		return nil, fmt.Errorf("%s はアプリだけで使えるモジュールをインポートしているため、ホイストできません:\n  %s",
+			e.Rel(target.entry), strings.Join(appOnly, "\n  "))
+	}
+	return warnings, nil
+}
+
+// merge-exports（scripts/merge-exports.js）を使うパッケージで、exports を追加する config/exports/*.json
+// "./src/custom/" を指すエントリが最も多いファイル（ない場合は custom.json）を返す
+// merge-exports を使わないパッケージの場合は空を返す
+func (e *Engine) exportSources(ws *Workspace, exportKey string) ([]string, error) {
+	dir := filepath.Join(ws.Path, "config", "exports")
+	if !exists(e.fs, filepath.Join(ws.Path, "scripts", "merge-exports.js")) || !exists(e.fs, dir) {
+		return nil, nil
+	}
+	entries, err := e.fs.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(dir), err)
+	}
+	source, most := filepath.Join(dir, "custom.json"), 0
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		file := filepath.Join(dir, entry.Name())
+		data, err := e.fs.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+		}
+		custom := 0
+		for _, s := range scanJSONStrings(data) {
+			switch {
+			case s.key && jsonPath(s.path) == "exports" && s.value == exportKey:
+				return nil, fmt.Errorf("%s に %s が既に登録されています", e.Rel(file), exportKey)
+			case !s.key && len(s.path) == 2 && s.path[0] == "exports" && strings.HasPrefix(s.value, "./"+hoistDir+"/"):
+				custom++
+			}
+		}
+		if custom > most {
+			source, most = file, custom
+		}
+	}
+	if !exists(e.fs, source) {
+		return nil, fmt.Errorf("exports を追加する %s が見つかりません", e.Rel(source))
+	}
+	return []string{source}, nil
+}
+
+// JSON ファイルの exports に "key": "value" を追加する
+// "./src/custom/" を指す最後のエントリの後（ない場合は exports の末尾）に追加します
+func (e *Engine) addExport(file, key, value string) error {
+	data, err := e.fs.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+	}
+	strs, objects := scanJSON(data)
+	var exports *jsonObject
+	for i, obj := range objects {
+		if jsonPath(obj.path) == "exports" {
+			exports = &objects[i]
+		}
+	}
+	if exports == nil {
+		return fmt.Errorf("%s に exports がありません", e.Rel(file))
+	}
+	after := -1
+	for _, s := range strs {
+		if !s.key && len(s.path) == 2 && s.path[0] == "exports" && strings.HasPrefix(s.value, "./"+hoistDir+"/") {
+			after = s.end + 1
+		}
+	}
+	e.printf("%s: exports に %s を追加します\n", e.Rel(file), key)
+	if err := e.fs.WriteFile(file, insertJSONMember(data, *exports, after, key, value), 0644); err != nil {
+		return fmt.Errorf("%s の書き込みに失敗しました: %w", e.Rel(file), err)
+	}
+	return nil
+}
+
+// ホイストしたファイルの中で、ホイスト先のパッケージをパッケージ名で参照している指定子を相対パスに書き換える
+// （パッケージの中のファイルは相対パスで参照する）
+func (e *Engine) relativizeSelfImports(target *hoistTarget) ([]SpecifierRewrite, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+	files, err := e.findSourceFiles(target.dir)
+	if err != nil {
+		return nil, fmt.Errorf("ファイル一覧の取得に失敗しました: %w", err)
+	}
+	var rewrites []SpecifierRewrite
+	for _, file := range files {
+		refs, err := e.Imports(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+		}
+		var edits []specifierEdit
+		for _, ref := range refs {
+			if ref.Specifier != target.ws.Name && !strings.HasPrefix(ref.Specifier, target.ws.Name+"/") {
+				continue
+			}
+			res := resolver.Resolve(file, ref.Specifier)
+			if res.Status != ResolveOK || res.Path == "" || !strings.HasPrefix(res.Path, target.ws.Path+string(filepath.Separator)) {
+				continue
+			}
+			spec := relativeSpecifier(file, specifierFormOf(ref.Specifier, res.Path).modulePath(res.Path))
+			edits = append(edits, specifierEdit{ref: ref, replacement: spec})
+			rewrites = append(rewrites, SpecifierRewrite{File: e.Rel(file), Line: ref.Line, From: ref.Specifier, To: spec, Kind: ResolveKindRelative})
+		}
+		if len(edits) > 0 {
+			if err := e.editSpecifiers(file, edits); err != nil {
+				return rewrites, err
+			}
+		}
+	}
+	return rewrites, nil
+}
@@ -0,0 +1,181 @@
+package renamer
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HoistTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *HoistTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/app/page.tsx":                        "import { AppLogo } from '~/components/AppLogo';\nimport { UserMenu } from '~/components/UserMenu';\n",
+		"apps/web/app/layout.tsx":                      "import { AppLogo } from '../components/AppLogo';\n",
+		"apps/web/components/AppLogo.tsx":              "import { cn } from '@kit/ui/utils';\nimport styles from './AppLogo.module.css';\n",
+		"apps/web/components/AppLogo.stories.tsx":      "import { AppLogo } from './AppLogo';\n",
+		"apps/web/components/AppLogo.module.css":       "",
+		"apps/web/components/UserMenu/index.tsx":       "import { Avatar } from './Avatar';\n",
+		"apps/web/components/UserMenu/Avatar.tsx":      "import { logger } from '@kit/shared';\n",
+		"apps/web/components/Button.tsx":               "import { cn } from '../lib/utils';\nimport type { Props } from '~/components/user-card';\n",
+		"packages/ui/scripts/merge-exports.js":         "",
+		"packages/ui/config/exports/custom.json":       "{\n  \"exports\": {\n    \"./page-header\": \"./src/custom/page-header/index.tsx\"\n  }\n}\n",
+		"packages/ui/config/exports/shadcn.json":       "{\n  \"exports\": {\n    \"./button\": \"./src/shadcn/button.tsx\"\n  }\n}\n",
+		"packages/ui/src/custom/page-header/index.tsx": "",
+	})
+}
+
+func (s *HoistTestSuite) newEngine(opts Options) *Engine {
+	opts.FS = s.fs
+	engine, err := NewEngine(s.root, opts)
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *HoistTestSuite) hoist(src, name string) *HoistResult {
+	result, _, err := s.newEngine(Options{}).Hoist(src, "@kit/ui", name)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *HoistTestSuite) read(path string) string {
+	content, err := s.fs.ReadFile(filepath.Join(s.root, filepath.FromSlash(path)))
+	s.Require().NoError(err)
+	return string(content)
+}
+
+func (s *HoistTestSuite) exists(path string) bool {
+	return exists(s.fs, filepath.Join(s.root, filepath.FromSlash(path)))
+}
+
+// ファイルをホイストする
+func (s *HoistTestSuite) TestHoistFile() {
+	result := s.hoist("apps/web/components/AppLogo.tsx", "")
+	s.Equal("packages/ui/src/custom/app-logo", result.To)
+	s.Equal("@kit/ui/app-logo", result.Specifier)
+	s.Equal([]HoistedFile{
+		{From: "apps/web/components/AppLogo.tsx", To: "packages/ui/src/custom/app-logo/index.tsx"},
+		{From: "apps/web/components/AppLogo.module.css", To: "packages/ui/src/custom/app-logo/app-logo.module.css"},
+		{From: "apps/web/components/AppLogo.stories.tsx", To: "packages/ui/src/custom/app-logo/app-logo.stories.tsx"},
+	}, result.Files, "同じ名前で始まるファイルをパッケージの命名規則で一緒に移動する")
+	s.False(s.exists("apps/web/components/AppLogo.tsx"))
+	s.False(s.exists("apps/web/components/AppLogo.stories.tsx"))
+
+	s.Equal("import { AppLogo } from '@kit/ui/app-logo';\nimport { UserMenu } from '~/components/UserMenu';\n", s.read("apps/web/app/page.tsx"))
+	s.Equal("import { AppLogo } from '@kit/ui/app-logo';\n", s.read("apps/web/app/layout.tsx"))
+	s.Equal("import { cn } from '../../lib/utils';\nimport styles from './app-logo.module.css';\n",
+		s.read("packages/ui/src/custom/app-logo/index.tsx"), "パッケージの中ではパッケージ名ではなく相対パスで参照する")
+	s.Equal("import { AppLogo } from './index';\n", s.read("packages/ui/src/custom/app-logo/app-logo.stories.tsx"))
+	s.Empty(result.Warnings)
+}
+
+// exports と merge-exports の config/exports に登録する
+func (s *HoistTestSuite) TestHoistRegistersExports() {
+	result := s.hoist("apps/web/components/AppLogo.tsx", "")
+	s.Equal([]string{
+		`packages/ui/config/exports/custom.json: "./app-logo": "./src/custom/app-logo/index.tsx"`,
+		`packages/ui/package.json: "./app-logo": "./src/custom/app-logo/index.tsx"`,
+	}, result.Exports)
+	s.Equal("{\n  \"exports\": {\n    \"./page-header\": \"./src/custom/page-header/index.tsx\",\n    \"./app-logo\": \"./src/custom/app-logo/index.tsx\"\n  }\n}\n",
+		s.read("packages/ui/config/exports/custom.json"))
+	s.Contains(s.read("packages/ui/package.json"), "\"./page-header\": \"./src/custom/page-header/index.tsx\",\n    \"./app-logo\": \"./src/custom/app-logo/index.tsx\",\n    \"./utils\"",
+		"src/custom を指す最後のエントリの後に追加する")
+	s.NotContains(s.read("packages/ui/config/exports/shadcn.json"), "app-logo")
+}
+
+// merge-exports を使わないパッケージでは package.json だけに登録する
+func (s *HoistTestSuite) TestHoistWithoutMergeExports() {
+	s.Require().NoError(s.fs.Remove(filepath.Join(s.root, "packages", "ui", "scripts", "merge-exports.js")))
+	result := s.hoist("apps/web/components/AppLogo.tsx", "logo")
+	s.Equal([]string{`packages/ui/package.json: "./logo": "./src/custom/logo/index.tsx"`}, result.Exports)
+	s.NotContains(s.read("packages/ui/config/exports/custom.json"), "logo")
+	s.Equal("import { AppLogo } from '@kit/ui/logo';\n", s.read("apps/web/app/layout.tsx"))
+}
+
+// ワイルドカードの exports があっても、参照はホイストで追加した指定子に書き換える
+func (s *HoistTestSuite) TestHoistWithWildcardExports() {
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"packages/ui/package.json": `{
+  "name": "@kit/ui",
+  "exports": {
+    "./page-header": "./src/custom/page-header/index.tsx",
+    "./*": "./src/custom/*.tsx"
+  }
+}`,
+	})
+	result := s.hoist("apps/web/components/AppLogo.tsx", "")
+	s.Equal("import { AppLogo } from '@kit/ui/app-logo';\nimport { UserMenu } from '~/components/UserMenu';\n", s.read("apps/web/app/page.tsx"))
+	s.Equal("import { AppLogo } from '@kit/ui/app-logo';\n", s.read("apps/web/app/layout.tsx"))
+	for _, rewrite := range result.Rewrites {
+		if strings.HasPrefix(rewrite.To, "@kit/ui/") {
+			s.Equal("@kit/ui/app-logo", rewrite.To, rewrite.String())
+		}
+	}
+}
+
+// ディレクトリをホイストする
+func (s *HoistTestSuite) TestHoistDirectory() {
+	result := s.hoist("apps/web/components/UserMenu", "")
+	s.Equal([]HoistedFile{{From: "apps/web/components/UserMenu", To: "packages/ui/src/custom/user-menu"}}, result.Files)
+	s.True(s.exists("packages/ui/src/custom/user-menu/Avatar.tsx"))
+	s.Equal("import { AppLogo } from '~/components/AppLogo';\nimport { UserMenu } from '@kit/ui/user-menu';\n", s.read("apps/web/app/page.tsx"))
+	s.Equal([]string{"apps/web/components/UserMenu/Avatar.tsx:1: '@kit/shared'（@kit/ui は @kit/shared に依存していません）"}, result.Warnings,
+		"パッケージが依存していないワークスペースのパッケージは警告する")
+}
+
+// アプリのエイリアスやアプリのファイルをインポートしている場合はホイストしない
+func (s *HoistTestSuite) TestHoistRefusesAppImports() {
+	_, _, err := s.newEngine(Options{}).Hoist("apps/web/components/Button.tsx", "@kit/ui", "")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "apps/web/components/Button.tsx:1: '../lib/utils'（アプリのファイル）")
+	s.Contains(err.Error(), "apps/web/components/Button.tsx:2: '~/components/user-card'")
+	s.True(s.exists("apps/web/components/Button.tsx"), "ファイルは変更しない")
+	s.NotContains(s.read("packages/ui/package.json"), "./button")
+}
+
+func (s *HoistTestSuite) TestHoistErrors() {
+	engine := s.newEngine(Options{})
+	for _, tc := range []struct {
+		src, pkg, name string
+		message        string
+	}{
+		{"apps/web/components/Missing.tsx", "@kit/ui", "", "コンポーネントが見つかりません"},
+		{"apps/web/components/AppLogo.tsx", "@kit/missing", "", "ワークスペースのパッケージが見つかりません"},
+		{"packages/ui/src/lib/utils.ts", "@kit/ui", "", "外にあるワークスペース"},
+		{"apps/web/components/AppLogo.tsx", "@kit/ui", "page-header", "ホイスト先が既に存在します"},
+		{"apps/web/components/AppLogo.tsx", "@kit/ui", "utils", "exports に ./utils が既に登録されています"},
+		{"apps/web/components/AppLogo.tsx", "@kit/ui", "button", "shadcn.json に ./button が既に登録されています"},
+		{"apps/web/components/AppLogo.tsx", "@kit/ui", "AppLogo", "ケバブケース"},
+		{"apps/web/components/AppLogo.module.css", "@kit/ui", "", "ソースファイルではありません"},
+	} {
+		_, _, err := engine.Hoist(tc.src, tc.pkg, tc.name)
+		s.Require().Error(err, tc.message)
+		s.Contains(err.Error(), tc.message)
+	}
+}
+
+// ドライランではファイルを変更しない
+func (s *HoistTestSuite) TestHoistDryRun() {
+	engine := s.newEngine(Options{})
+	engine.SetDryRun(true)
+	result, run, err := engine.Hoist("apps/web/components/AppLogo.tsx", "@kit/ui", "")
+	s.Require().NoError(err)
+	s.NotEmpty(result.Rewrites)
+	s.NotNil(run.Overlay)
+	s.True(s.exists("apps/web/components/AppLogo.tsx"))
+	s.Equal("import { AppLogo } from '../components/AppLogo';\n", s.read("apps/web/app/layout.tsx"))
+	s.NotContains(s.read("packages/ui/package.json"), "app-logo")
+}
+
+func TestHoistSuite(t *testing.T) {
+	suite.Run(t, new(HoistTestSuite))
+}
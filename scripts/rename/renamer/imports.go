@@ -0,0 +1,77 @@
+package renamer
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WorkspaceDirs はインポートの検査対象となるワークスペースのディレクトリ（プロジェクトルートからの相対パス）を返します
+// ワークスペースが定義されていない場合は apps と packages を返します
+func (e *Engine) WorkspaceDirs() ([]string, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, ws := range resolver.Workspaces() {
+		dirs = append(dirs, ws.Dir)
+	}
+	if len(dirs) == 0 {
+		dirs = []string{"apps", "packages"}
+	}
+	dirs = e.removeChildDirectories(dirs)
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// FixCaseMismatches は issues のうち修正候補のある大文字小文字の不一致を、指定子を書き換えて修正します
+// 変更は command の名前でジャーナルに記録し、修正した問題と実行の記録を返します
+// 検査後にファイルが変更され、指定子が記録された位置にない場合は修正しません
+func (e *Engine) FixCaseMismatches(command string, issues []ImportIssue) ([]ImportIssue, *Run, error) {
+	byFile := make(map[string][]ImportIssue)
+	var files []string
+	for _, issue := range issues {
+		if issue.Status != ResolveCaseMismatch || issue.Suggestion == "" {
+			continue
+		}
+		if _, ok := byFile[issue.File]; !ok {
+			files = append(files, issue.File)
+		}
+		byFile[issue.File] = append(byFile[issue.File], issue)
+	}
+	sort.Strings(files)
+
+	var fixed []ImportIssue
+	run, err := e.Execute(command, func(worker *Engine) error {
+		for _, file := range files {
+			path := worker.abs(file)
+			content, err := worker.fs.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("%s の読み込みに失敗しました: %w", file, err)
+			}
+
+			// 後ろから置き換えて、前にある指定子のオフセットがずれないようにする
+			fileIssues := byFile[file]
+			sort.Slice(fileIssues, func(i, j int) bool { return fileIssues[i].Offset > fileIssues[j].Offset })
+			var fileFixed []ImportIssue
+			for _, issue := range fileIssues {
+				var ok bool
+				if content, ok = replaceSpecifier(content, ImportRef{Specifier: issue.Specifier, Offset: issue.Offset}, issue.Suggestion); !ok {
+					worker.debugf("%s:%d: '%s' が見つからないためスキップします\n", file, issue.Line, issue.Specifier)
+					continue
+				}
+				fileFixed = append(fileFixed, issue)
+			}
+			if len(fileFixed) == 0 {
+				continue
+			}
+			if err := worker.fs.WriteFile(path, content, 0644); err != nil {
+				return fmt.Errorf("%s の書き込みに失敗しました: %w", file, err)
+			}
+			fixed = append(fixed, fileFixed...)
+		}
+		return nil
+	})
+	sortImportIssues(fixed)
+	return fixed, run, err
+}
@@ -0,0 +1,119 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportsTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *ImportsTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/app/page.tsx": `import { Button } from '../components/button';
+import { UserCard } from '~/components/User-Card';
+import { Missing } from './missing';
+import { PageHeader } from '@kit/ui/Page-Header';
+`,
+	})
+}
+
+func (s *ImportsTestSuite) newEngine(dryRun bool) *Engine {
+	engine, err := NewEngine(s.root, Options{FS: s.fs, DryRun: dryRun})
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *ImportsTestSuite) read(fsys FS, rel string) string {
+	data, err := fsys.ReadFile(filepath.Join(s.root, filepath.FromSlash(rel)))
+	s.Require().NoError(err)
+	return string(data)
+}
+
+func (s *ImportsTestSuite) TestWorkspaceDirs() {
+	dirs, err := s.newEngine(false).WorkspaceDirs()
+	s.Require().NoError(err)
+	s.Equal([]string{"apps/web", "packages/shared", "packages/ui", "tooling/typescript"}, dirs)
+
+	// ワークスペースが定義されていない場合
+	fsys := NewMemFS()
+	s.Require().NoError(fsys.MkdirAll(s.root, 0755))
+	engine, err := NewEngine(s.root, Options{FS: fsys})
+	s.Require().NoError(err)
+	dirs, err = engine.WorkspaceDirs()
+	s.Require().NoError(err)
+	s.Equal([]string{"apps", "packages"}, dirs)
+}
+
+func (s *ImportsTestSuite) TestFixCaseMismatches() {
+	engine := s.newEngine(false)
+	before := s.read(s.fs, "apps/web/app/page.tsx")
+
+	issues, err := engine.CheckImports([]string{"apps/web"})
+	s.Require().NoError(err)
+	s.Require().Len(issues, 4)
+
+	fixed, run, err := engine.FixCaseMismatches("check-imports", issues)
+	s.Require().NoError(err)
+	s.Len(fixed, 3)
+	s.Equal(`import { Button } from '../components/Button';
+import { UserCard } from '~/components/user-card';
+import { Missing } from './missing';
+import { PageHeader } from '@kit/ui/page-header';
+`, s.read(s.fs, "apps/web/app/page.tsx"))
+
+	// 解決できないインポートだけが残る
+	issues, err = engine.CheckImports([]string{"apps/web"})
+	s.Require().NoError(err)
+	s.Require().Len(issues, 1)
+	s.Equal(ResolveUnresolved, issues[0].Status)
+
+	// ジャーナルから元に戻せる
+	s.Equal("check-imports", run.Journal.Command)
+	s.Require().NoError(engine.Rollback(run))
+	s.Equal(before, s.read(s.fs, "apps/web/app/page.tsx"))
+}
+
+func (s *ImportsTestSuite) TestFixCaseMismatchesDryRun() {
+	engine := s.newEngine(true)
+	before := s.read(s.fs, "apps/web/app/page.tsx")
+
+	issues, err := engine.CheckImports([]string{"apps/web"})
+	s.Require().NoError(err)
+	fixed, run, err := engine.FixCaseMismatches("check-imports", issues)
+	s.Require().NoError(err)
+	s.Len(fixed, 3)
+	s.True(run.DryRun())
+
+	// ドライランではファイルシステムは変更されない
+	s.Equal(before, s.read(s.fs, "apps/web/app/page.tsx"))
+	s.Contains(s.read(run.Overlay, "apps/web/app/page.tsx"), "'../components/Button'")
+}
+
+func (s *ImportsTestSuite) TestFixCaseMismatchesSkipsStaleIssues() {
+	engine := s.newEngine(false)
+	issues, err := engine.CheckImports([]string{"apps/web"})
+	s.Require().NoError(err)
+
+	// 検査後にファイルが変更された場合は修正しない
+	path := filepath.Join(s.root, filepath.FromSlash("apps/web/app/page.tsx"))
+	s.Require().NoError(s.fs.WriteFile(path, []byte("// changed\n"), 0644))
+
+	fixed, run, err := engine.FixCaseMismatches("check-imports", issues)
+	s.Require().NoError(err)
+	s.Empty(fixed)
+	s.True(run.Journal.Empty())
+	s.Equal("// changed\n", s.read(s.fs, "apps/web/app/page.tsx"))
+}
+
+func TestImportsSuite(t *testing.T) {
+	suite.Run(t, new(ImportsTestSuite))
+}
@@ -0,0 +1,437 @@
+package renamer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// 索引のエントリの種類
+const (
+	KindDir       = "dir"
+	KindComponent = "component" // .tsx / .jsx
+	KindSource    = "source"    // その他のソースファイル（.ts / .js など）
+	KindOther     = "other"
+)
+
+// 名前の命名規則の分類
+const (
+	ConventionKebab = "kebab"
+	ConventionCamel = "camel"
+	ConventionOther = "other"
+)
+
+// 索引のキャッシュの形式のバージョン
+const indexCacheVersion = 1
+
+// IndexEntry は索引に記録されたファイルまたはディレクトリです
+type IndexEntry struct {
+	// プロジェクトルートからの相対パス（スラッシュ区切り）
+	Path string
+	// 種類（KindDir / KindComponent / KindSource / KindOther）
+	Kind string
+	// 名前（ファイルの場合は拡張子を除く）の命名規則（ConventionKebab / ConventionCamel / ConventionOther）
+	Convention string
+	Info       fs.FileInfo
+
+	// 配下のエントリの終わり（entries のインデックス）
+	end int
+	// 配下を索引に含めていない除外ディレクトリ（node_modules など）かどうか
+	truncated bool
+}
+
+// Index はプロジェクトルート配下を 1 回の走査で記録した索引です
+// エントリは Walk と同じ順序（名前順の深さ優先）で保持し、除外ディレクトリ（isExcludedDir）の配下は含めません
+// モジュール指定子は必要になった時点で読み込み、更新日時とサイズが変わらない限り再利用します
+type Index struct {
+	fs        FS
+	root      string
+	entries   []*IndexEntry
+	positions map[string]int
+
+	mu      sync.Mutex
+	imports map[string]indexedImports
+	// 索引の作成後にファイルシステムが変更されたかどうか
+	stale atomic.Bool
+}
+
+// ファイルごとのモジュール指定子の記録
+type indexedImports struct {
+	ModTime int64       `json:"modTime"`
+	Size    int64       `json:"size"`
+	Imports []ImportRef `json:"imports"`
+}
+
+// 索引のキャッシュファイル
+type indexCacheFile struct {
+	Version int                       `json:"version"`
+	Files   map[string]indexedImports `json:"files"`
+}
+
+// IndexCachePath はプロジェクトルートから索引のキャッシュファイルのパスを生成します
+func IndexCachePath(projectRoot string) string {
+	return filepath.Join(projectRoot, "scripts", "rename", ".cache", "index.json")
+}
+
+// BuildIndex は root 配下を 1 回走査して索引を作成します
+func BuildIndex(fsys FS, root string) (*Index, error) {
+	idx := &Index{
+		fs:        fsys,
+		root:      root,
+		positions: make(map[string]int),
+		imports:   make(map[string]indexedImports),
+	}
+	err := Walk(fsys, root, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		entry := &IndexEntry{Path: filepath.ToSlash(rel), Info: info, Kind: entryKind(path, info)}
+		name := info.Name()
+		if !info.IsDir() {
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+		}
+		entry.Convention = namingConvention(name)
+		idx.positions[entry.Path] = len(idx.entries)
+		idx.entries = append(idx.entries, entry)
+		if info.IsDir() && path != root && isExcludedDir(info.Name()) {
+			entry.truncated = true
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("索引の作成に失敗しました: %w", err)
+	}
+
+	// 各ディレクトリの配下の終わりを求める
+	var open []int
+	for i, entry := range idx.entries {
+		for len(open) > 0 && !idx.contains(idx.entries[open[len(open)-1]].Path, entry.Path) {
+			idx.entries[open[len(open)-1]].end = i
+			open = open[:len(open)-1]
+		}
+		entry.end = i + 1
+		if entry.Info.IsDir() {
+			open = append(open, i)
+		}
+	}
+	for _, i := range open {
+		idx.entries[i].end = len(idx.entries)
+	}
+	return idx, nil
+}
+
+// エントリの種類を判定する
+func entryKind(path string, info fs.FileInfo) string {
+	switch ext := filepath.Ext(path); {
+	case info.IsDir():
+		return KindDir
+	case ext == ".tsx" || ext == ".jsx":
+		return KindComponent
+	case IsSourceFile(path):
+		return KindSource
+	}
+	return KindOther
+}
+
+// 名前の命名規則を判定する（analyzeFiles と同じくケバブケースを先に判定する）
+func namingConvention(name string) string {
+	if IsKebabCase(name) {
+		return ConventionKebab
+	}
+	if IsCamelCase(name) {
+		return ConventionCamel
+	}
+	return ConventionOther
+}
+
+// rel がディレクトリ dir 自身またはその配下かどうか
+func (idx *Index) contains(dir, rel string) bool {
+	return dir == "." || rel == dir || strings.HasPrefix(rel, dir+"/")
+}
+
+// Entries は索引のすべてのエントリを Walk と同じ順序で返します
+func (idx *Index) Entries() []*IndexEntry {
+	return idx.entries
+}
+
+// Entry はプロジェクトルートからの相対パスのエントリを返します（索引にない場合は nil）
+func (idx *Index) Entry(rel string) *IndexEntry {
+	if i, ok := idx.positions[rel]; ok {
+		return idx.entries[i]
+	}
+	return nil
+}
+
+// 絶対パスを索引の相対パスに変換する（プロジェクトルートの外の場合は false）
+func (idx *Index) rel(path string) (string, bool) {
+	rel, err := filepath.Rel(idx.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// Children はディレクトリの直下のエントリを名前順に返します（索引にない場合は nil）
+func (idx *Index) Children(rel string) []*IndexEntry {
+	i, ok := idx.positions[rel]
+	if !ok || !idx.entries[i].Info.IsDir() {
+		return nil
+	}
+	var children []*IndexEntry
+	for j := i + 1; j < idx.entries[i].end; j = idx.entries[j].end {
+		children = append(children, idx.entries[j])
+	}
+	return children
+}
+
+// Walk と同じ規則で i 番目のエントリとその配下を走査し、次に走査するエントリのインデックスを返す
+func (idx *Index) walk(i int, fn filepath.WalkFunc) (int, error) {
+	entry := idx.entries[i]
+	path := filepath.Join(idx.root, filepath.FromSlash(entry.Path))
+	if entry.truncated {
+		// 除外ディレクトリを走査する場合は、ファイルシステムを直接走査する
+		entries, err := idx.fs.ReadDir(path)
+		if err1 := fn(path, entry.Info, err); err != nil || err1 != nil {
+			return entry.end, err1
+		}
+		return entry.end, walkEntries(idx.fs, path, entries, fn)
+	}
+	if err := fn(path, entry.Info, nil); err != nil || !entry.Info.IsDir() {
+		return entry.end, err
+	}
+	for j := i + 1; j < entry.end; {
+		child := idx.entries[j]
+		next, err := idx.walk(j, fn)
+		if err != nil && (!child.Info.IsDir() || err != filepath.SkipDir) {
+			return entry.end, err
+		}
+		j = next
+	}
+	return entry.end, nil
+}
+
+// Imports はソースファイルのモジュール指定子を返します
+// 更新日時とサイズが前回読み込んだときと同じ場合は、ファイルを読み込まずに記録した指定子を返します
+func (idx *Index) Imports(path string) ([]ImportRef, error) {
+	rel, ok := idx.rel(path)
+	info, err := idx.fs.Stat(path)
+	if !ok || err != nil || info.IsDir() {
+		content, err := idx.fs.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return ScanImports(content), nil
+	}
+
+	// 索引の作成後に変更された場合も検出できるように、更新日時とサイズは毎回取得する
+	modTime, size := info.ModTime().UnixNano(), info.Size()
+	idx.mu.Lock()
+	cached, ok := idx.imports[rel]
+	idx.mu.Unlock()
+	if ok && cached.ModTime == modTime && cached.Size == size {
+		return cached.Imports, nil
+	}
+
+	content, err := idx.fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	refs := ScanImports(content)
+	idx.mu.Lock()
+	idx.imports[rel] = indexedImports{ModTime: modTime, Size: size, Imports: refs}
+	idx.mu.Unlock()
+	return refs, nil
+}
+
+// 以前の索引で読み込んだモジュール指定子を引き継ぐ
+func (idx *Index) inherit(previous *Index) {
+	previous.mu.Lock()
+	defer previous.mu.Unlock()
+	for rel, imports := range previous.imports {
+		if _, ok := idx.imports[rel]; !ok {
+			idx.imports[rel] = imports
+		}
+	}
+}
+
+// LoadCache はキャッシュファイルに保存したモジュール指定子を読み込みます
+// ファイルが存在しない場合や形式のバージョンが異なる場合は何もしません
+func (idx *Index) LoadCache(path string) error {
+	data, err := idx.fs.ReadFile(path)
+	if err != nil {
+		if isNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("索引のキャッシュの読み込みに失敗しました: %w", err)
+	}
+	var cache indexCacheFile
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return fmt.Errorf("索引のキャッシュの解析に失敗しました: %w", err)
+	}
+	if cache.Version != indexCacheVersion {
+		return nil
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for rel, imports := range cache.Files {
+		if _, ok := idx.imports[rel]; !ok {
+			idx.imports[rel] = imports
+		}
+	}
+	return nil
+}
+
+// SaveCache は読み込んだモジュール指定子のうち、索引に含まれるファイルのものをキャッシュファイルに保存します
+func (idx *Index) SaveCache(path string) error {
+	cache := indexCacheFile{Version: indexCacheVersion, Files: make(map[string]indexedImports)}
+	idx.mu.Lock()
+	for rel, imports := range idx.imports {
+		if entry := idx.Entry(rel); entry != nil && !entry.Info.IsDir() {
+			cache.Files[rel] = imports
+		}
+	}
+	idx.mu.Unlock()
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("索引のキャッシュの変換に失敗しました: %w", err)
+	}
+	if err := idx.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("索引のキャッシュの保存先の作成に失敗しました: %w", err)
+	}
+	if err := idx.fs.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("索引のキャッシュの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// エンジンが使用する索引（WithOutput による複製の間で共有する）
+type indexHolder struct {
+	mu  sync.Mutex
+	idx *Index
+	// 複製元のエンジンの索引（読み込んだモジュール指定子を引き継ぐ）
+	previous *Index
+}
+
+// Index はプロジェクトの索引を返します
+// 索引は最初に必要になった時点で作成し、エンジンがファイルシステムを変更するまで再利用します
+// 作り直す場合は、以前の索引で読み込んだモジュール指定子を引き継ぎます
+func (e *Engine) Index() (*Index, error) {
+	if e.index == nil {
+		e.index = &indexHolder{}
+	}
+	e.index.mu.Lock()
+	defer e.index.mu.Unlock()
+	if e.index.idx != nil && !e.index.idx.stale.Load() {
+		return e.index.idx, nil
+	}
+
+	idx, err := BuildIndex(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+	if e.index.idx != nil {
+		idx.inherit(e.index.idx)
+	} else if e.index.previous != nil {
+		idx.inherit(e.index.previous)
+		e.index.previous = nil
+	} else if e.opts.IndexCachePath != "" {
+		if err := idx.LoadCache(e.opts.IndexCachePath); err != nil {
+			e.debugf("警告: %v\n", err)
+		}
+	}
+	e.index.idx = idx
+	return idx, nil
+}
+
+// 索引を作成済みの場合はその索引を返す
+func (e *Engine) currentIndex() *Index {
+	if e.index == nil {
+		return nil
+	}
+	e.index.mu.Lock()
+	defer e.index.mu.Unlock()
+	return e.index.idx
+}
+
+// InvalidateIndex は索引を破棄し、次に使用するときに作り直します
+// エンジンを通さずにファイルシステムを変更した場合に呼び出してください
+func (e *Engine) InvalidateIndex() {
+	if idx := e.currentIndex(); idx != nil {
+		idx.stale.Store(true)
+	}
+}
+
+// SaveIndexCache は索引のモジュール指定子を IndexCachePath に保存します（未設定の場合は何もしません）
+func (e *Engine) SaveIndexCache() error {
+	if e.opts.IndexCachePath == "" {
+		return nil
+	}
+	idx, err := e.Index()
+	if err != nil {
+		return err
+	}
+	return idx.SaveCache(e.opts.IndexCachePath)
+}
+
+// Imports はソースファイルのモジュール指定子を索引から返します
+func (e *Engine) Imports(path string) ([]ImportRef, error) {
+	idx, err := e.Index()
+	if err != nil {
+		content, err := e.fs.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return ScanImports(content), nil
+	}
+	return idx.Imports(path)
+}
+
+// ディレクトリの直下に .tsx / .jsx ファイルがあるかどうか
+func (e *Engine) containsComponentFile(dir string) bool {
+	if idx, err := e.Index(); err == nil {
+		if rel, ok := idx.rel(filepath.Clean(dir)); ok && idx.Entry(rel) != nil {
+			for _, child := range idx.Children(rel) {
+				if child.Kind == KindComponent {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	files, _ := e.fs.ReadDir(dir)
+	for _, f := range files {
+		if !f.IsDir() && (strings.HasSuffix(f.Name(), ".tsx") || strings.HasSuffix(f.Name(), ".jsx")) {
+			return true
+		}
+	}
+	return false
+}
+
+// walkIndexed は Walk(e.fs, root, fn) と同じ規則で、索引を使って root 配下を走査します
+// 索引を作成できない場合や、root が索引に含まれない場合はファイルシステムを直接走査します
+func (e *Engine) walkIndexed(root string, fn filepath.WalkFunc) error {
+	idx, err := e.Index()
+	if err != nil {
+		e.debugf("警告: %v\n", err)
+		return Walk(e.fs, root, fn)
+	}
+	rel, ok := idx.rel(filepath.Clean(root))
+	i, found := idx.positions[rel]
+	if !ok || !found {
+		return Walk(e.fs, root, fn)
+	}
+	if _, err := idx.walk(i, fn); err != nil && err != filepath.SkipDir && err != filepath.SkipAll {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package renamer
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// 読み込みの回数を数える FS
+type readCountingFS struct {
+	FS
+	mu    sync.Mutex
+	reads map[string]int
+}
+
+func (c *readCountingFS) ReadFile(name string) ([]byte, error) {
+	c.mu.Lock()
+	c.reads[name]++
+	c.mu.Unlock()
+	return c.FS.ReadFile(name)
+}
+
+type IndexTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *IndexTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/components/Button.tsx":          "import { cn } from '../lib/cn';\n",
+		"apps/web/components/user-card/index.tsx": "export * from './user-card';\n",
+		"apps/web/components/user-card/user-card.tsx": "import React from 'react';\n" +
+			"const Lazy = import('./lazy');\n",
+		"apps/web/lib/cn.ts":                     "export const cn = () => '';\n",
+		"apps/web/README.md":                     "# web\n",
+		"apps/web/node_modules/pkg/Index.tsx":    "export {};\n",
+		"apps/web/node_modules/pkg/package.json": "{}\n",
+	})
+}
+
+func (s *IndexTestSuite) newEngine(fsys FS) *Engine {
+	engine, err := NewEngine(s.root, Options{FS: fsys, ConversionDirection: DirectionCamelToKebab})
+	s.Require().NoError(err)
+	return engine
+}
+
+// fn で走査したパスを記録する
+func collect(walk func(string, filepath.WalkFunc) error, root string, skip func(path string, info fs.FileInfo) error) ([]string, error) {
+	var paths []string
+	err := walk(root, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+		if skip != nil {
+			return skip(path, info)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+func (s *IndexTestSuite) TestBuildIndex() {
+	idx, err := BuildIndex(s.fs, s.root)
+	s.Require().NoError(err)
+
+	entry := idx.Entry("apps/web/components/Button.tsx")
+	s.Require().NotNil(entry)
+	s.Equal(KindComponent, entry.Kind)
+	s.Equal(ConventionCamel, entry.Convention)
+
+	s.Equal(KindSource, idx.Entry("apps/web/lib/cn.ts").Kind)
+	s.Equal(KindOther, idx.Entry("apps/web/README.md").Kind)
+	dir := idx.Entry("apps/web/components/user-card")
+	s.Equal(KindDir, dir.Kind)
+	s.Equal(ConventionKebab, dir.Convention)
+
+	var children []string
+	for _, child := range idx.Children("apps/web/components") {
+		children = append(children, child.Path)
+	}
+	s.Equal([]string{"apps/web/components/Button.tsx", "apps/web/components/user-card"}, children)
+
+	// 除外ディレクトリの配下は索引に含めない
+	s.NotNil(idx.Entry("apps/web/node_modules"))
+	s.Nil(idx.Entry("apps/web/node_modules/pkg"))
+}
+
+func (s *IndexTestSuite) TestWalkIndexedMatchesWalk() {
+	engine := s.newEngine(s.fs)
+	walk := func(root string, fn filepath.WalkFunc) error { return Walk(s.fs, root, fn) }
+
+	cases := map[string]func(path string, info fs.FileInfo) error{
+		"すべて": nil,
+		"除外ディレクトリをスキップ": func(path string, info fs.FileInfo) error {
+			if info.IsDir() && isExcludedDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		},
+		"ディレクトリをスキップ": func(path string, info fs.FileInfo) error {
+			if info.IsDir() && info.Name() == "components" {
+				return filepath.SkipDir
+			}
+			return nil
+		},
+		// ファイルで SkipDir を返した場合は、残りの兄弟を走査しない
+		"ファイルで SkipDir": func(path string, info fs.FileInfo) error {
+			if info.Name() == "index.tsx" {
+				return filepath.SkipDir
+			}
+			return nil
+		},
+		"SkipAll": func(path string, info fs.FileInfo) error {
+			if info.Name() == "cn.ts" {
+				return filepath.SkipAll
+			}
+			return nil
+		},
+	}
+	for _, root := range []string{s.root, filepath.Join(s.root, "apps/web/components")} {
+		for name, skip := range cases {
+			want, err := collect(walk, root, skip)
+			s.Require().NoError(err)
+			got, err := collect(engine.walkIndexed, root, skip)
+			s.Require().NoError(err)
+			s.Equal(want, got, name)
+		}
+	}
+
+	// 索引にないパスはファイルシステムを直接走査する
+	_, err := collect(engine.walkIndexed, filepath.Join(s.root, "missing"), nil)
+	s.Error(err)
+}
+
+func (s *IndexTestSuite) TestImportsCache() {
+	fsys := &readCountingFS{FS: s.fs, reads: make(map[string]int)}
+	engine := s.newEngine(fsys)
+	path := filepath.Join(s.root, "apps/web/components/user-card/user-card.tsx")
+
+	refs, err := engine.Imports(path)
+	s.Require().NoError(err)
+	s.Len(refs, 2)
+	s.True(refs[1].Dynamic)
+	_, err = engine.Imports(path)
+	s.Require().NoError(err)
+	s.Equal(1, fsys.reads[path], "変更のないファイルは読み込まない")
+
+	// サイズが変わった場合は読み込み直す
+	s.Require().NoError(s.fs.WriteFile(path, []byte("import React from 'react';\n"), 0644))
+	refs, err = engine.Imports(path)
+	s.Require().NoError(err)
+	s.Len(refs, 1)
+	s.Equal(2, fsys.reads[path])
+}
+
+func (s *IndexTestSuite) TestSaveAndLoadCache() {
+	cachePath := IndexCachePath(s.root)
+	path := filepath.Join(s.root, "apps/web/components/Button.tsx")
+
+	engine := s.newEngine(s.fs)
+	engine.SetIndexCachePath(cachePath)
+	_, err := engine.Imports(path)
+	s.Require().NoError(err)
+	s.Require().NoError(engine.SaveIndexCache())
+	s.True(exists(s.fs, cachePath))
+
+	// 別の実行ではキャッシュから読み込む
+	fsys := &readCountingFS{FS: s.fs, reads: make(map[string]int)}
+	engine = s.newEngine(fsys)
+	engine.SetIndexCachePath(cachePath)
+	refs, err := engine.Imports(path)
+	s.Require().NoError(err)
+	s.Equal([]ImportRef{{Specifier: "../lib/cn", Line: 1, Offset: 20}}, refs)
+	s.Zero(fsys.reads[path])
+}
+
+func (s *IndexTestSuite) TestInvalidateAfterExecute() {
+	engine := s.newEngine(s.fs)
+	components := filepath.Join(s.root, "apps/web/components")
+	before, err := collect(engine.walkIndexed, components, nil)
+	s.Require().NoError(err)
+	s.Contains(before, filepath.Join(components, "Button.tsx"))
+
+	_, err = engine.Execute("test", func(worker *Engine) error {
+		return worker.FS().Rename(filepath.Join(components, "Button.tsx"), filepath.Join(components, "button.tsx"))
+	})
+	s.Require().NoError(err)
+
+	after, err := collect(engine.walkIndexed, components, nil)
+	s.Require().NoError(err)
+	s.Contains(after, filepath.Join(components, "button.tsx"))
+	s.NotContains(after, filepath.Join(components, "Button.tsx"))
+}
+
+func TestIndexSuite(t *testing.T) {
+	suite.Run(t, new(IndexTestSuite))
+}
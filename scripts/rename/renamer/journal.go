@@ -0,0 +1,416 @@
+package renamer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ジャーナルの形式のバージョン
+const journalVersion = 1
+
+// ジャーナルに記録する操作の種類
+const (
+	JournalOpRename = "rename"
+	JournalOpWrite  = "write"
+	JournalOpRemove = "remove"
+	JournalOpMkdir  = "mkdir"
+)
+
+// Journal は 1 回の実行でファイルシステムに対して行った変更の記録です
+// 記録を逆順にたどることで、実行前の状態に戻すことができます
+type Journal struct {
+	Version int    `json:"version"`
+	ID      string `json:"id"`
+	// 変更を行ったコマンド（apply など）
+	Command   string    `json:"command"`
+	Root      string    `json:"root"`
+	CreatedAt time.Time `json:"createdAt"`
+	// 元に戻した日時（元に戻していない場合は nil）
+	UndoneAt *time.Time `json:"undoneAt,omitempty"`
+	// git mv でリネームしたかどうか（元に戻す場合も git mv を使用する）
+	Git bool        `json:"git,omitempty"`
+	Ops []JournalOp `json:"ops"`
+
+	mu sync.Mutex
+}
+
+// JournalOp はジャーナルに記録された 1 つの操作です
+// パスはプロジェクトルートからの相対パス（スラッシュ区切り）です
+type JournalOp struct {
+	Op   string `json:"op"`
+	Path string `json:"path,omitempty"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// 変更前の内容（write / remove の場合）
+	Before []byte `json:"before,omitempty"`
+	// 変更前にファイルが存在したかどうか（write の場合）
+	Existed bool        `json:"existed,omitempty"`
+	Perm    fs.FileMode `json:"perm,omitempty"`
+}
+
+// NewJournal は新しいジャーナルを作成します
+func NewJournal(root, command string) *Journal {
+	now := time.Now()
+	return &Journal{
+		Version:   journalVersion,
+		ID:        now.Format("20060102-150405.000000"),
+		Command:   command,
+		Root:      root,
+		CreatedAt: now,
+	}
+}
+
+// JournalDir はジャーナルの保存先ディレクトリを返します
+func JournalDir(projectRoot string) string {
+	return filepath.Join(projectRoot, "scripts", "rename", ".journal")
+}
+
+// Empty は記録された操作がないかどうかを返します
+func (j *Journal) Empty() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.Ops) == 0
+}
+
+// 操作を記録する
+func (j *Journal) record(op JournalOp) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.Ops = append(j.Ops, op)
+}
+
+// プロジェクトルートからの相対パスに変換する
+func (j *Journal) rel(path string) string {
+	rel, err := filepath.Rel(j.Root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// 記録された相対パスを絶対パスに変換する
+func (j *Journal) abs(rel string) string {
+	path := filepath.FromSlash(rel)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(j.Root, path)
+}
+
+// MapPath は実行前の相対パスを、記録されたリネームを順に適用した実行後の相対パスに変換します
+func (j *Journal) MapPath(rel string) string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.mapPathFrom(rel, 0)
+}
+
+// start 番目以降の操作に含まれるリネームを適用する（ロック取得済みであること）
+func (j *Journal) mapPathFrom(rel string, start int) string {
+	for _, op := range j.Ops[start:] {
+		if op.Op != JournalOpRename {
+			continue
+		}
+		if rel == op.From {
+			rel = op.To
+		} else if strings.HasPrefix(rel, op.From+"/") {
+			rel = op.To + strings.TrimPrefix(rel, op.From)
+		}
+	}
+	return rel
+}
+
+// ChangedPaths は実行によって作成・変更・移動されたパスの一覧を返します（実行後の相対パス）
+func (j *Journal) ChangedPaths() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var paths []string
+	for i, op := range j.Ops {
+		switch op.Op {
+		case JournalOpRename:
+			paths = append(paths, j.mapPathFrom(op.To, i+1))
+		case JournalOpWrite:
+			paths = append(paths, j.mapPathFrom(op.Path, i+1))
+		}
+	}
+	sort.Strings(paths)
+	return dedupeSorted(paths)
+}
+
+// RenamedPaths はリネームの元と先のパスの一覧を返します（元は実行前、先は実行後の相対パス）
+func (j *Journal) RenamedPaths() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var paths []string
+	for i, op := range j.Ops {
+		if op.Op == JournalOpRename {
+			paths = append(paths, op.From, j.mapPathFrom(op.To, i+1))
+		}
+	}
+	sort.Strings(paths)
+	return dedupeSorted(paths)
+}
+
+// Touches は相対パス rel が記録された操作の対象（リネームの場合は元と先のパスとその配下）かどうかを返します
+func (j *Journal) Touches(rel string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	under := func(base string) bool {
+		return base != "" && (rel == base || strings.HasPrefix(rel, base+"/"))
+	}
+	for i, op := range j.Ops {
+		switch op.Op {
+		case JournalOpRename:
+			if under(op.From) || under(op.To) || under(j.mapPathFrom(op.To, i+1)) {
+				return true
+			}
+		case JournalOpWrite, JournalOpRemove:
+			if rel == op.Path || rel == j.mapPathFrom(op.Path, i+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CreatedPaths は実行によって新たに作成したファイルの実行後のパスを返します
+func (j *Journal) CreatedPaths() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var paths []string
+	for i, op := range j.Ops {
+		if op.Op == JournalOpWrite && !op.Existed {
+			paths = append(paths, j.mapPathFrom(op.Path, i+1))
+		}
+	}
+	sort.Strings(paths)
+	return dedupeSorted(paths)
+}
+
+// Rollback は記録された操作を逆順に取り消し、実行前の状態に戻します
+// 取り消しに失敗した操作があっても残りの操作は続行し、最初のエラーを返します
+func (j *Journal) Rollback(fsys FS) error {
+	j.mu.Lock()
+	ops := append([]JournalOp(nil), j.Ops...)
+	j.mu.Unlock()
+
+	var firstErr error
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	for i := len(ops) - 1; i >= 0; i-- {
+		op := ops[i]
+		switch op.Op {
+		case JournalOpRename:
+			if err := fsys.Rename(j.abs(op.To), j.abs(op.From)); err != nil {
+				fail(fmt.Errorf("%s → %s のリネームを元に戻せませんでした: %w", op.To, op.From, err))
+			}
+		case JournalOpWrite:
+			var err error
+			if op.Existed {
+				err = fsys.WriteFile(j.abs(op.Path), op.Before, op.Perm)
+			} else {
+				err = fsys.Remove(j.abs(op.Path))
+			}
+			if err != nil {
+				fail(fmt.Errorf("%s の変更を元に戻せませんでした: %w", op.Path, err))
+			}
+		case JournalOpRemove:
+			var err error
+			if op.Perm.IsDir() {
+				err = fsys.MkdirAll(j.abs(op.Path), op.Perm.Perm())
+			} else {
+				err = fsys.WriteFile(j.abs(op.Path), op.Before, op.Perm)
+			}
+			if err != nil {
+				fail(fmt.Errorf("%s を復元できませんでした: %w", op.Path, err))
+			}
+		case JournalOpMkdir:
+			// 作成したディレクトリは空の場合のみ削除する
+			if entries, err := fsys.ReadDir(j.abs(op.Path)); err == nil && len(entries) == 0 {
+				if err := fsys.Remove(j.abs(op.Path)); err != nil {
+					fail(fmt.Errorf("%s を削除できませんでした: %w", op.Path, err))
+				}
+			}
+		}
+	}
+	return firstErr
+}
+
+// MarkUndone は元に戻したことを記録します（保存し直すと LatestJournal の対象外になります）
+func (j *Journal) MarkUndone() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now()
+	j.UndoneAt = &now
+}
+
+// Save はジャーナルをディレクトリに保存し、保存したファイルのパスを返します
+func (j *Journal) Save(fsys FS, dir string) (string, error) {
+	j.mu.Lock()
+	data, err := json.MarshalIndent(j, "", "  ")
+	j.mu.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("ジャーナルの変換に失敗しました: %w", err)
+	}
+	if err := fsys.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("ジャーナルの保存先の作成に失敗しました: %w", err)
+	}
+	path := filepath.Join(dir, j.ID+".json")
+	if err := fsys.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("ジャーナルの保存に失敗しました: %w", err)
+	}
+	return path, nil
+}
+
+// LoadJournal は保存されたジャーナルを読み込みます
+func LoadJournal(fsys FS, path string) (*Journal, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ジャーナルの読み込みに失敗しました: %w", err)
+	}
+	var j Journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("ジャーナルの解析に失敗しました: %w", err)
+	}
+	if j.Version != journalVersion {
+		return nil, fmt.Errorf("対応していないジャーナルのバージョンです: %d", j.Version)
+	}
+	return &j, nil
+}
+
+// LatestJournal はディレクトリ内で最も新しい、まだ元に戻していないジャーナルとそのパスを返します
+// 該当するジャーナルがない場合は nil を返します
+func LatestJournal(fsys FS, dir string) (*Journal, string, error) {
+	entries, err := fsys.ReadDir(dir)
+	if err != nil {
+		if isNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("ジャーナルの一覧の取得に失敗しました: %w", err)
+	}
+
+	// ID は日時のため、名前の降順が新しい順になる
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		j, err := LoadJournal(fsys, path)
+		if err != nil {
+			return nil, "", err
+		}
+		if j.UndoneAt == nil {
+			return j, path, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// JournalFS は下層の FS への変更をジャーナルに記録する FS の実装です
+type JournalFS struct {
+	base    FS
+	journal *Journal
+}
+
+// NewJournalFS は base への変更を journal に記録する FS を作成します
+func NewJournalFS(base FS, journal *Journal) *JournalFS {
+	return &JournalFS{base: base, journal: journal}
+}
+
+// Journal は変更を記録しているジャーナルを返します
+func (f *JournalFS) Journal() *Journal {
+	return f.journal
+}
+
+func (f *JournalFS) ReadFile(name string) ([]byte, error) { return f.base.ReadFile(name) }
+
+func (f *JournalFS) Stat(name string) (fs.FileInfo, error) { return f.base.Stat(name) }
+
+func (f *JournalFS) ReadDir(name string) ([]fs.DirEntry, error) { return f.base.ReadDir(name) }
+
+func (f *JournalFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	op := JournalOp{Op: JournalOpWrite, Path: f.journal.rel(name), Perm: perm}
+	if info, err := f.base.Stat(name); err == nil && !info.IsDir() {
+		before, err := f.base.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		op.Before, op.Existed, op.Perm = before, true, info.Mode().Perm()
+	}
+	if err := f.base.WriteFile(name, data, perm); err != nil {
+		return err
+	}
+	f.journal.record(op)
+	return nil
+}
+
+func (f *JournalFS) Rename(oldpath, newpath string) error {
+	if err := f.base.Rename(oldpath, newpath); err != nil {
+		return err
+	}
+	f.journal.record(JournalOp{Op: JournalOpRename, From: f.journal.rel(oldpath), To: f.journal.rel(newpath)})
+	return nil
+}
+
+func (f *JournalFS) MkdirAll(path string, perm fs.FileMode) error {
+	// 作成されるディレクトリを親から順に記録する
+	var missing []string
+	for p := filepath.Clean(path); !exists(f.base, p); p = filepath.Dir(p) {
+		missing = append(missing, p)
+		if filepath.Dir(p) == p {
+			break
+		}
+	}
+	if err := f.base.MkdirAll(path, perm); err != nil {
+		return err
+	}
+	for i := len(missing) - 1; i >= 0; i-- {
+		f.journal.record(JournalOp{Op: JournalOpMkdir, Path: f.journal.rel(missing[i])})
+	}
+	return nil
+}
+
+func (f *JournalFS) Remove(name string) error {
+	info, err := f.base.Stat(name)
+	if err != nil {
+		return f.base.Remove(name)
+	}
+	op := JournalOp{Op: JournalOpRemove, Path: f.journal.rel(name), Perm: info.Mode() & (fs.ModeDir | fs.ModePerm)}
+	if !info.IsDir() {
+		if op.Before, err = f.base.ReadFile(name); err != nil {
+			return err
+		}
+	}
+	if err := f.base.Remove(name); err != nil {
+		return err
+	}
+	f.journal.record(op)
+	return nil
+}
+
+// ソート済みのスライスから重複を取り除く
+func dedupeSorted(items []string) []string {
+	var result []string
+	for i, item := range items {
+		if i == 0 || item != items[i-1] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
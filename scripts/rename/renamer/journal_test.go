@@ -0,0 +1,132 @@
+package renamer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JournalTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *JournalTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"components/UserCard/index.tsx": "export * from './UserCard';",
+		"components/Button.tsx":         "export const Button = () => null;",
+		"app/page.tsx":                  "import { Button } from '../components/Button';",
+		"lib/old.ts":                    "export const old = true;",
+	})
+}
+
+func (s *JournalTestSuite) path(rel string) string {
+	return filepath.Join(s.root, filepath.FromSlash(rel))
+}
+
+// ファイルの内容の一覧（相対パス → 内容）
+func (s *JournalTestSuite) snapshot() map[string]string {
+	files := map[string]string{}
+	err := Walk(s.fs, s.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(s.root, path)
+		if info.IsDir() {
+			files[filepath.ToSlash(rel)+"/"] = ""
+			return nil
+		}
+		data, err := s.fs.ReadFile(path)
+		files[filepath.ToSlash(rel)] = string(data)
+		return err
+	})
+	s.Require().NoError(err)
+	return files
+}
+
+// ファイルシステムを一通り変更する
+func (s *JournalTestSuite) modify(fsys FS) {
+	s.Require().NoError(fsys.Rename(s.path("components/UserCard"), s.path("components/user-card")))
+	s.Require().NoError(fsys.Rename(s.path("components/Button.tsx"), s.path("components/button.tsx")))
+	s.Require().NoError(fsys.WriteFile(s.path("app/page.tsx"), []byte("import { Button } from '../components/button';"), 0644))
+	s.Require().NoError(fsys.MkdirAll(s.path("lib/generated/types"), 0755))
+	s.Require().NoError(fsys.WriteFile(s.path("lib/generated/types/index.ts"), []byte("export {};"), 0644))
+	s.Require().NoError(fsys.Remove(s.path("lib/old.ts")))
+}
+
+func (s *JournalTestSuite) TestRollback() {
+	before := s.snapshot()
+
+	journal := NewJournal(s.root, "test")
+	s.modify(NewJournalFS(s.fs, journal))
+	s.NotEqual(before, s.snapshot())
+
+	s.Require().NoError(journal.Rollback(s.fs))
+	s.Equal(before, s.snapshot())
+}
+
+func (s *JournalTestSuite) TestMapPathAndChangedPaths() {
+	journal := NewJournal(s.root, "test")
+	s.modify(NewJournalFS(s.fs, journal))
+
+	s.Equal("components/user-card/index.tsx", journal.MapPath("components/UserCard/index.tsx"))
+	s.Equal("components/button.tsx", journal.MapPath("components/Button.tsx"))
+	s.Equal("app/page.tsx", journal.MapPath("app/page.tsx"))
+
+	s.Equal([]string{
+		"app/page.tsx",
+		"components/button.tsx",
+		"components/user-card",
+		"lib/generated/types/index.ts",
+	}, journal.ChangedPaths())
+}
+
+func (s *JournalTestSuite) TestSaveAndLatest() {
+	dir := JournalDir(s.root)
+
+	// ジャーナルがない場合
+	latest, _, err := LatestJournal(s.fs, dir)
+	s.NoError(err)
+	s.Nil(latest)
+
+	first := NewJournal(s.root, "apply")
+	first.ID = "20250101-000000.000000"
+	s.modify(NewJournalFS(s.fs, first))
+	_, err = first.Save(s.fs, dir)
+	s.Require().NoError(err)
+
+	second := NewJournal(s.root, "mv")
+	second.ID = "20250102-000000.000000"
+	s.Require().NoError(NewJournalFS(s.fs, second).WriteFile(s.path("app/page.tsx"), []byte("changed"), 0644))
+	secondPath, err := second.Save(s.fs, dir)
+	s.Require().NoError(err)
+
+	latest, path, err := LatestJournal(s.fs, dir)
+	s.Require().NoError(err)
+	s.Equal(secondPath, path)
+	s.Equal("mv", latest.Command)
+	s.Len(latest.Ops, 1)
+
+	// 読み込んだジャーナルで元に戻せる
+	s.Require().NoError(latest.Rollback(s.fs))
+	data, err := s.fs.ReadFile(s.path("app/page.tsx"))
+	s.NoError(err)
+	s.Equal("import { Button } from '../components/button';", string(data))
+
+	// 元に戻したジャーナルは対象外になる
+	latest.MarkUndone()
+	_, err = latest.Save(s.fs, dir)
+	s.Require().NoError(err)
+	latest, _, err = LatestJournal(s.fs, dir)
+	s.Require().NoError(err)
+	s.Equal("apply", latest.Command)
+}
+
+func TestJournalSuite(t *testing.T) {
+	suite.Run(t, new(JournalTestSuite))
+}
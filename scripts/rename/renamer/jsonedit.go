@@ -0,0 +1,179 @@
+package renamer
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// JSON（JSONC）の文字列リテラル
+type jsonString struct {
+	// 引用符の内側の範囲（バイトオフセット）と、エスケープを解釈しない内容
+	offset, end int
+	value       string
+	// 文字列を含むオブジェクトのキー（"compilerOptions", "paths" など、配列は含まない）
+	path []string
+	// オブジェクトのキーかどうか
+	key bool
+	// 1 から始まる行番号
+	line int
+}
+
+// JSON（JSONC）のオブジェクト
+type jsonObject struct {
+	// "{" と "}" のバイトオフセット
+	offset, end int
+	// オブジェクトのキー（ルートのオブジェクトは空）
+	path []string
+}
+
+// JSON（JSONC）の文字列リテラルを、オブジェクトの中の位置とともに出現順に返す
+// コメントは読み飛ばし、書式の検査は行わない
+func scanJSONStrings(data []byte) []jsonString {
+	strs, _ := scanJSON(data)
+	return strs
+}
+
+// JSON（JSONC）の文字列リテラルを出現順に、オブジェクトを閉じた順に返す
+func scanJSON(data []byte) ([]jsonString, []jsonObject) {
+	type frame struct {
+		object    bool
+		expectKey bool
+		key       string
+		offset    int
+		path      []string
+	}
+	var stack []frame
+	keys := func(depth int) []string {
+		var path []string
+		for _, f := range stack[:depth] {
+			if f.object && f.key != "" {
+				path = append(path, f.key)
+			}
+		}
+		return path
+	}
+
+	starts := lineStarts(data)
+	var strs []jsonString
+	var objects []jsonObject
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i+1 < len(data) && data[i+1] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == '{':
+			stack = append(stack, frame{object: true, expectKey: true, offset: i, path: keys(len(stack))})
+		case c == '[':
+			stack = append(stack, frame{})
+		case c == '}' || c == ']':
+			if top := len(stack) - 1; top >= 0 {
+				if c == '}' && stack[top].object {
+					objects = append(objects, jsonObject{offset: stack[top].offset, end: i, path: stack[top].path})
+				}
+				stack = stack[:top]
+			}
+		case c == ',':
+			if len(stack) > 0 && stack[len(stack)-1].object {
+				stack[len(stack)-1].expectKey = true
+			}
+		case c == ':':
+			if len(stack) > 0 {
+				stack[len(stack)-1].expectKey = false
+			}
+		case c == '"':
+			start := i + 1
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			end := min(i, len(data))
+			s := jsonString{offset: start, end: end, value: string(data[start:end]), line: lineAt(starts, start)}
+			if top := len(stack) - 1; top >= 0 && stack[top].object && stack[top].expectKey {
+				s.key = true
+				s.path = keys(top)
+				stack[top].key = s.value
+			} else {
+				s.path = keys(len(stack))
+			}
+			strs = append(strs, s)
+		}
+	}
+	return strs, objects
+}
+
+// 文字列リテラルの書き換え
+type jsonEdit struct {
+	str         jsonString
+	replacement string
+}
+
+// 文字列リテラルを書き換える（後ろから置き換えて、前にある文字列のオフセットがずれないようにする）
+func applyJSONEdits(data []byte, edits []jsonEdit) []byte {
+	sorted := append([]jsonEdit(nil), edits...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].str.offset > sorted[j].str.offset })
+	out := string(data)
+	for _, edit := range sorted {
+		out = out[:edit.str.offset] + edit.replacement + out[edit.str.end:]
+	}
+	return []byte(out)
+}
+
+// JSON の位置を "compilerOptions.paths" の形式で表す
+func jsonPath(path []string) string {
+	return strings.Join(path, ".")
+}
+
+// オブジェクト obj に文字列のメンバー "key": "value" を追加する
+// after が 0 以上の場合はその位置（前のメンバーの値の直後）に、負の場合はオブジェクトの末尾に追加し、前のメンバーのインデントに合わせる
+func insertJSONMember(data []byte, obj jsonObject, after int, key, value string) []byte {
+	member := fmt.Sprintf("%q: %q", key, value)
+	if after < 0 {
+		after = obj.end
+		for after > obj.offset+1 && isJSONSpace(data[after-1]) {
+			after--
+		}
+	}
+	out := string(data)
+	switch {
+	case after == obj.offset+1 && !strings.Contains(out[obj.offset:obj.end], "\n"):
+		// 空のオブジェクト（1 行）
+		return []byte(out[:obj.offset+1] + member + out[obj.end:])
+	case after == obj.offset+1:
+		// 空のオブジェクト（複数行）
+		indent := lineIndent(data, obj.offset)
+		return []byte(out[:after] + "\n" + indent + "  " + member + "\n" + indent + out[obj.end:])
+	case !strings.Contains(out[obj.offset:obj.end], "\n"):
+		return []byte(out[:after] + ", " + member + out[after:])
+	}
+	separator := ","
+	if data[after-1] == ',' {
+		// 末尾のカンマ（JSONC）
+		separator = ""
+	}
+	return []byte(out[:after] + separator + "\n" + lineIndent(data, after-1) + member + out[after:])
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// offset を含む行の先頭の空白
+func lineIndent(data []byte, offset int) string {
+	start := bytes.LastIndexByte(data[:offset], '\n') + 1
+	end := start
+	for end < len(data) && (data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+	return string(data[start:end])
+}
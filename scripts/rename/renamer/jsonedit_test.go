@@ -0,0 +1,115 @@
+package renamer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONEditTestSuite struct {
+	suite.Suite
+}
+
+const testJSONC = `{
+  // コメントの中の "文字列" は無視する
+  "extends": "@kit/tsconfig/base.json",
+  "compilerOptions": {
+    "paths": {
+      "@kit/ui/*": ["../../packages/ui/src/*", "./src/\"quoted\""],
+    },
+  },
+  /* "block" */ "include": ["src"]
+}`
+
+func (s *JSONEditTestSuite) TestScanJSONStrings() {
+	var result []string
+	for _, str := range scanJSONStrings([]byte(testJSONC)) {
+		kind := "value"
+		if str.key {
+			kind = "key"
+		}
+		result = append(result, str.value+" "+kind+" "+jsonPath(str.path))
+	}
+	s.Equal([]string{
+		"extends key ",
+		"@kit/tsconfig/base.json value extends",
+		"compilerOptions key ",
+		"paths key compilerOptions",
+		"@kit/ui/* key compilerOptions.paths",
+		"../../packages/ui/src/* value compilerOptions.paths.@kit/ui/*",
+		`./src/\"quoted\" value compilerOptions.paths.@kit/ui/*`,
+		"include key ",
+		"src value include",
+	}, result)
+}
+
+func (s *JSONEditTestSuite) TestApplyJSONEdits() {
+	data := []byte(testJSONC)
+	strs := scanJSONStrings(data)
+	s.Equal(3, strs[1].line)
+	edited := applyJSONEdits(data, []jsonEdit{
+		{str: strs[1], replacement: "@kit/typescript-config/base.json"},
+		{str: strs[4], replacement: "@acme/ui/*"},
+	})
+	s.Contains(string(edited), `"extends": "@kit/typescript-config/base.json",`)
+	s.Contains(string(edited), `"@acme/ui/*": ["../../packages/ui/src/*"`)
+	s.Contains(string(edited), "// コメントの中の \"文字列\" は無視する", "コメントと書式はそのまま残す")
+}
+
+// path のオブジェクト
+func (s *JSONEditTestSuite) object(data []byte, path ...string) jsonObject {
+	_, objects := scanJSON(data)
+	for _, obj := range objects {
+		if jsonPath(obj.path) == jsonPath(path) {
+			return obj
+		}
+	}
+	s.FailNow("オブジェクトが見つかりません", jsonPath(path))
+	return jsonObject{}
+}
+
+func (s *JSONEditTestSuite) TestInsertJSONMember() {
+	data := []byte(`{
+  "name": "@kit/ui",
+  "exports": {
+    "./page-header": "./src/custom/page-header/index.tsx",
+    "./utils": {
+      "default": "./src/lib/utils.ts"
+    }
+  }
+}
+`)
+	exports := s.object(data, "exports")
+	s.Equal(`{
+  "name": "@kit/ui",
+  "exports": {
+    "./page-header": "./src/custom/page-header/index.tsx",
+    "./utils": {
+      "default": "./src/lib/utils.ts"
+    },
+    "./app-logo": "./src/custom/app-logo/index.tsx"
+  }
+}
+`, string(insertJSONMember(data, exports, -1, "./app-logo", "./src/custom/app-logo/index.tsx")), "末尾に追加する")
+
+	header := scanJSONStrings(data)[4]
+	s.Equal("./src/custom/page-header/index.tsx", header.value)
+	s.Contains(string(insertJSONMember(data, exports, header.end+1, "./app-logo", "./src/custom/app-logo/index.tsx")), `    "./page-header": "./src/custom/page-header/index.tsx",
+    "./app-logo": "./src/custom/app-logo/index.tsx",
+    "./utils": {`, "指定したメンバーの後に追加する")
+}
+
+func (s *JSONEditTestSuite) TestInsertJSONMemberEmptyObject() {
+	data := []byte("{\n  \"exports\": {}\n}")
+	s.Equal("{\n  \"exports\": {\"./a\": \"./a.ts\"}\n}", string(insertJSONMember(data, s.object(data, "exports"), -1, "./a", "./a.ts")))
+
+	data = []byte("{\n  \"exports\": {\n  }\n}")
+	s.Equal("{\n  \"exports\": {\n    \"./a\": \"./a.ts\"\n  }\n}", string(insertJSONMember(data, s.object(data, "exports"), -1, "./a", "./a.ts")))
+
+	data = []byte(`{"exports": {"./a": "./a.ts"}}`)
+	s.Equal(`{"exports": {"./a": "./a.ts", "./b": "./b.ts"}}`, string(insertJSONMember(data, s.object(data, "exports"), -1, "./b", "./b.ts")), "1 行のオブジェクト")
+}
+
+func TestJSONEditSuite(t *testing.T) {
+	suite.Run(t, new(JSONEditTestSuite))
+}
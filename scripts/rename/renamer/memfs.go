@@ -0,0 +1,252 @@
+package renamer
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemFS はメモリ上でファイルツリーを保持する FS の実装です
+// テストや、実際のファイルシステムに触れずに変換を試す用途で使用します
+type MemFS struct {
+	mu       sync.RWMutex
+	nodes    map[string]*memNode
+	children map[string]map[string]bool
+}
+
+// メモリ上のファイルまたはディレクトリ
+type memNode struct {
+	name    string
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMemFS は空の MemFS を作成します（ルートディレクトリのみ存在します）
+func NewMemFS() *MemFS {
+	m := &MemFS{
+		nodes:    make(map[string]*memNode),
+		children: make(map[string]map[string]bool),
+	}
+	root := string(filepath.Separator)
+	m.nodes[root] = &memNode{name: root, mode: fs.ModeDir | 0755, modTime: time.Now()}
+	m.children[root] = make(map[string]bool)
+	return m
+}
+
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, ok := m.nodes[filepath.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errIsDir}
+	}
+	return append([]byte(nil), node.data...), nil
+}
+
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	parent, ok := m.nodes[filepath.Dir(name)]
+	if !ok || !parent.mode.IsDir() {
+		return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if node, ok := m.nodes[name]; ok && node.mode.IsDir() {
+		return &fs.PathError{Op: "open", Path: name, Err: errIsDir}
+	}
+	m.add(name, &memNode{
+		name:    filepath.Base(name),
+		data:    append([]byte(nil), data...),
+		mode:    perm.Perm(),
+		modTime: time.Now(),
+	})
+	return nil
+}
+
+func (m *MemFS) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldpath, newpath = filepath.Clean(oldpath), filepath.Clean(newpath)
+	node, ok := m.nodes[oldpath]
+	if !ok {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrNotExist}
+	}
+	if oldpath == newpath {
+		return nil
+	}
+	if parent, ok := m.nodes[filepath.Dir(newpath)]; !ok || !parent.mode.IsDir() {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrNotExist}
+	}
+	if node.mode.IsDir() && strings.HasPrefix(newpath, oldpath+string(filepath.Separator)) {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrInvalid}
+	}
+	if target, ok := m.nodes[newpath]; ok {
+		// os.Rename と同様に、既存のファイルは置き換え、空でないディレクトリへの移動は失敗させる
+		if target.mode.IsDir() && len(m.children[newpath]) > 0 {
+			return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrExist}
+		}
+		m.removeTree(newpath)
+	}
+
+	// 子孫も含めて移動する
+	moved := map[string]*memNode{}
+	for path, n := range m.nodes {
+		if path == oldpath || strings.HasPrefix(path, oldpath+string(filepath.Separator)) {
+			moved[newpath+strings.TrimPrefix(path, oldpath)] = n
+		}
+	}
+	m.removeTree(oldpath)
+	paths := make([]string, 0, len(moved))
+	for path := range moved {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		n := moved[path]
+		n.name = filepath.Base(path)
+		m.add(path, n)
+	}
+	return nil
+}
+
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, ok := m.nodes[filepath.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return newMemFileInfo(node), nil
+}
+
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name = filepath.Clean(name)
+	node, ok := m.nodes[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if !node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errNotDir}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(m.children[name]))
+	for childName := range m.children[name] {
+		child := m.nodes[filepath.Join(name, childName)]
+		entries = append(entries, newMemFileInfo(child))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (m *MemFS) MkdirAll(path string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path = filepath.Clean(path)
+	var missing []string
+	for p := path; ; p = filepath.Dir(p) {
+		if node, ok := m.nodes[p]; ok {
+			if !node.mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: p, Err: errNotDir}
+			}
+			break
+		}
+		missing = append(missing, p)
+		if filepath.Dir(p) == p {
+			break
+		}
+	}
+	for i := len(missing) - 1; i >= 0; i-- {
+		m.add(missing[i], &memNode{
+			name:    filepath.Base(missing[i]),
+			mode:    fs.ModeDir | perm.Perm(),
+			modTime: time.Now(),
+		})
+	}
+	return nil
+}
+
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if _, ok := m.nodes[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if len(m.children[name]) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrExist}
+	}
+	m.removeTree(name)
+	return nil
+}
+
+// ノードを登録し、親ディレクトリの子一覧に追加する（ロック取得済みであること）
+func (m *MemFS) add(path string, node *memNode) {
+	m.nodes[path] = node
+	if node.mode.IsDir() && m.children[path] == nil {
+		m.children[path] = make(map[string]bool)
+	}
+	if parent := filepath.Dir(path); parent != path {
+		if m.children[parent] == nil {
+			m.children[parent] = make(map[string]bool)
+		}
+		m.children[parent][filepath.Base(path)] = true
+	}
+}
+
+// ノードを子孫も含めて削除する（ロック取得済みであること）
+func (m *MemFS) removeTree(path string) {
+	for childName := range m.children[path] {
+		m.removeTree(filepath.Join(path, childName))
+	}
+	delete(m.nodes, path)
+	delete(m.children, path)
+	if parent := filepath.Dir(path); parent != path {
+		delete(m.children[parent], filepath.Base(path))
+	}
+}
+
+// MemFS のファイル情報（fs.FileInfo と fs.DirEntry を兼ねる）
+type memFileInfo struct {
+	node *memNode
+	size int64
+}
+
+// ノードの現在の状態を写し取ったファイル情報を作成する（ロック取得済みであること）
+func newMemFileInfo(node *memNode) memFileInfo {
+	snapshot := *node
+	snapshot.data = nil
+	return memFileInfo{node: &snapshot, size: int64(len(node.data))}
+}
+
+func (i memFileInfo) Name() string               { return i.node.name }
+func (i memFileInfo) Size() int64                { return i.size }
+func (i memFileInfo) Mode() fs.FileMode          { return i.node.mode }
+func (i memFileInfo) ModTime() time.Time         { return i.node.modTime }
+func (i memFileInfo) IsDir() bool                { return i.node.mode.IsDir() }
+func (i memFileInfo) Sys() interface{}           { return nil }
+func (i memFileInfo) Type() fs.FileMode          { return i.node.mode.Type() }
+func (i memFileInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+// MemFS 固有のエラー
+var (
+	errIsDir  = errors.New("is a directory")
+	errNotDir = errors.New("not a directory")
+)
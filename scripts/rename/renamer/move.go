@@ -0,0 +1,376 @@
+package renamer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MoveResult は Move による移動の結果です
+// パスはすべてプロジェクトルートからの相対パス（スラッシュ区切り）です
+type MoveResult struct {
+	// 移動元と移動先
+	From string `json:"from"`
+	To   string `json:"to"`
+	// ディレクトリを移動したかどうか
+	IsDir bool `json:"isDir,omitempty"`
+	// 移動したファイルの数
+	Files int `json:"files"`
+	// 書き換えたモジュール指定子
+	Rewrites []SpecifierRewrite `json:"rewrites"`
+	// 書き換えた package.json のエントリ（main / types / module / exports）
+	PackageEntries []SpecifierRewrite `json:"packageEntries,omitempty"`
+	// 確認が必要な書き換え
+	Warnings []string `json:"warnings,omitempty"`
+	// 更新したバレル（barrels.yaml で設定したもの）
+	Barrels []string `json:"barrels,omitempty"`
+}
+
+// SpecifierRewrite はモジュール指定子の 1 件の書き換えです
+type SpecifierRewrite struct {
+	// 書き換えたファイル（移動後のパス）
+	File string `json:"file"`
+	// 1 から始まる行番号
+	Line int `json:"line,omitempty"`
+	// 書き換え前と書き換え後の指定子
+	From string `json:"from"`
+	To   string `json:"to"`
+	// 書き換え後の指定子の解決方法（relative / alias / workspace）
+	// package.json や tsconfig の書き換えの場合は書き換えた項目（name / dependencies / compilerOptions.paths など）
+	Kind string `json:"kind,omitempty"`
+	// 適用した書き換えの規則（rewrite-imports）
+	Rule string `json:"rule,omitempty"`
+}
+
+// String は "ファイル:行: '書き換え前' → '書き換え後'" の形式で書き換えを表します
+func (r SpecifierRewrite) String() string {
+	if r.Line == 0 {
+		return fmt.Sprintf("%s: '%s' → '%s'", r.File, r.From, r.To)
+	}
+	return fmt.Sprintf("%s:%d: '%s' → '%s'", r.File, r.Line, r.From, r.To)
+}
+
+// 移動によって書き換えが必要になる可能性のあるモジュール指定子
+type movedImport struct {
+	// インポートするファイルと解決先（移動前の絶対パス）
+	file, target string
+	ref          ImportRef
+	kind         string
+}
+
+// Move は src のファイルまたはディレクトリを dst（既存のディレクトリの場合はその中）に移動し、プロジェクト全体の参照を更新します
+func (e *Engine) Move(src, dst string) (*MoveResult, *Run, error) {
+	from, to, isDir, err := e.moveTarget(src, dst)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &MoveResult{From: e.Rel(from), To: e.Rel(to), IsDir: isDir, Rewrites: []SpecifierRewrite{}}
+	run, err := e.Execute("mv", func(worker *Engine) error {
+		if err := worker.move(result, []movePath{{from: from, to: to}}); err != nil {
+			return err
+		}
+		var err error
+		result.Barrels, err = worker.updateRenamedBarrels()
+		return err
+	})
+	return result, run, err
+}
+
+// 移動元と移動先の絶対パスを求め、移動できるかどうかを確認する
+func (e *Engine) moveTarget(src, dst string) (from, to string, isDir bool, err error) {
+	from, to = e.pathArg(src), e.pathArg(dst)
+	info, err := e.fs.Stat(from)
+	if err != nil {
+		return "", "", false, fmt.Errorf("移動元が見つかりません: %s", e.Rel(from))
+	}
+	if from == e.root {
+		return "", "", false, fmt.Errorf("プロジェクトルートは移動できません")
+	}
+	if dstInfo, err := e.fs.Stat(to); err == nil && dstInfo.IsDir() && !strings.EqualFold(from, to) {
+		to = filepath.Join(to, filepath.Base(from))
+	}
+	if to == from {
+		return "", "", false, fmt.Errorf("移動元と移動先が同じです: %s", e.Rel(from))
+	}
+	// 大文字小文字だけを変更する場合は、大文字小文字を区別しないファイルシステムで移動先が存在するように見える
+	if _, err := e.fs.Stat(to); err == nil && !strings.EqualFold(from, to) {
+		return "", "", false, fmt.Errorf("移動先が既に存在します: %s", e.Rel(to))
+	}
+	if strings.HasPrefix(to, from+string(filepath.Separator)) {
+		return "", "", false, fmt.Errorf("ディレクトリをその中に移動することはできません: %s → %s", e.Rel(from), e.Rel(to))
+	}
+	if rel := e.Rel(to); rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", "", false, fmt.Errorf("プロジェクトの外には移動できません: %s", to)
+	}
+	return from, to, info.IsDir(), nil
+}
+
+// 絶対パスまたはプロジェクトルートからの相対パスを絶対パスに変換する
+func (e *Engine) pathArg(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return e.abs(filepath.Clean(filepath.FromSlash(path)))
+}
+
+// 移動元と移動先の絶対パス
+type movePath struct {
+	from, to string
+	// 他のワークスペースから移動先を参照する指定子（"@kit/ui/app-logo" など）
+	// 空でない場合、この指定子で移動先に解決できるインポートは、書き方に関係なくこの指定子に書き換える
+	specifier string
+}
+
+// paths の移動を同時に行い、参照を書き換える（移動するファイルの間の参照も、移動後の位置で書き換える）
+func (e *Engine) move(result *MoveResult, paths []movePath) error {
+	// path を含む移動（ない場合は nil）
+	moveOf := func(path string) *movePath {
+		for i, p := range paths {
+			if path == p.from || strings.HasPrefix(path, p.from+string(filepath.Separator)) {
+				return &paths[i]
+			}
+		}
+		return nil
+	}
+	moved := func(path string) bool {
+		return moveOf(path) != nil
+	}
+	mapPath := func(path string) string {
+		p := moveOf(path)
+		if p == nil {
+			return path
+		}
+		return p.to + path[len(p.from):]
+	}
+
+	// 移動前の状態で、移動するファイルに関係する指定子を解決しておく
+	before, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return err
+	}
+	imports, err := e.movedImports(before, moved)
+	if err != nil {
+		return err
+	}
+	entries := packageEntries(before, moved)
+
+	for _, p := range paths {
+		info, err := e.fs.Stat(p.from)
+		if err != nil {
+			return fmt.Errorf("移動元が見つかりません: %s", e.Rel(p.from))
+		}
+		if info.IsDir() {
+			_ = Walk(e.fs, p.from, func(path string, info os.FileInfo, err error) error {
+				if err == nil && !info.IsDir() {
+					result.Files++
+				}
+				return nil
+			})
+		} else {
+			result.Files++
+		}
+
+		e.printf("%s → %s\n", e.Rel(p.from), e.Rel(p.to))
+		if err := e.fs.MkdirAll(filepath.Dir(p.to), 0755); err != nil {
+			return fmt.Errorf("%s の作成に失敗しました: %w", e.Rel(filepath.Dir(p.to)), err)
+		}
+		if info.IsDir() || strings.EqualFold(p.from, p.to) {
+			err = renameDirectory(e.fs, p.from, p.to)
+		} else {
+			err = e.fs.Rename(p.from, p.to)
+		}
+		if err != nil {
+			return fmt.Errorf("%s の移動に失敗しました: %w", e.Rel(p.from), err)
+		}
+	}
+	e.InvalidateIndex()
+	if err := e.rewritePackageEntries(result, entries, mapPath); err != nil {
+		return err
+	}
+
+	// 移動後の状態で、解決できなくなった指定子を書き換える
+	after, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return err
+	}
+	edits := make(map[string][]specifierEdit)
+	for _, imp := range imports {
+		file, target := mapPath(imp.file), mapPath(imp.target)
+		if res := after.Resolve(file, imp.ref.Specifier); res.Status == ResolveOK && res.Path == target {
+			continue
+		}
+		spec, kind, ok := after.specifierFor(file, target, specifierFormOf(imp.ref.Specifier, imp.target), imp.kind)
+		if p := moveOf(imp.target); p != nil && p.specifier != "" && after.WorkspaceOf(file) != after.WorkspaceOf(target) {
+			if res := after.Resolve(file, p.specifier); res.Status == ResolveOK && res.Path == target {
+				spec, kind, ok = p.specifier, ResolveKindWorkspace, true
+			}
+		}
+		if spec == "" {
+			// ワークスペースをまたぐ相対パスには書き換えない
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s:%d: '%s'（%s をパッケージ名やエイリアスで参照できないため書き換えていません。package.json の dependencies と exports を確認してください）",
+				e.Rel(file), imp.ref.Line, imp.ref.Specifier, e.Rel(target)))
+			continue
+		}
+		rewrite := SpecifierRewrite{File: e.Rel(file), Line: imp.ref.Line, From: imp.ref.Specifier, To: spec, Kind: kind}
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s（解決できる指定子が見つからないため、相対パスに書き換えます）", rewrite))
+		}
+		edits[file] = append(edits[file], specifierEdit{ref: imp.ref, replacement: spec})
+		result.Rewrites = append(result.Rewrites, rewrite)
+	}
+
+	files := make([]string, 0, len(edits))
+	for file := range edits {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if err := e.editSpecifiers(file, edits[file]); err != nil {
+			return err
+		}
+	}
+	sort.SliceStable(result.Rewrites, func(i, j int) bool {
+		if result.Rewrites[i].File != result.Rewrites[j].File {
+			return result.Rewrites[i].File < result.Rewrites[j].File
+		}
+		return result.Rewrites[i].Line < result.Rewrites[j].Line
+	})
+	return nil
+}
+
+// プロジェクト内のソースファイルの指定子のうち、移動するファイルの中にあるものと移動するファイルを指すもの
+func (e *Engine) movedImports(resolver *Resolver, moved func(string) bool) ([]movedImport, error) {
+	files, err := e.findSourceFiles(e.root)
+	if err != nil {
+		return nil, fmt.Errorf("ファイル一覧の取得に失敗しました: %w", err)
+	}
+	var imports []movedImport
+	for _, file := range files {
+		refs, err := e.Imports(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+		}
+		for _, ref := range refs {
+			res := resolver.Resolve(file, ref.Specifier)
+			if res.Status != ResolveOK || res.Path == "" {
+				continue
+			}
+			if moved(file) || moved(res.Path) {
+				imports = append(imports, movedImport{file: file, target: res.Path, ref: ref, kind: res.Kind})
+			}
+		}
+	}
+	return imports, nil
+}
+
+// 指定子の書き換え
+type specifierEdit struct {
+	// 書き換える指定子（移動前に読み込んだ位置）
+	ref ImportRef
+	// 書き換え後の指定子
+	replacement string
+}
+
+// ファイルの指定子を書き換える
+// 後ろから置き換えて、前にある指定子のオフセットがずれないようにする
+func (e *Engine) editSpecifiers(file string, edits []specifierEdit) error {
+	content, err := e.fs.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].ref.Offset > edits[j].ref.Offset })
+	for _, edit := range edits {
+		var ok bool
+		if content, ok = replaceSpecifier(content, edit.ref, edit.replacement); !ok {
+			e.debugf("%s:%d: '%s' が見つからないためスキップします\n", e.Rel(file), edit.ref.Line, edit.ref.Specifier)
+		}
+	}
+	if err := e.fs.WriteFile(file, content, 0644); err != nil {
+		return fmt.Errorf("%s の書き込みに失敗しました: %w", e.Rel(file), err)
+	}
+	return nil
+}
+
+// ref の位置にある指定子を replacement に置き換える（指定子が記録された位置にない場合は false）
+func replaceSpecifier(content []byte, ref ImportRef, replacement string) ([]byte, bool) {
+	end := ref.End()
+	if ref.Offset < 0 || end > len(content) || string(content[ref.Offset:end]) != ref.Specifier {
+		return content, false
+	}
+	updated := make([]byte, 0, len(content)-len(ref.Specifier)+len(replacement))
+	updated = append(updated, content[:ref.Offset]...)
+	updated = append(updated, replacement...)
+	return append(updated, content[end:]...), true
+}
+
+// 移動するファイルを指す package.json のエントリ
+type packageEntry struct {
+	ws *Workspace
+	// エントリの値（"./src/index.ts" など）と、それが指すファイルの絶対パス（移動前）
+	value, target string
+}
+
+// ワークスペースの main / types / module / exports のうち、移動するファイルを指すもの（ワイルドカードを除く）
+func packageEntries(resolver *Resolver, moved func(string) bool) []packageEntry {
+	var entries []packageEntry
+	for _, ws := range resolver.Workspaces() {
+		values := []string{ws.Main, ws.Types, ws.Module}
+		for _, targets := range ws.Exports {
+			values = append(values, targets...)
+		}
+		seen := make(map[string]bool)
+		for _, value := range values {
+			if value == "" || strings.Contains(value, "*") || seen[value] {
+				continue
+			}
+			seen[value] = true
+			if target := filepath.Join(ws.Path, filepath.FromSlash(value)); moved(target) {
+				entries = append(entries, packageEntry{ws: ws, value: value, target: target})
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].target < entries[j].target })
+	return entries
+}
+
+// 移動したファイルを指す package.json のエントリを移動先に書き換える
+// 移動先がワークスペースの外の場合は書き換えずに警告します
+func (e *Engine) rewritePackageEntries(result *MoveResult, entries []packageEntry, mapPath func(string) string) error {
+	byFile := make(map[string][]packageEntry)
+	var files []string
+	for _, entry := range entries {
+		path := filepath.Join(entry.ws.Path, "package.json")
+		if _, ok := byFile[path]; !ok {
+			files = append(files, path)
+		}
+		byFile[path] = append(byFile[path], entry)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		content, err := e.fs.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+		}
+		text := string(content)
+		for _, entry := range byFile[file] {
+			rel, err := filepath.Rel(entry.ws.Path, mapPath(entry.target))
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: '%s' の移動先がワークスペースの外のため、書き換えていません", e.Rel(file), entry.value))
+				continue
+			}
+			value := "./" + filepath.ToSlash(rel)
+			text = strings.ReplaceAll(text, `"`+entry.value+`"`, `"`+value+`"`)
+			result.PackageEntries = append(result.PackageEntries, SpecifierRewrite{File: e.Rel(file), From: entry.value, To: value})
+		}
+		if text == string(content) {
+			continue
+		}
+		if err := e.fs.WriteFile(file, []byte(text), 0644); err != nil {
+			return fmt.Errorf("%s の書き込みに失敗しました: %w", e.Rel(file), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MoveTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *MoveTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/app/page.tsx":                  "import { Button } from '~/components/Button';\nimport { Card } from '~/components/user-card';\n",
+		"apps/web/app/layout.tsx":                "import { PageHeader } from '@kit/ui/page-header';\n",
+		"apps/web/lib/utils.ts":                  "import { Button } from '../components/Button';\n",
+		"apps/web/components/Button.tsx":         "import { cn } from '../lib/utils';\nimport type { Props } from './user-card';\n",
+		"apps/web/components/user-card/index.ts": "import { Button } from '../Button';\n",
+	})
+}
+
+func (s *MoveTestSuite) newEngine(opts Options) *Engine {
+	opts.FS = s.fs
+	engine, err := NewEngine(s.root, opts)
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *MoveTestSuite) move(src, dst string) *MoveResult {
+	result, _, err := s.newEngine(Options{}).Move(src, dst)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *MoveTestSuite) rewrites(result *MoveResult) []string {
+	var rewrites []string
+	for _, rewrite := range result.Rewrites {
+		rewrites = append(rewrites, rewrite.String())
+	}
+	return rewrites
+}
+
+func (s *MoveTestSuite) read(path string) string {
+	content, err := s.fs.ReadFile(filepath.Join(s.root, filepath.FromSlash(path)))
+	s.Require().NoError(err)
+	return string(content)
+}
+
+func (s *MoveTestSuite) exists(path string) bool {
+	return exists(s.fs, filepath.Join(s.root, filepath.FromSlash(path)))
+}
+
+// 同じワークスペースの中でファイルを移動する
+func (s *MoveTestSuite) TestMoveFile() {
+	result := s.move("apps/web/components/Button.tsx", "apps/web/components/ui/button.tsx")
+
+	s.Equal("apps/web/components/Button.tsx", result.From)
+	s.Equal("apps/web/components/ui/button.tsx", result.To)
+	s.Equal(1, result.Files)
+	s.False(s.exists("apps/web/components/Button.tsx"))
+	s.Equal([]string{
+		"apps/web/app/page.tsx:1: '~/components/Button' → '~/components/ui/button'",
+		"apps/web/components/ui/button.tsx:1: '../lib/utils' → '../../lib/utils'",
+		"apps/web/components/ui/button.tsx:2: './user-card' → '../user-card'",
+		"apps/web/components/user-card/index.ts:1: '../Button' → '../ui/button'",
+		"apps/web/lib/utils.ts:1: '../components/Button' → '../components/ui/button'",
+	}, s.rewrites(result), "エイリアスと相対パスの書き方を引き継ぎ、index の省略も維持する")
+	s.Empty(result.Warnings)
+
+	s.Equal("import { Button } from '~/components/ui/button';\nimport { Card } from '~/components/user-card';\n", s.read("apps/web/app/page.tsx"))
+	s.Equal("import { cn } from '../../lib/utils';\nimport type { Props } from '../user-card';\n", s.read("apps/web/components/ui/button.tsx"))
+}
+
+// 既存のディレクトリを指定した場合はその中に移動する
+func (s *MoveTestSuite) TestMoveIntoDirectory() {
+	result := s.move("apps/web/lib/utils.ts", "apps/web/components")
+
+	s.Equal("apps/web/components/utils.ts", result.To)
+	s.Equal([]string{
+		"apps/web/components/Button.tsx:1: '../lib/utils' → './utils'",
+	}, s.rewrites(result), "移動後も解決できる指定子（'../components/Button'）は書き換えない")
+}
+
+// 大文字小文字だけを変更する
+func (s *MoveTestSuite) TestMoveCaseOnly() {
+	result := s.move("apps/web/components/Button.tsx", "apps/web/components/button.tsx")
+
+	s.True(s.exists("apps/web/components/button.tsx"))
+	s.Equal([]string{
+		"apps/web/app/page.tsx:1: '~/components/Button' → '~/components/button'",
+		"apps/web/components/user-card/index.ts:1: '../Button' → '../button'",
+		"apps/web/lib/utils.ts:1: '../components/Button' → '../components/button'",
+	}, s.rewrites(result), "移動したファイルの中の相対パスは解決できるため書き換えない")
+}
+
+// ディレクトリを移動する
+func (s *MoveTestSuite) TestMoveDirectory() {
+	result := s.move("apps/web/components/user-card", "apps/web/widgets/user-card")
+
+	s.True(result.IsDir)
+	s.Equal(1, result.Files)
+	s.True(s.exists("apps/web/widgets/user-card/index.ts"))
+	s.Equal([]string{
+		"apps/web/app/page.tsx:2: '~/components/user-card' → '../widgets/user-card'",
+		"apps/web/components/Button.tsx:2: './user-card' → '../widgets/user-card'",
+		"apps/web/widgets/user-card/index.ts:1: '../Button' → '../../components/Button'",
+	}, s.rewrites(result), "エイリアスで参照できない場合は相対パスにする")
+}
+
+// 別のワークスペースに移動する
+func (s *MoveTestSuite) TestMoveAcrossWorkspaces() {
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"packages/ui/package.json": `{
+  "name": "@kit/ui",
+  "exports": {
+    "./page-header": "./src/custom/page-header/index.tsx",
+    "./custom/*": "./src/custom/*.tsx"
+  }
+}`,
+	})
+	result := s.move("apps/web/components/Button.tsx", "packages/ui/src/custom/button.tsx")
+
+	s.Equal([]string{
+		"apps/web/app/page.tsx:1: '~/components/Button' → '@kit/ui/custom/button'",
+		"apps/web/components/user-card/index.ts:1: '../Button' → '@kit/ui/custom/button'",
+		"apps/web/lib/utils.ts:1: '../components/Button' → '@kit/ui/custom/button'",
+	}, s.rewrites(result), "依存しているパッケージは exports のサブパスで参照する")
+	s.Equal("workspace", result.Rewrites[0].Kind)
+	s.Len(result.Warnings, 2, "パッケージ名で参照できないワークスペースをまたぐ参照は相対パスに書き換えず、警告する")
+	s.Contains(result.Warnings[0], "packages/ui/src/custom/button.tsx:1: '../lib/utils'（apps/web/lib/utils.ts をパッケージ名やエイリアスで参照できないため書き換えていません")
+	s.Contains(s.read("packages/ui/src/custom/button.tsx"), "'../lib/utils'")
+}
+
+// package.json のエントリを書き換える
+func (s *MoveTestSuite) TestMovePackageEntries() {
+	result := s.move("packages/ui/src/custom/page-header", "packages/ui/src/custom/header")
+
+	s.Empty(result.Rewrites, "パッケージ名での参照は書き換えない")
+	s.Equal([]SpecifierRewrite{
+		{File: "packages/ui/package.json", From: "./src/custom/page-header/index.tsx", To: "./src/custom/header/index.tsx"},
+	}, result.PackageEntries)
+	s.Contains(s.read("packages/ui/package.json"), `"./page-header": "./src/custom/header/index.tsx"`)
+
+	resolver, err := NewResolver(s.fs, s.root)
+	s.Require().NoError(err)
+	res := resolver.Resolve(filepath.Join(s.root, "apps/web/app/layout.tsx"), "@kit/ui/page-header")
+	s.Equal(ResolveOK, res.Status)
+	s.Equal(filepath.Join(s.root, "packages/ui/src/custom/header/index.tsx"), res.Path)
+}
+
+func (s *MoveTestSuite) TestMoveErrors() {
+	engine := s.newEngine(Options{})
+	for _, tc := range []struct{ src, dst, message string }{
+		{"apps/web/components/Missing.tsx", "apps/web/components/ui/missing.tsx", "移動元がない"},
+		{"apps/web/components/Button.tsx", "apps/web/lib/utils.ts", "移動先が既に存在する"},
+		{"apps/web/components", "apps/web/components/ui", "ディレクトリをその中に移動する"},
+		{"apps/web/components/Button.tsx", "../outside/Button.tsx", "プロジェクトの外に移動する"},
+	} {
+		_, _, err := engine.Move(tc.src, tc.dst)
+		s.Error(err, tc.message)
+	}
+	s.True(s.exists("apps/web/components/Button.tsx"))
+}
+
+func (s *MoveTestSuite) TestDryRunAndRollback() {
+	engine := s.newEngine(Options{DryRun: true})
+	_, run, err := engine.Move("apps/web/components/Button.tsx", "apps/web/components/ui/button.tsx")
+	s.Require().NoError(err)
+	s.True(s.exists("apps/web/components/Button.tsx"), "ドライランではファイルを変更しない")
+	s.Equal("import { Button } from '../components/Button';\n", s.read("apps/web/lib/utils.ts"))
+	s.True(exists(engine.View(run).FS(), filepath.Join(s.root, "apps/web/components/ui/button.tsx")))
+
+	engine = s.newEngine(Options{})
+	_, run, err = engine.Move("apps/web/components/Button.tsx", "apps/web/components/ui/button.tsx")
+	s.Require().NoError(err)
+	s.Require().NoError(engine.Rollback(run))
+	s.True(s.exists("apps/web/components/Button.tsx"))
+	s.False(s.exists("apps/web/components/ui/button.tsx"))
+	s.Equal("import { Button } from '../components/Button';\n", s.read("apps/web/lib/utils.ts"))
+}
+
+func TestMoveSuite(t *testing.T) {
+	suite.Run(t, new(MoveTestSuite))
+}
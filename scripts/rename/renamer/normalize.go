@@ -0,0 +1,131 @@
+package renamer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// インポートの書き方の統一の方向
+const (
+	// 深い相対パスをエイリアスに書き換える
+	NormalizeToAlias = "alias"
+	// エイリアスを相対パスに書き換える
+	NormalizeToRelative = "relative"
+)
+
+// NormalizeOptions はインポートの書き方の統一の設定です
+type NormalizeOptions struct {
+	// 書き換えの方向（NormalizeToAlias / NormalizeToRelative）
+	To string
+	// 相対パスで許容する親ディレクトリ（"../"）の数
+	// alias ではこれより深い相対パスを、relative では相対パスにしてもこれ以下になるエイリアスを書き換える
+	MaxDepth int
+	// 同じディレクトリ以下のファイルを指すエイリアスを相対パス（"./"）に書き換える
+	SameDirRelative bool
+}
+
+// NormalizeResult は NormalizeImports による書き換えの結果です
+type NormalizeResult struct {
+	// 検査したファイルの数
+	Files int `json:"files"`
+	// 書き換えたモジュール指定子（Kind は書き換え後の解決方法、Rule は書き換えた理由）
+	Rewrites []SpecifierRewrite `json:"rewrites"`
+}
+
+// NormalizeImports は dirs（空の場合はプロジェクト全体）の相対パスとエイリアスの指定子を、同じファイルに解決できるものだけ opts に従って書き換えます
+func (e *Engine) NormalizeImports(opts NormalizeOptions, dirs []string) (*NormalizeResult, *Run, error) {
+	if opts.To != NormalizeToAlias && opts.To != NormalizeToRelative {
+		return nil, nil, fmt.Errorf("書き換えの方向は %s または %s を指定してください: %s", NormalizeToAlias, NormalizeToRelative, opts.To)
+	}
+	if opts.MaxDepth < 0 {
+		return nil, nil, fmt.Errorf("親ディレクトリの数は 0 以上を指定してください: %d", opts.MaxDepth)
+	}
+	files, err := e.sourceFilesIn(dirs)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &NormalizeResult{Files: len(files), Rewrites: []SpecifierRewrite{}}
+	run, err := e.Execute("normalize-imports", func(worker *Engine) error {
+		for _, file := range files {
+			refs, err := worker.Imports(file)
+			if err != nil {
+				return fmt.Errorf("%s の読み込みに失敗しました: %w", worker.Rel(file), err)
+			}
+			var edits []specifierEdit
+			for _, ref := range refs {
+				spec, kind, reason, ok := resolver.normalizedSpecifier(file, ref.Specifier, opts)
+				if !ok {
+					continue
+				}
+				edits = append(edits, specifierEdit{ref: ref, replacement: spec})
+				result.Rewrites = append(result.Rewrites, SpecifierRewrite{File: worker.Rel(file), Line: ref.Line, From: ref.Specifier, To: spec, Kind: kind, Rule: reason})
+			}
+			if len(edits) == 0 {
+				continue
+			}
+			if err := worker.editSpecifiers(file, edits); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result, run, err
+}
+
+// spec を opts に従って書き換えた指定子と、その解決方法・書き換えた理由を返す（書き換えない場合は false）
+func (r *Resolver) normalizedSpecifier(fromFile, spec string, opts NormalizeOptions) (string, string, string, bool) {
+	res := r.Resolve(fromFile, spec)
+	if res.Status != ResolveOK || res.Path == "" {
+		return "", "", "", false
+	}
+	form := specifierFormOf(spec, res.Path)
+	modulePath := form.modulePath(res.Path)
+	resolves := func(candidate string) bool {
+		target := r.Resolve(fromFile, candidate+form.query)
+		return target.Status == ResolveOK && target.Path == res.Path
+	}
+
+	switch res.Kind {
+	case ResolveKindRelative:
+		if opts.To != NormalizeToAlias || parentDepth(spec) <= opts.MaxDepth {
+			return "", "", "", false
+		}
+		for _, alias := range r.aliasSpecifiers(fromFile, modulePath) {
+			if resolves(alias) {
+				return alias + form.query, ResolveKindAlias, fmt.Sprintf("親ディレクトリが %d 階層を超える相対パス", opts.MaxDepth), true
+			}
+		}
+	case ResolveKindAlias:
+		relative := relativeSpecifier(fromFile, modulePath)
+		if relative == "" || r.WorkspaceOf(fromFile) != r.WorkspaceOf(res.Path) || !resolves(relative) {
+			return "", "", "", false
+		}
+		switch {
+		case opts.SameDirRelative && parentDepth(relative) == 0:
+			return relative + form.query, ResolveKindRelative, "同じディレクトリ以下を指すエイリアス", true
+		case opts.To == NormalizeToRelative && parentDepth(relative) <= opts.MaxDepth:
+			return relative + form.query, ResolveKindRelative, fmt.Sprintf("親ディレクトリが %d 階層以下になるエイリアス", opts.MaxDepth), true
+		}
+	}
+	return "", "", "", false
+}
+
+// 相対パスの指定子の先頭にある親ディレクトリ（".."）の数
+func parentDepth(spec string) int {
+	depth := 0
+	for _, segment := range strings.Split(spec, "/") {
+		switch segment {
+		case "..":
+			depth++
+		case ".":
+		default:
+			return depth
+		}
+	}
+	return depth
+}
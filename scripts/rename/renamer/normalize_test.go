@@ -0,0 +1,114 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NormalizeTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *NormalizeTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/app/dashboard/settings/page.tsx": "import { Button } from '../../../components/Button';\n" +
+			"import { Card } from '../../../components/user-card/index';\n" +
+			"import RootLayout from '../../layout';\n" +
+			"import { cn } from '../../../../../packages/ui/src/lib/utils';\n",
+		"apps/web/app/page.tsx":          "import { Button } from '~/components/Button';\nimport { cn } from 'lib/utils';\n",
+		"apps/web/components/Button.tsx": "import type { Props } from '~/components/user-card';\n",
+	})
+}
+
+func (s *NormalizeTestSuite) normalize(opts NormalizeOptions, dirs ...string) *NormalizeResult {
+	engine, err := NewEngine(s.root, Options{FS: s.fs})
+	s.Require().NoError(err)
+	result, _, err := engine.NormalizeImports(opts, dirs)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *NormalizeTestSuite) rewrites(result *NormalizeResult) []string {
+	var rewrites []string
+	for _, rewrite := range result.Rewrites {
+		rewrites = append(rewrites, rewrite.String())
+	}
+	return rewrites
+}
+
+func (s *NormalizeTestSuite) read(path string) string {
+	content, err := s.fs.ReadFile(filepath.Join(s.root, filepath.FromSlash(path)))
+	s.Require().NoError(err)
+	return string(content)
+}
+
+// 深い相対パスをエイリアスに書き換える
+func (s *NormalizeTestSuite) TestToAlias() {
+	result := s.normalize(NormalizeOptions{To: NormalizeToAlias, MaxDepth: 2})
+	s.Equal([]string{
+		"apps/web/app/dashboard/settings/page.tsx:1: '../../../components/Button' → '~/components/Button'",
+		"apps/web/app/dashboard/settings/page.tsx:2: '../../../components/user-card/index' → '~/components/user-card/index'",
+	}, s.rewrites(result), "制限以下の相対パスと、エイリアスで参照できないワークスペースの外の相対パスは書き換えない")
+	s.Equal(ResolveKindAlias, result.Rewrites[0].Kind)
+	s.Contains(s.read("apps/web/app/dashboard/settings/page.tsx"), "import RootLayout from '../../layout';\n")
+
+	s.Len(s.normalize(NormalizeOptions{To: NormalizeToAlias, MaxDepth: 1}).Rewrites, 1, "書き換え済みの指定子は対象外になり、../../layout だけを書き換える")
+	s.Contains(s.read("apps/web/app/dashboard/settings/page.tsx"), "import RootLayout from '~/layout';\n")
+}
+
+// エイリアスを相対パスに書き換える
+func (s *NormalizeTestSuite) TestToRelative() {
+	result := s.normalize(NormalizeOptions{To: NormalizeToRelative, MaxDepth: 1}, "apps/web/app/page.tsx", "apps/web/components")
+	s.Equal([]string{
+		"apps/web/app/page.tsx:1: '~/components/Button' → '../components/Button'",
+		"apps/web/components/Button.tsx:1: '~/components/user-card' → './user-card'",
+	}, s.rewrites(result), "baseUrl の指定子は書き換えない")
+
+	s.Empty(s.normalize(NormalizeOptions{To: NormalizeToRelative, MaxDepth: 2}, "apps/web/app/dashboard").Rewrites)
+}
+
+// 同じディレクトリ以下を指すエイリアスを相対パスに書き換える
+func (s *NormalizeTestSuite) TestSameDirRelative() {
+	result := s.normalize(NormalizeOptions{To: NormalizeToAlias, MaxDepth: 2, SameDirRelative: true}, "apps/web/components", "apps/web/app/page.tsx")
+	s.Equal([]string{"apps/web/components/Button.tsx:1: '~/components/user-card' → './user-card'"}, s.rewrites(result))
+	s.Equal("import type { Props } from './user-card';\n", s.read("apps/web/components/Button.tsx"))
+}
+
+func (s *NormalizeTestSuite) TestDryRun() {
+	engine, err := NewEngine(s.root, Options{FS: s.fs})
+	s.Require().NoError(err)
+	engine.SetDryRun(true)
+	result, run, err := engine.NormalizeImports(NormalizeOptions{To: NormalizeToAlias, MaxDepth: 2}, nil)
+	s.Require().NoError(err)
+	s.Len(result.Rewrites, 2)
+	s.NotNil(run.Overlay)
+	s.Contains(s.read("apps/web/app/dashboard/settings/page.tsx"), "'../../../components/Button'")
+}
+
+func (s *NormalizeTestSuite) TestInvalidOptions() {
+	engine, err := NewEngine(s.root, Options{FS: s.fs})
+	s.Require().NoError(err)
+	_, _, err = engine.NormalizeImports(NormalizeOptions{To: "package"}, nil)
+	s.Error(err)
+	_, _, err = engine.NormalizeImports(NormalizeOptions{To: NormalizeToAlias, MaxDepth: -1}, nil)
+	s.Error(err)
+}
+
+func (s *NormalizeTestSuite) TestParentDepth() {
+	s.Equal(0, parentDepth("./Button"))
+	s.Equal(0, parentDepth("."))
+	s.Equal(1, parentDepth(".."))
+	s.Equal(3, parentDepth("../../../components/Button"))
+	s.Equal(0, parentDepth("~/components/Button"))
+}
+
+func TestNormalizeSuite(t *testing.T) {
+	suite.Run(t, new(NormalizeTestSuite))
+}
@@ -0,0 +1,264 @@
+package renamer
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OverlayFS は下層の FS を読み取り専用として扱い、変更をすべてメモリ上に保持する FS の実装です
+// ドライランでは実際の変換処理をこの FS に対して実行することで、下層を変更せずに結果を確認できます
+type OverlayFS struct {
+	base  FS
+	upper *MemFS
+
+	mu sync.RWMutex
+	// 下層から削除（または移動）されたパス。配下のパスも含めて下層のエントリを隠す
+	whiteouts map[string]bool
+}
+
+// NewOverlayFS は base の上に変更を重ねる OverlayFS を作成します
+func NewOverlayFS(base FS) *OverlayFS {
+	return &OverlayFS{
+		base:      base,
+		upper:     NewMemFS(),
+		whiteouts: make(map[string]bool),
+	}
+}
+
+// Base は下層の FS を返します
+func (o *OverlayFS) Base() FS {
+	return o.base
+}
+
+func (o *OverlayFS) ReadFile(name string) ([]byte, error) {
+	name = filepath.Clean(name)
+	if data, err := o.upper.ReadFile(name); err == nil || !isNotExist(err) {
+		return data, err
+	}
+	if o.hidden(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return o.base.ReadFile(name)
+}
+
+func (o *OverlayFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	name = filepath.Clean(name)
+	parent, err := o.Stat(filepath.Dir(name))
+	if err != nil || !parent.IsDir() {
+		return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if info, err := o.Stat(name); err == nil && info.IsDir() {
+		return &fs.PathError{Op: "open", Path: name, Err: errIsDir}
+	}
+	if err := o.upper.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	return o.upper.WriteFile(name, data, perm)
+}
+
+func (o *OverlayFS) Rename(oldpath, newpath string) error {
+	oldpath, newpath = filepath.Clean(oldpath), filepath.Clean(newpath)
+	info, err := o.Stat(oldpath)
+	if err != nil {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrNotExist}
+	}
+	if oldpath == newpath {
+		return nil
+	}
+	if parent, err := o.Stat(filepath.Dir(newpath)); err != nil || !parent.IsDir() {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrNotExist}
+	}
+	if target, err := o.Stat(newpath); err == nil {
+		if target.IsDir() {
+			entries, _ := o.ReadDir(newpath)
+			if len(entries) > 0 {
+				return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrExist}
+			}
+		}
+		o.removeTree(newpath)
+	}
+
+	// 移動元の内容（ディレクトリの場合は配下すべて）を上層に写してから移動元を隠す
+	if err := o.copyTree(oldpath, newpath, info); err != nil {
+		return err
+	}
+	o.removeTree(oldpath)
+	return nil
+}
+
+func (o *OverlayFS) Stat(name string) (fs.FileInfo, error) {
+	name = filepath.Clean(name)
+	if info, err := o.upper.Stat(name); err == nil {
+		return info, nil
+	}
+	if o.hidden(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return o.base.Stat(name)
+}
+
+func (o *OverlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	name = filepath.Clean(name)
+	info, err := o.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errNotDir}
+	}
+
+	merged := make(map[string]fs.DirEntry)
+	if !o.hidden(name) {
+		if baseEntries, err := o.base.ReadDir(name); err == nil {
+			for _, entry := range baseEntries {
+				if !o.hidden(filepath.Join(name, entry.Name())) {
+					merged[entry.Name()] = entry
+				}
+			}
+		}
+	}
+	if upperEntries, err := o.upper.ReadDir(name); err == nil {
+		for _, entry := range upperEntries {
+			merged[entry.Name()] = entry
+		}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(merged))
+	for _, entry := range merged {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (o *OverlayFS) MkdirAll(path string, perm fs.FileMode) error {
+	path = filepath.Clean(path)
+	if info, err := o.Stat(path); err == nil {
+		if !info.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: path, Err: errNotDir}
+		}
+		return nil
+	}
+	return o.upper.MkdirAll(path, perm)
+}
+
+func (o *OverlayFS) Remove(name string) error {
+	name = filepath.Clean(name)
+	info, err := o.Stat(name)
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if info.IsDir() {
+		if entries, _ := o.ReadDir(name); len(entries) > 0 {
+			return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrExist}
+		}
+	}
+	o.removeTree(name)
+	return nil
+}
+
+// ChangedFiles は上層に書き込まれたファイルの一覧を返します
+func (o *OverlayFS) ChangedFiles() []string {
+	var files []string
+	Walk(o.upper, string(filepath.Separator), func(path string, info fs.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
+
+// RemovedPaths は下層から削除（または移動）されたパスの一覧を返します
+func (o *OverlayFS) RemovedPaths() []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	paths := make([]string, 0, len(o.whiteouts))
+	for path := range o.whiteouts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// パス自身または祖先が下層から隠されているかどうか
+func (o *OverlayFS) hidden(name string) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if len(o.whiteouts) == 0 {
+		return false
+	}
+	for p := name; ; p = filepath.Dir(p) {
+		if o.whiteouts[p] {
+			return true
+		}
+		if filepath.Dir(p) == p {
+			return false
+		}
+	}
+}
+
+// 上層のエントリを削除し、下層のエントリを隠す
+func (o *OverlayFS) removeTree(name string) {
+	if _, err := o.upper.Stat(name); err == nil {
+		o.upper.mu.Lock()
+		o.upper.removeTree(name)
+		o.upper.mu.Unlock()
+	}
+
+	// 下層に存在しないパスは隠す必要がない
+	if o.hidden(name) {
+		return
+	}
+	if _, err := o.base.Stat(name); err != nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	// 配下の既存のホワイトアウトは不要になる
+	for path := range o.whiteouts {
+		if strings.HasPrefix(path, name+string(filepath.Separator)) {
+			delete(o.whiteouts, path)
+		}
+	}
+	o.whiteouts[name] = true
+}
+
+// from の内容を to として上層に複製する
+func (o *OverlayFS) copyTree(from, to string, info fs.FileInfo) error {
+	if !info.IsDir() {
+		data, err := o.ReadFile(from)
+		if err != nil {
+			return err
+		}
+		if err := o.upper.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			return err
+		}
+		return o.upper.WriteFile(to, data, info.Mode().Perm())
+	}
+
+	if err := o.upper.MkdirAll(to, info.Mode().Perm()); err != nil {
+		return err
+	}
+	entries, err := o.ReadDir(from)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		childInfo, err := o.Stat(filepath.Join(from, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err := o.copyTree(filepath.Join(from, entry.Name()), filepath.Join(to, entry.Name()), childInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
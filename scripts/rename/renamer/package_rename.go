@@ -0,0 +1,194 @@
+package renamer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// PackageRenameResult は RenamePackage によるパッケージ名の変更の結果です
+// パスはすべてプロジェクトルートからの相対パス（スラッシュ区切り）です
+type PackageRenameResult struct {
+	// 変更前と変更後のパッケージ名
+	From string `json:"from"`
+	To   string `json:"to"`
+	// 名前を変更したワークスペースのディレクトリ
+	Workspace string `json:"workspace"`
+	// 書き換えた package.json の name と依存（Kind は name / dependencies など）
+	Manifests []SpecifierRewrite `json:"manifests"`
+	// 書き換えた tsconfig の extends や paths（Kind は JSON の位置）
+	TSConfigs []SpecifierRewrite `json:"tsconfigs"`
+	// 書き換えたモジュール指定子
+	Imports []SpecifierRewrite `json:"imports"`
+	// インポート以外で変更前の名前を参照している箇所（書き換えていないもの、"ファイル:行" の形式）
+	Remaining []string `json:"remaining,omitempty"`
+}
+
+// npm のパッケージ名（スコープ付きを含む）
+var packageNameRegex = regexp.MustCompile(`^(@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+
+// パッケージ名をキーとする package.json の項目
+var dependencyFields = []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies", "peerDependenciesMeta"}
+
+// RenamePackage はワークスペースのパッケージ名を oldName から newName に変更し、package.json・tsconfig・モジュール指定子の参照を書き換えます
+func (e *Engine) RenamePackage(oldName, newName string) (*PackageRenameResult, *Run, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, nil, err
+	}
+	ws := resolver.WorkspaceByName(oldName)
+	if ws == nil {
+		return nil, nil, fmt.Errorf("ワークスペースのパッケージが見つかりません: %s", oldName)
+	}
+	if !packageNameRegex.MatchString(newName) {
+		return nil, nil, fmt.Errorf("パッケージ名として使用できません: %s", newName)
+	}
+	if newName == oldName {
+		return nil, nil, fmt.Errorf("変更前と変更後のパッケージ名が同じです: %s", oldName)
+	}
+	if other := resolver.WorkspaceByName(newName); other != nil {
+		return nil, nil, fmt.Errorf("%s は %s のパッケージ名として既に使用されています", newName, other.Dir)
+	}
+
+	manifests := []string{filepath.Join(e.root, "package.json")}
+	for _, w := range resolver.Workspaces() {
+		manifests = append(manifests, filepath.Join(w.Path, "package.json"))
+	}
+	slices.Sort(manifests)
+	manifests = slices.Compact(manifests)
+	tsconfigs, err := e.findTSConfigs()
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := e.sourceFilesIn(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	ownManifest := filepath.Join(ws.Path, "package.json")
+	renamed := func(value string) (string, bool) {
+		if value == oldName {
+			return newName, true
+		}
+		if rest, ok := strings.CutPrefix(value, oldName+"/"); ok {
+			return newName + "/" + rest, true
+		}
+		return "", false
+	}
+
+	result := &PackageRenameResult{From: oldName, To: newName, Workspace: ws.Dir, Manifests: []SpecifierRewrite{}, TSConfigs: []SpecifierRewrite{}, Imports: []SpecifierRewrite{}}
+	run, err := e.Execute("rename-package", func(worker *Engine) error {
+		for _, manifest := range manifests {
+			rewrites, err := worker.editJSONStrings(manifest, func(s jsonString) (string, string, bool) {
+				switch {
+				case manifest == ownManifest && !s.key && slices.Equal(s.path, []string{"name"}) && s.value == oldName:
+					return newName, "name", true
+				case s.key && len(s.path) == 1 && slices.Contains(dependencyFields, s.path[0]) && s.value == oldName:
+					return newName, s.path[0], true
+				}
+				return "", "", false
+			})
+			if err != nil {
+				return err
+			}
+			result.Manifests = append(result.Manifests, rewrites...)
+		}
+
+		for _, tsconfig := range tsconfigs {
+			rewrites, err := worker.editJSONStrings(tsconfig, func(s jsonString) (string, string, bool) {
+				replacement, ok := renamed(s.value)
+				return replacement, jsonPath(s.path), ok
+			})
+			if err != nil {
+				return err
+			}
+			result.TSConfigs = append(result.TSConfigs, rewrites...)
+		}
+
+		mapping := &ImportMapping{Rules: []ImportMappingRule{{Prefix: oldName, To: newName}}}
+		var err error
+		if result.Imports, _, err = worker.remapSpecifiers(mapping, files); err != nil {
+			return err
+		}
+		result.Remaining, err = worker.packageMentions(files, oldName)
+		return err
+	})
+	return result, run, err
+}
+
+// プロジェクト内の tsconfig*.json
+func (e *Engine) findTSConfigs() ([]string, error) {
+	var files []string
+	err := e.walkIndexed(e.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != e.root && (isExcludedDir(info.Name()) || contains(e.opts.ExcludeDirectories, info.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if name := info.Name(); strings.HasPrefix(name, "tsconfig") && strings.HasSuffix(name, ".json") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tsconfig の検索に失敗しました: %w", err)
+	}
+	return files, nil
+}
+
+// JSON ファイルの文字列リテラルのうち replace が書き換えを返すものを書き換え、書き換えた内容を返す
+// ファイルが存在しない場合は何もしません
+func (e *Engine) editJSONStrings(file string, replace func(s jsonString) (replacement, kind string, ok bool)) ([]SpecifierRewrite, error) {
+	content, err := e.fs.ReadFile(file)
+	if err != nil {
+		if isNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+	}
+	var edits []jsonEdit
+	var rewrites []SpecifierRewrite
+	for _, s := range scanJSONStrings(content) {
+		replacement, kind, ok := replace(s)
+		if !ok || replacement == s.value {
+			continue
+		}
+		edits = append(edits, jsonEdit{str: s, replacement: replacement})
+		rewrites = append(rewrites, SpecifierRewrite{File: e.Rel(file), Line: s.line, From: s.value, To: replacement, Kind: kind})
+	}
+	if len(edits) == 0 {
+		return nil, nil
+	}
+	if err := e.fs.WriteFile(file, applyJSONEdits(content, edits), 0644); err != nil {
+		return nil, fmt.Errorf("%s の書き込みに失敗しました: %w", e.Rel(file), err)
+	}
+	return rewrites, nil
+}
+
+// ソースファイルのうち、インポート以外の文字列でパッケージ名（またはそのサブパス）を参照している箇所
+// （next.config の transpilePackages など、"ファイル:行" の形式）
+func (e *Engine) packageMentions(files []string, name string) ([]string, error) {
+	pattern := regexp.MustCompile(`['"` + "`" + `]` + regexp.QuoteMeta(name) + `['"` + "`" + `/]`)
+	var mentions []string
+	for _, file := range files {
+		content, err := e.fs.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+		}
+		matches := pattern.FindAllIndex(content, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		starts := lineStarts(content)
+		for _, match := range matches {
+			mentions = append(mentions, fmt.Sprintf("%s:%d", e.Rel(file), lineAt(starts, match[0])))
+		}
+	}
+	return mentions, nil
+}
@@ -0,0 +1,150 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PackageRenameTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *PackageRenameTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"package.json": `{"name": "root", "workspaces": ["apps/*", "packages/*", "packages/features/*", "tooling/*"]}`,
+
+		"apps/web/package.json": `{
+  "name": "web",
+  "dependencies": {
+    "@kit/features": "workspace:*",
+    "@kit/ui": "workspace:*"
+  },
+  "devDependencies": {"@kit/tsconfig": "workspace:*"}
+}`,
+		"apps/web/app/page.tsx": "import { Login } from '@kit/features';\n" +
+			"import { Form } from '@kit/features/form';\n" +
+			"import { Extra } from '@kit/features-extra';\n" +
+			"const name = '@kit/features';\n",
+		"apps/web/next.config.mjs": "export default { transpilePackages: ['@kit/features', '@kit/ui'] };\n",
+
+		"packages/features/auth/package.json": `{
+  "name": "@kit/features",
+  "exports": {".": "./src/index.ts", "./form": "./src/form.tsx"},
+  "peerDependencies": {"@kit/ui": "workspace:*"}
+}`,
+		"packages/features/auth/tsconfig.json": `{
+  // パッケージのパスのエイリアス
+  "extends": "@kit/tsconfig/base.json",
+  "compilerOptions": {"paths": {"@kit/features/*": ["./src/*"]}},
+}`,
+		"packages/features/auth/src/index.ts":  "export * from '@kit/features/form';\n",
+		"packages/features/auth/src/form.tsx":  "",
+		"packages/features/extra/package.json": `{"name": "@kit/features-extra", "main": "./index.ts"}`,
+		"packages/features/extra/index.ts":     "",
+	})
+}
+
+func (s *PackageRenameTestSuite) read(path string) string {
+	content, err := s.fs.ReadFile(filepath.Join(s.root, filepath.FromSlash(path)))
+	s.Require().NoError(err)
+	return string(content)
+}
+
+func (s *PackageRenameTestSuite) rename(oldName, newName string, opts Options) (*Engine, *PackageRenameResult, *Run) {
+	opts.FS = s.fs
+	engine, err := NewEngine(s.root, opts)
+	s.Require().NoError(err)
+	result, run, err := engine.RenamePackage(oldName, newName)
+	s.Require().NoError(err)
+	return engine, result, run
+}
+
+func rewriteStrings(rewrites []SpecifierRewrite) []string {
+	var result []string
+	for _, rewrite := range rewrites {
+		result = append(result, rewrite.String()+" "+rewrite.Kind)
+	}
+	return result
+}
+
+func (s *PackageRenameTestSuite) TestRenamePackage() {
+	_, result, _ := s.rename("@kit/features", "@kit/feature-auth", Options{})
+
+	s.Equal("packages/features/auth", result.Workspace)
+	s.Equal([]string{
+		"apps/web/package.json:4: '@kit/features' → '@kit/feature-auth' dependencies",
+		"packages/features/auth/package.json:2: '@kit/features' → '@kit/feature-auth' name",
+	}, rewriteStrings(result.Manifests))
+	s.Equal([]string{
+		"packages/features/auth/tsconfig.json:4: '@kit/features/*' → '@kit/feature-auth/*' compilerOptions.paths",
+	}, rewriteStrings(result.TSConfigs))
+	s.Equal([]string{
+		"apps/web/app/page.tsx:1: '@kit/features' → '@kit/feature-auth' ",
+		"apps/web/app/page.tsx:2: '@kit/features/form' → '@kit/feature-auth/form' ",
+		"packages/features/auth/src/index.ts:1: '@kit/features/form' → '@kit/feature-auth/form' ",
+	}, rewriteStrings(result.Imports), "別のパッケージ（@kit/features-extra）は書き換えない")
+	s.Equal([]string{"apps/web/app/page.tsx:4", "apps/web/next.config.mjs:1"}, result.Remaining)
+
+	s.Contains(s.read("packages/features/auth/package.json"), `"name": "@kit/feature-auth",`)
+	s.Contains(s.read("apps/web/package.json"), `"@kit/feature-auth": "workspace:*",`)
+
+	resolver, err := NewResolver(s.fs, s.root)
+	s.Require().NoError(err)
+	res := resolver.Resolve(filepath.Join(s.root, "apps/web/app/page.tsx"), "@kit/feature-auth/form")
+	s.Equal(ResolveOK, res.Status)
+	s.Equal(filepath.Join(s.root, "packages/features/auth/src/form.tsx"), res.Path)
+}
+
+// tsconfig の extends で参照されているパッケージの名前を変更する
+func (s *PackageRenameTestSuite) TestRenameTSConfigPackage() {
+	_, result, _ := s.rename("@kit/tsconfig", "@kit/typescript-config", Options{})
+
+	s.Equal([]string{
+		"apps/web/package.json:7: '@kit/tsconfig' → '@kit/typescript-config' devDependencies",
+		"tooling/typescript/package.json:1: '@kit/tsconfig' → '@kit/typescript-config' name",
+	}, rewriteStrings(result.Manifests))
+	s.Equal([]string{
+		"apps/web/tsconfig.json:3: '@kit/tsconfig/base.json' → '@kit/typescript-config/base.json' extends",
+		"packages/features/auth/tsconfig.json:3: '@kit/tsconfig/base.json' → '@kit/typescript-config/base.json' extends",
+	}, rewriteStrings(result.TSConfigs))
+	s.Contains(s.read("packages/features/auth/tsconfig.json"), "// パッケージのパスのエイリアス", "コメントは残す")
+}
+
+func (s *PackageRenameTestSuite) TestDryRunAndRollback() {
+	engine, result, run := s.rename("@kit/features", "@kit/feature-auth", Options{DryRun: true})
+	s.Len(result.Imports, 3)
+	s.Contains(s.read("packages/features/auth/package.json"), `"name": "@kit/features",`, "ドライランではファイルを変更しない")
+	content, err := engine.View(run).FS().ReadFile(filepath.Join(s.root, "packages/features/auth/package.json"))
+	s.Require().NoError(err)
+	s.Contains(string(content), `"name": "@kit/feature-auth",`)
+
+	engine, _, run = s.rename("@kit/features", "@kit/feature-auth", Options{})
+	s.Require().NoError(engine.Rollback(run))
+	s.Contains(s.read("packages/features/auth/package.json"), `"name": "@kit/features",`)
+	s.Contains(s.read("apps/web/app/page.tsx"), "import { Login } from '@kit/features';\n")
+}
+
+func (s *PackageRenameTestSuite) TestErrors() {
+	engine, err := NewEngine(s.root, Options{FS: s.fs})
+	s.Require().NoError(err)
+	for _, tc := range []struct{ oldName, newName, message string }{
+		{"@kit/missing", "@kit/other", "ワークスペースのパッケージがない"},
+		{"@kit/features", "@kit/features-extra", "既に使用されている名前"},
+		{"@kit/features", "@Kit/Features", "パッケージ名として使用できない"},
+		{"@kit/features", "@kit/features", "同じ名前"},
+	} {
+		_, _, err := engine.RenamePackage(tc.oldName, tc.newName)
+		s.Error(err, tc.message)
+	}
+}
+
+func TestPackageRenameSuite(t *testing.T) {
+	suite.Run(t, new(PackageRenameTestSuite))
+}
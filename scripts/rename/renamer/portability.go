@@ -0,0 +1,301 @@
+package renamer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// 移植性の問題の種類
+const (
+	// 同じディレクトリに大文字小文字だけが異なる名前がある
+	PortabilityCaseCollision = "case-collision"
+	// Windows の予約名（con, aux, nul など）
+	PortabilityReservedName = "reserved-name"
+	// 名前の末尾がドットまたは空白
+	PortabilityTrailingDotOrSpace = "trailing-dot-or-space"
+	// Windows で使用できない文字を含む
+	PortabilityIllegalCharacter = "illegal-character"
+	// 名前が長すぎる
+	PortabilityNameTooLong = "name-too-long"
+	// パスが長すぎる
+	PortabilityPathTooLong = "path-too-long"
+)
+
+const (
+	// パスの長さの上限の既定値（プロジェクトルートからの相対パスの文字数）
+	// Windows の MAX_PATH（260）からクローン先のディレクトリの分を差し引いた値
+	DefaultMaxPathLength = 200
+	// ファイル名の長さの上限の既定値（バイト数）
+	DefaultMaxNameLength = 255
+)
+
+// Windows の予約名（拡張子を除いた部分で判定する）
+var windowsReservedNames = []string{
+	"con", "prn", "aux", "nul",
+	"com1", "com2", "com3", "com4", "com5", "com6", "com7", "com8", "com9",
+	"lpt1", "lpt2", "lpt3", "lpt4", "lpt5", "lpt6", "lpt7", "lpt8", "lpt9",
+}
+
+// Windows のファイル名に使用できない文字（制御文字を除く）
+const windowsIllegalCharacters = `<>:"/\|?*`
+
+// PortabilityIssue は OS によって作成・チェックアウトできない可能性のある名前です
+type PortabilityIssue struct {
+	// プロジェクトルートからの相対パス（スラッシュ区切り）
+	Path string `json:"path"`
+	// 問題の種類（PortabilityCaseCollision など）
+	Kind string `json:"kind"`
+	// 問題の詳細
+	Detail string `json:"detail"`
+	// 変換計画によって新たに生じる問題かどうか
+	Planned bool `json:"planned,omitempty"`
+}
+
+// String は "パス: 内容" の形式で問題を表します
+func (i PortabilityIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Detail)
+}
+
+// PortabilityError は移植性の問題があるために変換計画の実行を拒否したことを表します
+type PortabilityError struct {
+	Issues []PortabilityIssue
+}
+
+func (e *PortabilityError) Error() string {
+	return fmt.Sprintf("変換計画に移植性の問題が %d 件あるため実行を中止しました", len(e.Issues))
+}
+
+// パスの長さの上限
+func (e *Engine) maxPathLength() int {
+	if e.opts.MaxPathLength > 0 {
+		return e.opts.MaxPathLength
+	}
+	return DefaultMaxPathLength
+}
+
+// ファイル名の長さの上限
+func (e *Engine) maxNameLength() int {
+	if e.opts.MaxNameLength > 0 {
+		return e.opts.MaxNameLength
+	}
+	return DefaultMaxNameLength
+}
+
+// AuditPortability は dirs（プロジェクトルートからの相対パス）配下の既存のファイルとディレクトリの名前を検査します
+func (e *Engine) AuditPortability(dirs []string) ([]PortabilityIssue, error) {
+	var issues []PortabilityIssue
+	for _, dir := range e.removeChildDirectories(dirs) {
+		rootDir := e.abs(dir)
+		err := e.walkIndexed(rootDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				// 対象ディレクトリが存在しない場合は空とみなす
+				if path == rootDir && isNotExist(err) {
+					return filepath.SkipAll
+				}
+				return err
+			}
+			if path != rootDir {
+				issues = append(issues, e.checkName(path)...)
+			}
+			if !info.IsDir() {
+				return nil
+			}
+			if path != rootDir && (isExcludedDir(info.Name()) || contains(e.opts.ExcludeDirectories, info.Name())) {
+				return filepath.SkipDir
+			}
+
+			entries, err := e.fs.ReadDir(path)
+			if err != nil {
+				return err
+			}
+			names := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			issues = append(issues, e.caseCollisions(path, names, nil)...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s の検査に失敗しました: %w", dir, err)
+		}
+	}
+	sortPortabilityIssues(issues)
+	return issues, nil
+}
+
+// AuditPlan は変換計画を実行した場合に新たに生じる移植性の問題を返します
+// リネーム後の名前と、同じディレクトリにある既存の名前との大文字小文字の衝突、
+// リネームしたディレクトリ配下のパスの長さを検査します
+func (e *Engine) AuditPlan(plan *Plan) ([]PortabilityIssue, error) {
+	if plan == nil {
+		return nil, nil
+	}
+
+	// 親ディレクトリごとのリネーム前後の名前
+	removed := make(map[string]map[string]bool)
+	added := make(map[string]map[string]bool)
+	var parents []string
+	var issues []PortabilityIssue
+	for _, dirPlan := range plan.Dirs {
+		for _, rename := range dirPlan.Renames {
+			parent := filepath.Dir(rename.NewPath)
+			if removed[parent] == nil {
+				removed[parent] = make(map[string]bool)
+				added[parent] = make(map[string]bool)
+				parents = append(parents, parent)
+			}
+			if filepath.Dir(rename.OldPath) == parent {
+				removed[parent][filepath.Base(rename.OldPath)] = true
+			}
+			added[parent][filepath.Base(rename.NewPath)] = true
+
+			for _, issue := range e.checkName(rename.NewPath) {
+				issue.Planned = true
+				issues = append(issues, issue)
+			}
+			if rename.IsDir {
+				descendants, err := e.checkDescendantPaths(rename.OldPath, rename.NewPath)
+				if err != nil {
+					return nil, err
+				}
+				issues = append(issues, descendants...)
+			}
+		}
+	}
+
+	for _, parent := range parents {
+		entries, err := e.fs.ReadDir(parent)
+		if err != nil && !isNotExist(err) {
+			return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(parent), err)
+		}
+		var names []string
+		for _, entry := range entries {
+			if !removed[parent][entry.Name()] {
+				names = append(names, entry.Name())
+			}
+		}
+		for name := range added[parent] {
+			if !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		issues = append(issues, e.caseCollisions(parent, names, added[parent])...)
+	}
+	sortPortabilityIssues(issues)
+	return issues, nil
+}
+
+// 名前とパスの長さを検査する
+func (e *Engine) checkName(path string) []PortabilityIssue {
+	name := filepath.Base(path)
+	rel := e.Rel(path)
+	var issues []PortabilityIssue
+	add := func(kind, detail string) {
+		issues = append(issues, PortabilityIssue{Path: rel, Kind: kind, Detail: detail})
+	}
+
+	stem := strings.ToLower(name)
+	if i := strings.Index(stem, "."); i >= 0 {
+		stem = stem[:i]
+	}
+	if contains(windowsReservedNames, strings.TrimRight(stem, " ")) {
+		add(PortabilityReservedName, fmt.Sprintf("'%s' は Windows の予約名です", name))
+	}
+	if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+		add(PortabilityTrailingDotOrSpace, "名前の末尾がドットまたは空白です（Windows では作成できません）")
+	}
+	for _, r := range name {
+		if r < 0x20 || strings.ContainsRune(windowsIllegalCharacters, r) {
+			add(PortabilityIllegalCharacter, fmt.Sprintf("使用できない文字 %q が含まれています", r))
+			break
+		}
+	}
+	if len(name) > e.maxNameLength() {
+		add(PortabilityNameTooLong, fmt.Sprintf("名前が長すぎます（%d バイト、上限 %d）", len(name), e.maxNameLength()))
+	}
+	if length := utf8.RuneCountInString(rel); length > e.maxPathLength() {
+		add(PortabilityPathTooLong, fmt.Sprintf("パスが長すぎます（%d 文字、上限 %d）", length, e.maxPathLength()))
+	}
+	return issues
+}
+
+// ディレクトリのリネーム後に、配下のパスが長さの上限を超えないか検査する
+func (e *Engine) checkDescendantPaths(oldDir, newDir string) ([]PortabilityIssue, error) {
+	var issues []PortabilityIssue
+	err := e.walkIndexed(oldDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == oldDir && isNotExist(err) {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if path == oldDir {
+			return nil
+		}
+		rel, err := filepath.Rel(oldDir, path)
+		if err != nil {
+			return err
+		}
+		newRel := e.Rel(filepath.Join(newDir, rel))
+		if length := utf8.RuneCountInString(newRel); length > e.maxPathLength() {
+			issues = append(issues, PortabilityIssue{
+				Path:    newRel,
+				Kind:    PortabilityPathTooLong,
+				Detail:  fmt.Sprintf("パスが長すぎます（%d 文字、上限 %d）", length, e.maxPathLength()),
+				Planned: true,
+			})
+		}
+		return nil
+	})
+	return issues, err
+}
+
+// 同じディレクトリにある大文字小文字だけが異なる名前を検出する
+// planned が指定された場合は、その名前を含む衝突だけを計画による問題として返す
+func (e *Engine) caseCollisions(dir string, names []string, planned map[string]bool) []PortabilityIssue {
+	groups := make(map[string][]string)
+	for _, name := range names {
+		key := strings.ToLower(name)
+		groups[key] = append(groups[key], name)
+	}
+
+	var issues []PortabilityIssue
+	for _, group := range groups {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Strings(group)
+		for _, name := range group {
+			if planned != nil && !planned[name] {
+				continue
+			}
+			var others []string
+			for _, other := range group {
+				if other != name {
+					others = append(others, other)
+				}
+			}
+			issues = append(issues, PortabilityIssue{
+				Path:    e.Rel(filepath.Join(dir, name)),
+				Kind:    PortabilityCaseCollision,
+				Detail:  fmt.Sprintf("大文字小文字だけが異なる名前があります（%s）", strings.Join(others, ", ")),
+				Planned: planned != nil,
+			})
+		}
+	}
+	return issues
+}
+
+// パス・種類の順に並べる
+func sortPortabilityIssues(issues []PortabilityIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Path != issues[j].Path {
+			return issues[i].Path < issues[j].Path
+		}
+		return issues[i].Kind < issues[j].Kind
+	})
+}
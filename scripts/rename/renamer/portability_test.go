@@ -0,0 +1,127 @@
+package renamer
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PortabilityTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *PortabilityTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/components/Button.tsx":          "",
+		"apps/web/components/card.tsx":            "",
+		"apps/web/components/UserCard/index.tsx":  "",
+		"apps/web/components/UserCard/Avatar.tsx": "",
+		"apps/web/components/Header.tsx":          "",
+		"apps/web/components/header.tsx":          "",
+		"apps/web/lib/aux.ts":                     "",
+		"apps/web/lib/notes.":                     "",
+		"apps/web/lib/a:b.ts":                     "",
+		"apps/web/node_modules/con/index.js":      "",
+	})
+}
+
+func (s *PortabilityTestSuite) newEngine(opts Options) *Engine {
+	opts.FS = s.fs
+	engine, err := NewEngine(s.root, opts)
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *PortabilityTestSuite) path(rel string) string {
+	return filepath.Join(s.root, filepath.FromSlash(rel))
+}
+
+func (s *PortabilityTestSuite) kinds(issues []PortabilityIssue) []string {
+	var kinds []string
+	for _, issue := range issues {
+		kinds = append(kinds, issue.Path+"="+issue.Kind)
+	}
+	return kinds
+}
+
+func (s *PortabilityTestSuite) TestAuditPortability() {
+	issues, err := s.newEngine(Options{}).AuditPortability([]string{"apps/web"})
+	s.Require().NoError(err)
+	s.Equal([]string{
+		"apps/web/components/Header.tsx=case-collision",
+		"apps/web/components/header.tsx=case-collision",
+		"apps/web/lib/a:b.ts=illegal-character",
+		"apps/web/lib/aux.ts=reserved-name",
+		"apps/web/lib/notes.=trailing-dot-or-space",
+	}, s.kinds(issues), "node_modules は検査しない")
+	s.Equal("apps/web/components/Header.tsx: 大文字小文字だけが異なる名前があります（header.tsx）", issues[0].String())
+	s.False(issues[0].Planned)
+}
+
+func (s *PortabilityTestSuite) TestAuditPortabilityLengthLimits() {
+	issues, err := s.newEngine(Options{MaxPathLength: 36, MaxNameLength: 9}).AuditPortability([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	s.Contains(s.kinds(issues), "apps/web/components/UserCard/Avatar.tsx=path-too-long")
+	s.Contains(s.kinds(issues), "apps/web/components/Button.tsx=name-too-long")
+	s.NotContains(s.kinds(issues), "apps/web/components/card.tsx=name-too-long")
+}
+
+func (s *PortabilityTestSuite) TestAuditPlan() {
+	engine := s.newEngine(Options{MaxPathLength: 48})
+	plan := &Plan{
+		ConversionDirection: DirectionCamelToKebab,
+		Dirs: []DirPlan{{
+			TargetDir: "apps/web/components",
+			Renames: []ConversionResult{
+				// 大文字小文字のみのリネームは衝突しない
+				{OldPath: s.path("apps/web/components/Button.tsx"), NewPath: s.path("apps/web/components/button.tsx")},
+				// 既存の card.tsx と衝突する
+				{OldPath: s.path("apps/web/components/Button.tsx"), NewPath: s.path("apps/web/components/Card.tsx")},
+				// 予約名
+				{OldPath: s.path("apps/web/lib/a:b.ts"), NewPath: s.path("apps/web/lib/nul.ts")},
+				// 配下のパスが長くなる
+				{OldPath: s.path("apps/web/components/UserCard"), NewPath: s.path("apps/web/components/user-card-with-long-name"), IsDir: true},
+			},
+		}},
+	}
+
+	issues, err := engine.AuditPlan(plan)
+	s.Require().NoError(err)
+	s.Equal([]string{
+		"apps/web/components/Card.tsx=case-collision",
+		"apps/web/components/user-card-with-long-name/Avatar.tsx=path-too-long",
+		"apps/web/components/user-card-with-long-name/index.tsx=path-too-long",
+		"apps/web/lib/nul.ts=reserved-name",
+	}, s.kinds(issues), "既存の問題（Header.tsx と header.tsx など）は含めない")
+	for _, issue := range issues {
+		s.True(issue.Planned)
+	}
+}
+
+func (s *PortabilityTestSuite) TestApplyBlocksUnportablePlan() {
+	// NavBar.tsx → nav-bar.tsx は既存の Nav-Bar.tsx と衝突する
+	s.Require().NoError(s.fs.WriteFile(s.path("apps/web/components/NavBar.tsx"), nil, 0644))
+	s.Require().NoError(s.fs.WriteFile(s.path("apps/web/components/Nav-Bar.tsx"), nil, 0644))
+	engine := s.newEngine(Options{BlockUnportable: true, ConversionDirection: DirectionCamelToKebab})
+	plan, err := engine.Plan([]string{"apps/web/components"})
+	s.Require().NoError(err)
+
+	_, err = engine.Apply(plan)
+	var portabilityErr *PortabilityError
+	s.Require().True(errors.As(err, &portabilityErr), "エラー: %v", err)
+	s.Equal([]string{"apps/web/components/nav-bar.tsx=case-collision"}, s.kinds(portabilityErr.Issues))
+
+	// 何も変更されない
+	s.True(exists(s.fs, s.path("apps/web/components/Button.tsx")))
+	s.True(exists(s.fs, s.path("apps/web/components/UserCard/index.tsx")))
+}
+
+func TestPortabilitySuite(t *testing.T) {
+	suite.Run(t, new(PortabilityTestSuite))
+}
@@ -0,0 +1,193 @@
+package renamer
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ImportMapping はモジュール指定子の書き換えの規則です（rewrite-imports）
+type ImportMapping struct {
+	Rules []ImportMappingRule `yaml:"rewrites"`
+}
+
+// ImportMappingRule は 1 件の書き換えの規則です
+// from（完全一致）・prefix（前方一致）・regex（正規表現）のいずれか 1 つを指定します
+type ImportMappingRule struct {
+	// 完全に一致する指定子を to に書き換える
+	From string `yaml:"from"`
+	// 前方一致する指定子の一致した部分を to に書き換える（"/" で終わらない場合はパスの区切りで一致するものだけ）
+	Prefix string `yaml:"prefix"`
+	// 一致する指定子を to に書き換える（to には $1 などのグループを使用できる）
+	Regex string `yaml:"regex"`
+	To    string `yaml:"to"`
+
+	regex *regexp.Regexp
+}
+
+// String は規則を "'from' → 'to'"・"prefix 'prefix' → 'to'"・"regex 'regex' → 'to'" の形式で表します
+func (r ImportMappingRule) String() string {
+	switch {
+	case r.Prefix != "":
+		return fmt.Sprintf("prefix '%s' → '%s'", r.Prefix, r.To)
+	case r.Regex != "":
+		return fmt.Sprintf("regex '%s' → '%s'", r.Regex, r.To)
+	}
+	return fmt.Sprintf("'%s' → '%s'", r.From, r.To)
+}
+
+// apply は spec が規則に一致する場合、書き換え後の指定子を返します
+func (r *ImportMappingRule) apply(spec string) (string, bool) {
+	switch {
+	case r.Prefix != "":
+		rest, ok := strings.CutPrefix(spec, r.Prefix)
+		if ok && (rest == "" || strings.HasSuffix(r.Prefix, "/") || strings.HasPrefix(rest, "/")) {
+			return r.To + rest, true
+		}
+	case r.regex != nil:
+		if r.regex.MatchString(spec) {
+			return r.regex.ReplaceAllString(spec, r.To), true
+		}
+	case spec == r.From:
+		return r.To, true
+	}
+	return "", false
+}
+
+// LoadImportMapping は書き換えの規則ファイル（YAML または JSON）を読み込みます
+func LoadImportMapping(fsys FS, mappingPath string) (*ImportMapping, error) {
+	data, err := fsys.ReadFile(mappingPath)
+	if err != nil {
+		return nil, fmt.Errorf("書き換えの規則の読み込みに失敗しました: %w", err)
+	}
+	var mapping ImportMapping
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("書き換えの規則の解析に失敗しました: %w", err)
+	}
+	if len(mapping.Rules) == 0 {
+		return nil, fmt.Errorf("書き換えの規則がありません: %s", mappingPath)
+	}
+	for i := range mapping.Rules {
+		rule := &mapping.Rules[i]
+		specified := 0
+		for _, pattern := range []string{rule.From, rule.Prefix, rule.Regex} {
+			if pattern != "" {
+				specified++
+			}
+		}
+		if specified != 1 {
+			return nil, fmt.Errorf("書き換えの規則 %d 番目には from / prefix / regex のいずれか 1 つを指定してください", i+1)
+		}
+		if rule.Regex != "" {
+			if rule.regex, err = regexp.Compile(rule.Regex); err != nil {
+				return nil, fmt.Errorf("書き換えの規則 %d 番目の正規表現が不正です: %w", i+1, err)
+			}
+		}
+	}
+	return &mapping, nil
+}
+
+// Apply は spec に最初に一致した規則で書き換えた指定子と、その規則の番号を返します（一致しない場合は false）
+func (m *ImportMapping) Apply(spec string) (string, int, bool) {
+	for i := range m.Rules {
+		if replacement, ok := m.Rules[i].apply(spec); ok {
+			return replacement, i, true
+		}
+	}
+	return "", -1, false
+}
+
+// RemapResult は RewriteSpecifiers による書き換えの結果です
+type RemapResult struct {
+	// 検査したファイルの数
+	Files int `json:"files"`
+	// 書き換えたモジュール指定子
+	Rewrites []SpecifierRewrite `json:"rewrites"`
+	// どの指定子にも一致しなかった規則
+	UnusedRules []string `json:"unusedRules,omitempty"`
+}
+
+// RewriteSpecifiers は dirs（空の場合はプロジェクト全体）のモジュール指定子を、それぞれ最初に一致した mapping の規則で書き換えます
+func (e *Engine) RewriteSpecifiers(mapping *ImportMapping, dirs []string) (*RemapResult, *Run, error) {
+	files, err := e.sourceFilesIn(dirs)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &RemapResult{Files: len(files), Rewrites: []SpecifierRewrite{}}
+	var used []bool
+	run, err := e.Execute("rewrite-imports", func(worker *Engine) error {
+		var err error
+		result.Rewrites, used, err = worker.remapSpecifiers(mapping, files)
+		return err
+	})
+	for i, rule := range mapping.Rules {
+		if i < len(used) && !used[i] {
+			result.UnusedRules = append(result.UnusedRules, rule.String())
+		}
+	}
+	return result, run, err
+}
+
+// dirs（プロジェクトルートからの相対パス、空の場合はプロジェクト全体）の変換対象のソースファイル
+func (e *Engine) sourceFilesIn(dirs []string) ([]string, error) {
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	var files []string
+	for _, dir := range dirs {
+		found, err := e.findSourceFiles(e.abs(filepath.FromSlash(dir)))
+		if err != nil {
+			return nil, fmt.Errorf("ファイル一覧の取得に失敗しました: %w", err)
+		}
+		for _, file := range found {
+			if e.selectedFile(file) {
+				files = append(files, file)
+			}
+		}
+	}
+	// ディレクトリが重なっている場合に同じファイルを 2 回書き換えないようにする
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+// files のモジュール指定子を mapping の規則で書き換え、書き換えた指定子と規則ごとに一致したかどうかを返す
+func (e *Engine) remapSpecifiers(mapping *ImportMapping, files []string) ([]SpecifierRewrite, []bool, error) {
+	rewrites := []SpecifierRewrite{}
+	used := make([]bool, len(mapping.Rules))
+	for _, file := range files {
+		refs, err := e.Imports(file)
+		if err != nil {
+			return rewrites, used, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+		}
+		var edits []specifierEdit
+		for _, ref := range refs {
+			replacement, rule, ok := mapping.Apply(ref.Specifier)
+			if !ok {
+				continue
+			}
+			used[rule] = true
+			if replacement == ref.Specifier {
+				continue
+			}
+			edits = append(edits, specifierEdit{ref: ref, replacement: replacement})
+			rewrites = append(rewrites, SpecifierRewrite{
+				File: e.Rel(file),
+				Line: ref.Line,
+				From: ref.Specifier,
+				To:   replacement,
+				Rule: mapping.Rules[rule].String(),
+			})
+		}
+		if len(edits) == 0 {
+			continue
+		}
+		if err := e.editSpecifiers(file, edits); err != nil {
+			return rewrites, used, err
+		}
+	}
+	return rewrites, used, nil
+}
@@ -0,0 +1,156 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RemapTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *RemapTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/app/page.tsx": "import { Header } from '@kit/ui/old-header';\n" +
+			"import { Button } from '~/legacy/button';\n" +
+			"import { Older } from '~/legacy-old/button';\n" +
+			"export { Dialog } from '@kit/ui/dialog-v2';\n" +
+			"const Chart = await import('@kit/ui/chart-v2');\n",
+		"apps/web/lib/utils.ts":        "import { Header } from '@kit/ui/old-header';\n",
+		"packages/ui/src/lib/utils.ts": "const { cn } = require('~/legacy');\n",
+	})
+}
+
+func (s *RemapTestSuite) mapping(yaml string) *ImportMapping {
+	path := filepath.Join(s.root, "mapping.yaml")
+	s.Require().NoError(s.fs.WriteFile(path, []byte(yaml), 0o644))
+	mapping, err := LoadImportMapping(s.fs, path)
+	s.Require().NoError(err)
+	return mapping
+}
+
+func (s *RemapTestSuite) read(path string) string {
+	content, err := s.fs.ReadFile(filepath.Join(s.root, filepath.FromSlash(path)))
+	s.Require().NoError(err)
+	return string(content)
+}
+
+func (s *RemapTestSuite) TestLoadImportMapping() {
+	_, err := LoadImportMapping(s.fs, filepath.Join(s.root, "missing.yaml"))
+	s.Error(err, "規則ファイルがない場合はエラー")
+
+	path := filepath.Join(s.root, "invalid.yaml")
+	for _, tc := range []struct{ yaml, message string }{
+		{"rewrites: []\n", "規則がない"},
+		{"rewrites:\n  - to: \"@kit/ui\"\n", "from / prefix / regex がない"},
+		{"rewrites:\n  - from: \"a\"\n    prefix: \"b\"\n    to: \"c\"\n", "from と prefix を両方指定している"},
+		{"rewrites:\n  - regex: \"([\"\n    to: \"c\"\n", "不正な正規表現"},
+	} {
+		s.Require().NoError(s.fs.WriteFile(path, []byte(tc.yaml), 0o644))
+		_, err = LoadImportMapping(s.fs, path)
+		s.Error(err, tc.message)
+	}
+
+	s.Require().NoError(s.fs.WriteFile(path, []byte(`{"rewrites": [{"from": "a", "to": "b"}]}`), 0o644))
+	mapping, err := LoadImportMapping(s.fs, path)
+	s.Require().NoError(err, "JSON も読み込める")
+	s.Len(mapping.Rules, 1)
+}
+
+func (s *RemapTestSuite) TestApply() {
+	mapping := s.mapping(`
+rewrites:
+  - from: "@kit/ui/old-header"
+    to: "@kit/ui/page-header"
+  - prefix: "~/legacy"
+    to: "~/components"
+  - regex: "^@kit/ui/(.*)-v2$"
+    to: "@kit/ui/$1"
+  - prefix: "@kit/ui/"
+    to: "@acme/ui/"
+`)
+	for _, tc := range []struct{ spec, expected string }{
+		{"@kit/ui/old-header", "@kit/ui/page-header"},
+		{"~/legacy", "~/components"},
+		{"~/legacy/button", "~/components/button"},
+		{"@kit/ui/dialog-v2", "@kit/ui/dialog"},
+		{"@kit/ui/card", "@acme/ui/card"},
+	} {
+		replacement, _, ok := mapping.Apply(tc.spec)
+		s.True(ok, tc.spec)
+		s.Equal(tc.expected, replacement, tc.spec)
+	}
+
+	_, _, ok := mapping.Apply("~/legacy-old/button")
+	s.False(ok, "\"/\" で終わらない prefix はパスの区切りでだけ一致する")
+	replacement, rule, _ := mapping.Apply("@kit/ui/old-header/index")
+	s.Equal("@acme/ui/old-header/index", replacement, "from は完全一致のため、次に一致した規則を適用する")
+	s.Equal(3, rule)
+}
+
+func (s *RemapTestSuite) TestRewriteSpecifiers() {
+	mapping := s.mapping(`
+rewrites:
+  - from: "@kit/ui/old-header"
+    to: "@kit/ui/page-header"
+  - prefix: "~/legacy"
+    to: "~/components"
+  - regex: "^@kit/ui/(.*)-v2$"
+    to: "@kit/ui/$1"
+  - from: "@kit/unused"
+    to: "@kit/shared"
+`)
+	engine, err := NewEngine(s.root, Options{FS: s.fs})
+	s.Require().NoError(err)
+	result, run, err := engine.RewriteSpecifiers(mapping, nil)
+	s.Require().NoError(err)
+
+	var rewrites []string
+	for _, rewrite := range result.Rewrites {
+		rewrites = append(rewrites, rewrite.String())
+	}
+	s.Equal(3, result.Files)
+	s.Equal([]string{
+		"apps/web/app/page.tsx:1: '@kit/ui/old-header' → '@kit/ui/page-header'",
+		"apps/web/app/page.tsx:2: '~/legacy/button' → '~/components/button'",
+		"apps/web/app/page.tsx:4: '@kit/ui/dialog-v2' → '@kit/ui/dialog'",
+		"apps/web/app/page.tsx:5: '@kit/ui/chart-v2' → '@kit/ui/chart'",
+		"apps/web/lib/utils.ts:1: '@kit/ui/old-header' → '@kit/ui/page-header'",
+		"packages/ui/src/lib/utils.ts:1: '~/legacy' → '~/components'",
+	}, rewrites, "export from・動的インポート・require も書き換える")
+	s.Equal([]string{"'@kit/unused' → '@kit/shared'"}, result.UnusedRules)
+
+	s.Equal("import { Header } from '@kit/ui/page-header';\n"+
+		"import { Button } from '~/components/button';\n"+
+		"import { Older } from '~/legacy-old/button';\n"+
+		"export { Dialog } from '@kit/ui/dialog';\n"+
+		"const Chart = await import('@kit/ui/chart');\n", s.read("apps/web/app/page.tsx"))
+
+	s.Require().NoError(engine.Rollback(run))
+	s.Equal("import { Header } from '@kit/ui/old-header';\n", s.read("apps/web/lib/utils.ts"), "ジャーナルで元に戻せる")
+}
+
+func (s *RemapTestSuite) TestRewriteSpecifiersInDirs() {
+	mapping := s.mapping("rewrites:\n  - from: \"@kit/ui/old-header\"\n    to: \"@kit/ui/page-header\"\n")
+	engine, err := NewEngine(s.root, Options{FS: s.fs, DryRun: true})
+	s.Require().NoError(err)
+	result, run, err := engine.RewriteSpecifiers(mapping, []string{"apps/web/lib", "apps/web"})
+	s.Require().NoError(err)
+
+	s.Equal(2, result.Files, "重なっているディレクトリのファイルは 1 回だけ処理する")
+	s.Len(result.Rewrites, 2)
+	s.Equal("import { Header } from '@kit/ui/old-header';\n", s.read("apps/web/lib/utils.ts"), "ドライランではファイルを変更しない")
+	content, err := engine.View(run).FS().ReadFile(filepath.Join(s.root, "apps/web/lib/utils.ts"))
+	s.Require().NoError(err)
+	s.Equal("import { Header } from '@kit/ui/page-header';\n", string(content))
+}
+
+func TestRemapSuite(t *testing.T) {
+	suite.Run(t, new(RemapTestSuite))
+}
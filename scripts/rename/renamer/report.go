@@ -0,0 +1,617 @@
+package renamer
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// レポートの出力形式
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatSARIF    = "sarif"
+	FormatJUnit    = "junit"
+	FormatMarkdown = "markdown"
+)
+
+// ReportFormats は指定できるレポートの出力形式の一覧です
+var ReportFormats = []string{FormatText, FormatJSON, FormatSARIF, FormatJUnit, FormatMarkdown}
+
+// 検出結果の重要度（SARIF の level と同じ値）
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// 検出結果のルール
+const (
+	RuleNaming      = "naming"
+	RulePortability = "portability"
+	RuleApplyError  = "apply-error"
+	RuleUnused      = "unused"
+	RuleBoundary    = "boundary"
+	RuleMove        = "move"
+	RuleRemap       = "rewrite-imports"
+	RulePackage     = "rename-package"
+	RuleHoist       = "hoist"
+	RuleNormalize   = "normalize-imports"
+	RuleBarrel      = "barrels"
+	RuleExportName  = "export-name"
+	RuleCycle       = "cycle"
+)
+
+// Report はサブコマンドの実行結果を機械可読な形式で出力するためのモデルです
+// パスはすべてプロジェクトルートからの相対パス（スラッシュ区切り）です
+type Report struct {
+	// 実行したサブコマンド（analyze / plan / apply / check / unused / boundaries / mv / rewrite-imports / rename-package / hoist / normalize-imports / barrels update / barrels check / export-names）
+	Command string `json:"command"`
+	// 変換方向
+	Direction string `json:"direction,omitempty"`
+	// ドライランとして実行したかどうか
+	DryRun bool `json:"dryRun,omitempty"`
+	// プロジェクト構造（analyze）
+	Structure *StructureReport `json:"structure,omitempty"`
+	// ディレクトリごとの変換計画または変換結果（plan / apply）
+	Dirs []DirReport `json:"dirs,omitempty"`
+	// 命名規則の違反（check、ベースラインに記録済みのものを除く）
+	Violations []NamingViolation `json:"violations,omitempty"`
+	// ベースラインに記録済みの違反の件数（check）
+	KnownViolations int `json:"knownViolations,omitempty"`
+	// 循環しているインポート（check --cycles、ベースラインに記録済みのものを除く）
+	Cycles []ImportCycle `json:"cycles,omitempty"`
+	// ベースラインに記録済みの循環の件数（check --cycles）
+	KnownCycles int `json:"knownCycles,omitempty"`
+	// 移植性の問題
+	Portability []PortabilityIssue `json:"portability,omitempty"`
+	// どのファイルからもインポートされていないモジュール（unused）
+	Unused []UnusedModule `json:"unused,omitempty"`
+	// 主なエクスポートの識別子がファイル名と一致しないモジュール（export-names）
+	ExportNames []ExportNameMismatch `json:"exportNames,omitempty"`
+	// ワークスペースの境界の違反（boundaries）
+	Boundaries []BoundaryViolation `json:"boundaries,omitempty"`
+	// 移動と書き換えた参照（mv）
+	Move *MoveResult `json:"move,omitempty"`
+	// 規則による指定子の書き換え（rewrite-imports）
+	Remap *RemapResult `json:"rewriteImports,omitempty"`
+	// パッケージ名の変更（rename-package）
+	PackageRename *PackageRenameResult `json:"renamePackage,omitempty"`
+	// パッケージへのコンポーネントのホイスト（hoist）
+	Hoist *HoistResult `json:"hoist,omitempty"`
+	// インポートの書き方の統一（normalize-imports）
+	Normalize *NormalizeResult `json:"normalizeImports,omitempty"`
+	// 更新した（barrels check では更新が必要な、または存在しないファイルを指す）バレル（barrels）
+	Barrels []BarrelResult `json:"barrels,omitempty"`
+	// 集計
+	Summary ReportSummary `json:"summary"`
+}
+
+// StructureReport はプロジェクト構造の解析結果です
+type StructureReport struct {
+	RootType    string           `json:"rootType"`
+	Directories []DirectoryStats `json:"directories"`
+}
+
+// DirectoryStats はディレクトリごとのファイル統計です
+type DirectoryStats struct {
+	Path           string `json:"path"`
+	TotalFiles     int    `json:"totalFiles"`
+	CamelCaseFiles int    `json:"camelCaseFiles"`
+	KebabCaseFiles int    `json:"kebabCaseFiles"`
+}
+
+// DirReport はディレクトリごとの変換計画または変換結果です
+type DirReport struct {
+	TargetDir  string         `json:"targetDir"`
+	TotalFiles int            `json:"totalFiles"`
+	Skipped    int            `json:"skipped"`
+	Processed  int            `json:"processed,omitempty"`
+	Errors     int            `json:"errors,omitempty"`
+	Renames    []RenameReport `json:"renames"`
+	// インポートパスを更新したファイル（apply）
+	ImportUpdates []string `json:"importUpdates,omitempty"`
+	// リネームの後に再生成の対象になるバレル（plan）または更新したバレル（apply）
+	Barrels []string `json:"barrels,omitempty"`
+	// エラーの内容（apply）
+	ErrorDetails []string `json:"errorDetails,omitempty"`
+}
+
+// RenameReport は 1 件のリネームです
+type RenameReport struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	IsDir bool   `json:"isDir,omitempty"`
+	// リネーム対象を直接インポートしているファイル（AddImporters を呼び出した場合のみ）
+	Importers []string `json:"importers,omitempty"`
+}
+
+// ReportSummary はレポートの集計です
+type ReportSummary struct {
+	Renames       int `json:"renames"`
+	Processed     int `json:"processed"`
+	Skipped       int `json:"skipped"`
+	Errors        int `json:"errors"`
+	ImportUpdates int `json:"importUpdates"`
+	Violations    int `json:"violations"`
+	Portability   int `json:"portability"`
+	Unused        int `json:"unused,omitempty"`
+	ExportNames   int `json:"exportNames,omitempty"`
+	Boundaries    int `json:"boundaries,omitempty"`
+	Cycles        int `json:"cycles,omitempty"`
+}
+
+// Finding は SARIF や JUnit に出力する個々の検出結果です
+type Finding struct {
+	RuleID  string
+	Level   string
+	Path    string
+	Message string
+	// 1 から始まる行番号（行を特定できない場合は 0）
+	Line int
+}
+
+// NewAnalyzeReport はプロジェクト構造の解析結果からレポートを作成します
+func (e *Engine) NewAnalyzeReport(structure *ProjectStructure) *Report {
+	report := &Report{Command: "analyze", Structure: &StructureReport{RootType: structure.RootType, Directories: []DirectoryStats{}}}
+	for _, dir := range structure.Directories {
+		stats := structure.FileStats[dir]
+		report.Structure.Directories = append(report.Structure.Directories, DirectoryStats{
+			Path:           dir,
+			TotalFiles:     stats.TotalFiles,
+			CamelCaseFiles: stats.CamelCaseCount,
+			KebabCaseFiles: stats.KebabCaseCount,
+		})
+	}
+	return report
+}
+
+// NewPlanReport は変換計画からレポートを作成します
+func (e *Engine) NewPlanReport(plan *Plan) *Report {
+	report := &Report{Command: "plan", Direction: plan.ConversionDirection, Dirs: []DirReport{}}
+	for _, dirPlan := range plan.Dirs {
+		dir := e.dirReport(dirPlan)
+		dir.Barrels = e.PlannedBarrels(dirPlan)
+		report.Dirs = append(report.Dirs, dir)
+	}
+	report.summarize()
+	return report
+}
+
+// NewApplyReport は変換計画と変換結果からレポートを作成します
+// results は plan.Dirs と同じ順序の変換結果です
+func (e *Engine) NewApplyReport(plan *Plan, results []ConversionResult) *Report {
+	report := &Report{Command: "apply", Direction: plan.ConversionDirection, DryRun: e.opts.DryRun, Dirs: []DirReport{}}
+	for i, dirPlan := range plan.Dirs {
+		dir := e.dirReport(dirPlan)
+		if i < len(results) {
+			result := results[i]
+			dir.Processed = result.ProcessedFiles
+			dir.Errors = result.ErrorFiles
+			dir.ErrorDetails = result.Errors
+			for _, file := range result.ImportUpdateFiles {
+				dir.ImportUpdates = append(dir.ImportUpdates, e.Rel(file))
+			}
+			dir.Barrels = result.Barrels
+		}
+		report.Dirs = append(report.Dirs, dir)
+	}
+	report.summarize()
+	return report
+}
+
+// NewCheckReport は命名規則の検査結果からレポートを作成します
+// violations はベースラインに記録されていない違反、known はベースラインに記録済みの違反の件数です
+func (e *Engine) NewCheckReport(violations []NamingViolation, known int) *Report {
+	report := &Report{
+		Command:         "check",
+		Direction:       e.opts.ConversionDirection,
+		Violations:      violations,
+		KnownViolations: known,
+	}
+	report.summarize()
+	return report
+}
+
+// NewExportNameReport はエクスポートの識別子とファイル名の不一致の検出結果からレポートを作成します
+func (e *Engine) NewExportNameReport(mismatches []ExportNameMismatch) *Report {
+	report := &Report{Command: "export-names", ExportNames: mismatches}
+	report.summarize()
+	return report
+}
+
+// NewUnusedReport は未使用のモジュールの検出結果からレポートを作成します
+func (e *Engine) NewUnusedReport(unused []UnusedModule) *Report {
+	report := &Report{Command: "unused", Unused: unused}
+	report.summarize()
+	return report
+}
+
+// NewBoundaryReport はワークスペースの境界の検査結果からレポートを作成します
+func (e *Engine) NewBoundaryReport(violations []BoundaryViolation) *Report {
+	report := &Report{Command: "boundaries", Boundaries: violations}
+	report.summarize()
+	return report
+}
+
+// NewMoveReport はファイルまたはディレクトリの移動結果からレポートを作成します
+func (e *Engine) NewMoveReport(result *MoveResult) *Report {
+	report := &Report{Command: "mv", DryRun: e.opts.DryRun, Move: result}
+	report.summarize()
+	return report
+}
+
+// NewRemapReport は規則によるモジュール指定子の書き換えの結果からレポートを作成します
+func (e *Engine) NewRemapReport(result *RemapResult) *Report {
+	report := &Report{Command: "rewrite-imports", DryRun: e.opts.DryRun, Remap: result}
+	report.summarize()
+	return report
+}
+
+// NewPackageRenameReport はパッケージ名の変更の結果からレポートを作成します
+func (e *Engine) NewPackageRenameReport(result *PackageRenameResult) *Report {
+	report := &Report{Command: "rename-package", DryRun: e.opts.DryRun, PackageRename: result}
+	report.summarize()
+	return report
+}
+
+// NewHoistReport はコンポーネントのホイストの結果からレポートを作成します
+func (e *Engine) NewHoistReport(result *HoistResult) *Report {
+	report := &Report{Command: "hoist", DryRun: e.opts.DryRun, Hoist: result}
+	report.summarize()
+	return report
+}
+
+// NewNormalizeReport はインポートの書き方の統一の結果からレポートを作成します
+func (e *Engine) NewNormalizeReport(result *NormalizeResult) *Report {
+	report := &Report{Command: "normalize-imports", DryRun: e.opts.DryRun, Normalize: result}
+	report.summarize()
+	return report
+}
+
+// NewBarrelReport はバレルの更新または検査の結果からレポートを作成します
+// check の場合は更新が必要なバレルを警告として扱います
+func (e *Engine) NewBarrelReport(check bool, results []BarrelResult) *Report {
+	report := &Report{Command: "barrels update", DryRun: e.opts.DryRun, Barrels: []BarrelResult{}}
+	if check {
+		report.Command = "barrels check"
+	}
+	for _, result := range results {
+		if result.Outdated || len(result.Missing) > 0 {
+			report.Barrels = append(report.Barrels, result)
+		}
+	}
+	report.summarize()
+	return report
+}
+
+// AddCycles は循環しているインポートの検査結果をレポートに追加します
+// cycles はベースラインに記録されていない循環、known はベースラインに記録済みの循環の件数です
+func (r *Report) AddCycles(cycles []ImportCycle, known int) {
+	r.Cycles = append(r.Cycles, cycles...)
+	r.KnownCycles += known
+	r.summarize()
+}
+
+// AddPortability は移植性の問題をレポートに追加します
+func (r *Report) AddPortability(issues []PortabilityIssue) {
+	r.Portability = append(r.Portability, issues...)
+	r.summarize()
+}
+
+// AddImporters は依存グラフから各リネーム対象を直接インポートしているファイルをレポートに追加します
+func (r *Report) AddImporters(g *Graph) {
+	for i := range r.Dirs {
+		for j := range r.Dirs[i].Renames {
+			rename := &r.Dirs[i].Renames[j]
+			rename.Importers = g.Importers(rename.From)
+		}
+	}
+}
+
+// ディレクトリごとの変換計画をレポートに変換
+func (e *Engine) dirReport(dirPlan DirPlan) DirReport {
+	dir := DirReport{
+		TargetDir:  dirPlan.TargetDir,
+		TotalFiles: dirPlan.TotalFiles,
+		Skipped:    dirPlan.SkippedFiles,
+		Renames:    []RenameReport{},
+	}
+	for _, rename := range dirPlan.Renames {
+		dir.Renames = append(dir.Renames, RenameReport{From: e.Rel(rename.OldPath), To: e.Rel(rename.NewPath), IsDir: rename.IsDir})
+	}
+	return dir
+}
+
+// 集計を更新
+func (r *Report) summarize() {
+	var summary ReportSummary
+	for _, section := range r.sections() {
+		section.summarize(&summary)
+	}
+	r.Summary = summary
+}
+
+// Findings はレポートに含まれる検出結果を返します
+// 命名規則の違反・循環しているインポート・境界の違反・実行時のエラー・存在しないファイルを指すバレルの再エクスポートは error、
+// 移植性の問題・未使用のモジュール・ファイル名と一致しないエクスポート・移動とホイストの警告・どの指定子にも一致しなかった書き換えの規則・書き換えていないパッケージ名の参照・
+// 更新が必要なバレルは warning、
+// 予定・実行したリネーム・移動・指定子の書き換え・パッケージ名の変更・ホイスト・インポートの書き方の統一・バレルの更新は note とします
+func (r *Report) Findings() []Finding {
+	var findings []Finding
+	for _, section := range r.sections() {
+		findings = append(findings, section.findings()...)
+	}
+	return findings
+}
+
+// WriteReport はレポートを format の形式で w に出力します
+func WriteReport(w io.Writer, format string, report *Report) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, report)
+	case FormatSARIF:
+		return writeSARIF(w, report)
+	case FormatJUnit:
+		return writeJUnit(w, report)
+	case FormatMarkdown:
+		return writeMarkdown(w, report)
+	}
+	return fmt.Errorf("対応していない出力形式です: %s（%s）", format, strings.Join(ReportFormats, " / "))
+}
+
+// インデントした JSON を出力
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(v)
+}
+
+// ルールの説明（SARIF の shortDescription と JUnit のテストスイート名に使用）
+var ruleDescriptions = map[string]string{
+	RuleNaming:                           "ファイル名の命名規則",
+	RuleApplyError:                       "変換の実行エラー",
+	RuleUnused:                           "インポートされていないモジュール",
+	RuleExportName:                       "ファイル名と一致しないエクスポートの識別子",
+	RuleBoundary:                         "ワークスペースの境界",
+	RuleCycle:                            "循環しているインポート",
+	RuleMove:                             "ファイルの移動と参照の書き換え",
+	RuleRemap:                            "規則によるモジュール指定子の書き換え",
+	RulePackage:                          "ワークスペースのパッケージ名の変更",
+	RuleHoist:                            "コンポーネントのパッケージへのホイスト",
+	RuleNormalize:                        "相対パスとエイリアスの書き方の統一",
+	RuleBarrel:                           "バレル（index.ts）の再エクスポート",
+	RuleBoundary + "/" + BoundaryImport:  "許可されていないワークスペースのインポート",
+	RuleBoundary + "/" + BoundaryInclude: "ワークスペースの外を指す tsconfig の include",
+	RulePortability + "/" + PortabilityCaseCollision:      "大文字小文字だけが異なる名前",
+	RulePortability + "/" + PortabilityReservedName:       "Windows の予約名",
+	RulePortability + "/" + PortabilityTrailingDotOrSpace: "末尾がドットまたは空白の名前",
+	RulePortability + "/" + PortabilityIllegalCharacter:   "Windows で使用できない文字",
+	RulePortability + "/" + PortabilityNameTooLong:        "長すぎるファイル名",
+	RulePortability + "/" + PortabilityPathTooLong:        "長すぎるパス",
+}
+
+// SARIF 2.1.0 の出力に必要な部分
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIF 形式で出力（コードスキャンの画面に検出結果を表示するため）
+func writeSARIF(w io.Writer, report *Report) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "rename-script", Rules: []sarifRule{}}},
+		Results: []sarifResult{},
+	}
+	rules := make(map[string]bool)
+	for _, finding := range report.Findings() {
+		if !rules[finding.RuleID] {
+			rules[finding.RuleID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               finding.RuleID,
+				ShortDescription: sarifMessage{Text: ruleDescription(finding.RuleID)},
+			})
+		}
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: finding.Path}}
+		if finding.Line > 0 {
+			location.Region = &sarifRegion{StartLine: finding.Line}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    finding.RuleID,
+			Level:     finding.Level,
+			Message:   sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool { return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID })
+	return writeJSON(w, sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// ルールの説明を返す（未登録の場合はルール ID）
+func ruleDescription(ruleID string) string {
+	if description, ok := ruleDescriptions[ruleID]; ok {
+		return description
+	}
+	return ruleID
+}
+
+// JUnit XML の出力に必要な部分
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnit XML 形式で出力
+// error と warning の検出結果をルールごとのテストスイートの失敗として出力します（note は出力しません）
+// 失敗のないテストスイートは、検査を行ったことがわかるよう成功したテストケースを 1 件含めます
+func writeJUnit(w io.Writer, report *Report) error {
+	suites := map[string]*junitTestSuite{}
+	var order []string
+	suite := func(name string) *junitTestSuite {
+		if s, ok := suites[name]; ok {
+			return s
+		}
+		suites[name] = &junitTestSuite{Name: name}
+		order = append(order, name)
+		return suites[name]
+	}
+
+	for _, section := range report.sections() {
+		if rule := section.rule(); rule != "" {
+			suite(rule)
+		}
+	}
+	for _, finding := range report.Findings() {
+		if finding.Level == LevelNote {
+			continue
+		}
+		name := strings.SplitN(finding.RuleID, "/", 2)[0]
+		s := suite(name)
+		s.Cases = append(s.Cases, junitTestCase{
+			ClassName: finding.RuleID,
+			Name:      finding.Path,
+			Failure:   &junitFailure{Message: finding.Message, Type: finding.Level, Text: finding.Message},
+		})
+		s.Failures++
+	}
+
+	result := junitTestSuites{Name: "rename-script " + report.Command, Suites: []junitTestSuite{}}
+	for _, name := range order {
+		s := suites[name]
+		if len(s.Cases) == 0 {
+			s.Cases = append(s.Cases, junitTestCase{ClassName: name, Name: ruleDescription(name)})
+		}
+		s.Tests = len(s.Cases)
+		result.Tests += s.Tests
+		result.Failures += s.Failures
+		result.Suites = append(result.Suites, *s)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Markdown 形式で出力（PR の説明に貼り付けられる概要の表）
+func writeMarkdown(w io.Writer, report *Report) error {
+	var b strings.Builder
+	title := "rename-script " + report.Command
+	if report.Direction != "" {
+		title += "（" + report.Direction + "）"
+	}
+	if report.DryRun {
+		title += " ドライラン"
+	}
+	fmt.Fprintf(&b, "## %s\n", title)
+
+	for _, section := range report.sections() {
+		section.writeMarkdown(&b)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// 表のセルで使用できない文字をエスケープ
+func markdownCell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", `\|`), "\n", " ")
+}
+
+// パスをコードとして表のセルに出力
+func markdownCode(text string) string {
+	return "`" + markdownCell(text) + "`"
+}
+
+// 指定子の一覧をコードとして表のセルに出力
+func markdownSpecifiers(specs []string) string {
+	cells := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		cells = append(cells, markdownCode(spec))
+	}
+	return strings.Join(cells, " ")
+}
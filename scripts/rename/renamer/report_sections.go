@@ -0,0 +1,674 @@
+package renamer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// reportSection はレポートに含まれるコマンドごとの結果です
+// 集計・検出結果・Markdown の出力はセクションごとに実装し、各形式の出力はセクションを順に処理するだけにします
+// 新しいコマンドの結果は、セクションを実装して Report.sections に加えれば各形式の出力に反映されます
+type reportSection interface {
+	// JUnit で検出結果がなくても出力するテストスイートのルール（ない場合は空文字列）
+	rule() string
+	// 集計に加える
+	summarize(summary *ReportSummary)
+	// 検出結果を返す
+	findings() []Finding
+	// Markdown の本文を b に追加する
+	writeMarkdown(b *strings.Builder)
+}
+
+// レポートに含まれるセクションを Markdown に出力する順に返す
+func (r *Report) sections() []reportSection {
+	var sections []reportSection
+	if r.Structure != nil {
+		sections = append(sections, structureSection{r.Structure})
+	}
+	switch r.Command {
+	case "plan", "apply":
+		sections = append(sections, renameSection{dirs: r.Dirs, applied: r.Command == "apply" && !r.DryRun})
+	case "check":
+		sections = append(sections, checkSection{r})
+	case "unused":
+		sections = append(sections, unusedSection(r.Unused))
+	case "export-names":
+		sections = append(sections, exportNameSection(r.ExportNames))
+	case "boundaries":
+		sections = append(sections, boundarySection(r.Boundaries))
+	case "barrels update", "barrels check":
+		sections = append(sections, barrelSection{barrels: r.Barrels, check: r.Command == "barrels check", dryRun: r.DryRun})
+	}
+	if r.Move != nil {
+		sections = append(sections, moveSection{r.Move, r.DryRun})
+	}
+	if r.Remap != nil {
+		sections = append(sections, remapSection{r.Remap, r.DryRun})
+	}
+	if r.PackageRename != nil {
+		sections = append(sections, packageRenameSection{r.PackageRename, r.DryRun})
+	}
+	if r.Normalize != nil {
+		sections = append(sections, normalizeSection{r.Normalize, r.DryRun})
+	}
+	if r.Hoist != nil {
+		sections = append(sections, hoistSection{r.Hoist, r.DryRun})
+	}
+	if len(r.Portability) > 0 {
+		sections = append(sections, portabilitySection(r.Portability))
+	}
+	return sections
+}
+
+// 書き換えた参照があるファイルの数
+func rewrittenFiles(rewrites []SpecifierRewrite) int {
+	files := make(map[string]bool)
+	for _, rewrite := range rewrites {
+		files[rewrite.File] = true
+	}
+	return len(files)
+}
+
+// プロジェクト構造（analyze）
+type structureSection struct {
+	structure *StructureReport
+}
+
+func (s structureSection) rule() string                     { return "" }
+func (s structureSection) summarize(summary *ReportSummary) {}
+func (s structureSection) findings() []Finding              { return nil }
+
+func (s structureSection) writeMarkdown(b *strings.Builder) {
+	fmt.Fprintf(b, "\n%s: %d ディレクトリ\n\n", s.structure.RootType, len(s.structure.Directories))
+	b.WriteString("| ディレクトリ | キャメルケース | ケバブケース | 合計 |\n|---|---:|---:|---:|\n")
+	for _, dir := range s.structure.Directories {
+		fmt.Fprintf(b, "| %s | %d | %d | %d |\n", markdownCell(dir.Path), dir.CamelCaseFiles, dir.KebabCaseFiles, dir.TotalFiles)
+	}
+}
+
+// 変換計画または変換結果（plan / apply）
+type renameSection struct {
+	dirs []DirReport
+	// 実際にリネームしたかどうか（ドライランでない apply）
+	applied bool
+}
+
+func (s renameSection) rule() string { return RuleNaming }
+
+func (s renameSection) summarize(summary *ReportSummary) {
+	for _, dir := range s.dirs {
+		summary.Renames += len(dir.Renames)
+		summary.Processed += dir.Processed
+		summary.Skipped += dir.Skipped
+		summary.Errors += dir.Errors
+		summary.ImportUpdates += len(dir.ImportUpdates)
+	}
+}
+
+func (s renameSection) findings() []Finding {
+	var findings []Finding
+	for _, dir := range s.dirs {
+		for _, rename := range dir.Renames {
+			message := fmt.Sprintf("%s にリネームする予定です", rename.To)
+			if s.applied {
+				message = fmt.Sprintf("%s にリネームしました", rename.To)
+			}
+			findings = append(findings, Finding{RuleID: RuleNaming, Level: LevelNote, Path: rename.From, Message: message})
+		}
+		for _, barrel := range dir.Barrels {
+			message := "リネームの後にバレルを再生成する予定です"
+			if s.applied {
+				message = "リネームの後にバレルを更新しました"
+			}
+			findings = append(findings, Finding{RuleID: RuleBarrel, Level: LevelNote, Path: barrel, Message: message})
+		}
+		for _, detail := range dir.ErrorDetails {
+			findings = append(findings, Finding{RuleID: RuleApplyError, Level: LevelError, Path: dir.TargetDir, Message: detail})
+		}
+	}
+	return findings
+}
+
+func (s renameSection) writeMarkdown(b *strings.Builder) {
+	var summary ReportSummary
+	s.summarize(&summary)
+	b.WriteString("\n| ディレクトリ | ファイル | リネーム | スキップ | エラー | インポート更新 |\n|---|---:|---:|---:|---:|---:|\n")
+	total := 0
+	for _, dir := range s.dirs {
+		total += dir.TotalFiles
+		fmt.Fprintf(b, "| %s | %d | %d | %d | %d | %d |\n",
+			markdownCell(dir.TargetDir), dir.TotalFiles, len(dir.Renames), dir.Skipped, dir.Errors, len(dir.ImportUpdates))
+	}
+	fmt.Fprintf(b, "| **合計** | %d | %d | %d | %d | %d |\n",
+		total, summary.Renames, summary.Skipped, summary.Errors, summary.ImportUpdates)
+
+	if summary.Renames > 0 {
+		fmt.Fprintf(b, "\n<details>\n<summary>リネーム（%d 件）</summary>\n\n| 変更前 | 変更後 |\n|---|---|\n", summary.Renames)
+		for _, dir := range s.dirs {
+			for _, rename := range dir.Renames {
+				fmt.Fprintf(b, "| %s | %s |\n", markdownCode(rename.From), markdownCode(rename.To))
+			}
+		}
+		b.WriteString("\n</details>\n")
+	}
+
+	var barrels []string
+	for _, dir := range s.dirs {
+		for _, barrel := range dir.Barrels {
+			barrels = append(barrels, markdownCode(barrel))
+		}
+	}
+	if len(barrels) > 0 {
+		label := "リネームの後に再生成するバレル"
+		if s.applied {
+			label = "更新したバレル"
+		}
+		fmt.Fprintf(b, "\n%s: %s\n", label, strings.Join(barrels, ", "))
+	}
+}
+
+// 命名規則の違反と循環しているインポート（check）
+type checkSection struct {
+	report *Report
+}
+
+func (s checkSection) rule() string { return RuleNaming }
+
+func (s checkSection) summarize(summary *ReportSummary) {
+	summary.Violations += len(s.report.Violations)
+	summary.Cycles += len(s.report.Cycles)
+}
+
+func (s checkSection) findings() []Finding {
+	var findings []Finding
+	for _, violation := range s.report.Violations {
+		message := fmt.Sprintf("命名規則（%s）に違反しています（推奨: %s）", s.report.Direction, violation.Suggested)
+		if violation.Collision {
+			message += "。推奨される名前は既に存在するため、手で解決してください"
+		}
+		findings = append(findings, Finding{
+			RuleID:  RuleNaming,
+			Level:   LevelError,
+			Path:    violation.Path,
+			Message: message,
+		})
+	}
+	for _, cycle := range s.report.Cycles {
+		edges := make([]string, 0, len(cycle.Edges))
+		line := 0
+		for _, edge := range cycle.Edges {
+			edges = append(edges, fmt.Sprintf("%s:%d → %s", edge.From, edge.Line, edge.To))
+			if edge.From == cycle.Files[0] && line == 0 {
+				line = edge.Line
+			}
+		}
+		findings = append(findings, Finding{
+			RuleID:  RuleCycle,
+			Level:   LevelError,
+			Path:    cycle.Files[0],
+			Line:    line,
+			Message: fmt.Sprintf("%d 件のファイルがインポートで循環しています（%s）", len(cycle.Files), strings.Join(edges, ", ")),
+		})
+	}
+	return findings
+}
+
+func (s checkSection) writeMarkdown(b *strings.Builder) {
+	report := s.report
+	if len(report.Violations) == 0 {
+		b.WriteString("\n命名規則の違反はありません。\n")
+	} else {
+		fmt.Fprintf(b, "\n命名規則の違反: %d 件\n\n| パス | 推奨される名前 |\n|---|---|\n", len(report.Violations))
+		for _, violation := range report.Violations {
+			suggested := markdownCode(violation.Suggested)
+			if violation.Collision {
+				suggested += "（既に存在します）"
+			}
+			fmt.Fprintf(b, "| %s | %s |\n", markdownCode(violation.Path), suggested)
+		}
+	}
+	if report.KnownViolations > 0 {
+		fmt.Fprintf(b, "\nベースラインに記録済みの違反: %d 件\n", report.KnownViolations)
+	}
+	if len(report.Cycles) > 0 {
+		fmt.Fprintf(b, "\n循環しているインポート: %d 件\n", len(report.Cycles))
+		for i, cycle := range report.Cycles {
+			fmt.Fprintf(b, "\n%d. %d ファイル\n\n| インポート | 参照先 |\n|---|---|\n", i+1, len(cycle.Files))
+			for _, edge := range cycle.Edges {
+				fmt.Fprintf(b, "| %s | %s |\n", markdownCode(fmt.Sprintf("%s:%d", edge.From, edge.Line)), markdownCode(edge.To))
+			}
+		}
+	}
+	if report.KnownCycles > 0 {
+		fmt.Fprintf(b, "\nベースラインに記録済みの循環: %d 件\n", report.KnownCycles)
+	}
+}
+
+// 移植性の問題（plan / check）
+type portabilitySection []PortabilityIssue
+
+func (s portabilitySection) rule() string { return "" }
+
+func (s portabilitySection) summarize(summary *ReportSummary) { summary.Portability += len(s) }
+
+func (s portabilitySection) findings() []Finding {
+	var findings []Finding
+	for _, issue := range s {
+		findings = append(findings, Finding{
+			RuleID:  RulePortability + "/" + issue.Kind,
+			Level:   LevelWarning,
+			Path:    issue.Path,
+			Message: issue.Detail,
+		})
+	}
+	return findings
+}
+
+func (s portabilitySection) writeMarkdown(b *strings.Builder) {
+	fmt.Fprintf(b, "\n移植性の問題: %d 件\n\n| パス | 種類 | 内容 |\n|---|---|---|\n", len(s))
+	for _, issue := range s {
+		fmt.Fprintf(b, "| %s | %s | %s |\n", markdownCode(issue.Path), issue.Kind, markdownCell(issue.Detail))
+	}
+}
+
+// どのファイルからもインポートされていないモジュール（unused）
+type unusedSection []UnusedModule
+
+func (s unusedSection) rule() string { return RuleUnused }
+
+func (s unusedSection) summarize(summary *ReportSummary) { summary.Unused += len(s) }
+
+func (s unusedSection) findings() []Finding {
+	var findings []Finding
+	for _, module := range s {
+		findings = append(findings, Finding{
+			RuleID:  RuleUnused,
+			Level:   LevelWarning,
+			Path:    module.Path,
+			Message: "どのファイルからもインポートされていません",
+		})
+	}
+	return findings
+}
+
+func (s unusedSection) writeMarkdown(b *strings.Builder) {
+	if len(s) == 0 {
+		b.WriteString("\n未使用のモジュールはありません。\n")
+		return
+	}
+	fmt.Fprintf(b, "\n未使用のモジュール: %d 件\n\n| パス | ワークスペース |\n|---|---|\n", len(s))
+	for _, module := range s {
+		fmt.Fprintf(b, "| %s | %s |\n", markdownCode(module.Path), markdownCell(module.Workspace))
+	}
+}
+
+// ファイル名と一致しないエクスポート（export-names）
+type exportNameSection []ExportNameMismatch
+
+func (s exportNameSection) rule() string { return RuleExportName }
+
+func (s exportNameSection) summarize(summary *ReportSummary) { summary.ExportNames += len(s) }
+
+func (s exportNameSection) findings() []Finding {
+	var findings []Finding
+	for _, mismatch := range s {
+		findings = append(findings, Finding{
+			RuleID:  RuleExportName,
+			Level:   LevelWarning,
+			Path:    mismatch.Path,
+			Line:    mismatch.Line,
+			Message: exportNameMessage(mismatch),
+		})
+	}
+	return findings
+}
+
+func (s exportNameSection) writeMarkdown(b *strings.Builder) {
+	if len(s) == 0 {
+		b.WriteString("\nファイル名と一致しないエクスポートはありません。\n")
+		return
+	}
+	fmt.Fprintf(b, "\nファイル名と一致しないエクスポート: %d 件\n\n| パス | 識別子 | ファイル名の変更 | 識別子の変更 |\n|---|---|---|---|\n", len(s))
+	for _, mismatch := range s {
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n", markdownCode(fmt.Sprintf("%s:%d", mismatch.Path, mismatch.Line)), markdownCode(mismatch.Symbol), markdownCode(mismatch.SuggestedPath), markdownCode(mismatch.SuggestedSymbol))
+	}
+}
+
+// ワークスペースの境界の違反（boundaries）
+type boundarySection []BoundaryViolation
+
+func (s boundarySection) rule() string { return RuleBoundary }
+
+func (s boundarySection) summarize(summary *ReportSummary) { summary.Boundaries += len(s) }
+
+func (s boundarySection) findings() []Finding {
+	var findings []Finding
+	for _, violation := range s {
+		findings = append(findings, Finding{
+			RuleID:  RuleBoundary + "/" + violation.Kind,
+			Level:   LevelError,
+			Path:    violation.File,
+			Line:    violation.Line,
+			Message: violation.Message(),
+		})
+	}
+	return findings
+}
+
+func (s boundarySection) writeMarkdown(b *strings.Builder) {
+	if len(s) == 0 {
+		b.WriteString("\nワークスペースの境界の違反はありません。\n")
+		return
+	}
+	fmt.Fprintf(b, "\nワークスペースの境界の違反: %d 件\n\n| ファイル | 指定子 | 参照先 | 規則 |\n|---|---|---|---|\n", len(s))
+	for _, violation := range s {
+		file := violation.File
+		if violation.Line > 0 {
+			file = fmt.Sprintf("%s:%d", file, violation.Line)
+		}
+		rule := violation.Rule
+		if violation.Kind == BoundaryInclude {
+			rule = "tsconfig の include"
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n", markdownCode(file), markdownCode(violation.Specifier), markdownCode(violation.Target), markdownCell(rule))
+	}
+}
+
+// 移動と書き換えた参照（mv）
+type moveSection struct {
+	move   *MoveResult
+	dryRun bool
+}
+
+func (s moveSection) rule() string { return RuleMove }
+
+func (s moveSection) summarize(summary *ReportSummary) {
+	summary.Renames++
+	summary.ImportUpdates += rewrittenFiles(s.move.Rewrites)
+}
+
+func (s moveSection) findings() []Finding {
+	message := fmt.Sprintf("%s に移動する予定です（参照の書き換え: %d 件）", s.move.To, len(s.move.Rewrites))
+	if !s.dryRun {
+		message = fmt.Sprintf("%s に移動しました（参照の書き換え: %d 件）", s.move.To, len(s.move.Rewrites))
+	}
+	findings := []Finding{{RuleID: RuleMove, Level: LevelNote, Path: s.move.From, Message: message}}
+	for _, warning := range s.move.Warnings {
+		findings = append(findings, Finding{RuleID: RuleMove, Level: LevelWarning, Path: s.move.To, Message: warning})
+	}
+	return findings
+}
+
+func (s moveSection) writeMarkdown(b *strings.Builder) {
+	fmt.Fprintf(b, "\n%s → %s（%d ファイル）\n", markdownCode(s.move.From), markdownCode(s.move.To), s.move.Files)
+	rewrites := append(append([]SpecifierRewrite(nil), s.move.Rewrites...), s.move.PackageEntries...)
+	if len(rewrites) == 0 {
+		b.WriteString("\n書き換えた参照はありません。\n")
+	} else {
+		fmt.Fprintf(b, "\n書き換えた参照: %d 件\n\n| ファイル | 変更前 | 変更後 |\n|---|---|---|\n", len(rewrites))
+		for _, rewrite := range rewrites {
+			file := rewrite.File
+			if rewrite.Line > 0 {
+				file = fmt.Sprintf("%s:%d", file, rewrite.Line)
+			}
+			fmt.Fprintf(b, "| %s | %s | %s |\n", markdownCode(file), markdownCode(rewrite.From), markdownCode(rewrite.To))
+		}
+	}
+	if len(s.move.Warnings) > 0 {
+		fmt.Fprintf(b, "\n確認が必要な書き換え: %d 件\n\n", len(s.move.Warnings))
+		for _, warning := range s.move.Warnings {
+			fmt.Fprintf(b, "- %s\n", warning)
+		}
+	}
+}
+
+// 規則による指定子の書き換え（rewrite-imports）
+type remapSection struct {
+	remap  *RemapResult
+	dryRun bool
+}
+
+func (s remapSection) rule() string { return RuleRemap }
+
+func (s remapSection) summarize(summary *ReportSummary) {
+	summary.ImportUpdates += rewrittenFiles(s.remap.Rewrites)
+}
+
+func (s remapSection) findings() []Finding {
+	var findings []Finding
+	for _, rewrite := range s.remap.Rewrites {
+		message := fmt.Sprintf("%d 行目の '%s' を '%s' に書き換える予定です（規則: %s）", rewrite.Line, rewrite.From, rewrite.To, rewrite.Rule)
+		if !s.dryRun {
+			message = fmt.Sprintf("%d 行目の '%s' を '%s' に書き換えました（規則: %s）", rewrite.Line, rewrite.From, rewrite.To, rewrite.Rule)
+		}
+		findings = append(findings, Finding{RuleID: RuleRemap, Level: LevelNote, Path: rewrite.File, Line: rewrite.Line, Message: message})
+	}
+	for _, rule := range s.remap.UnusedRules {
+		findings = append(findings, Finding{RuleID: RuleRemap, Level: LevelWarning, Path: ".", Message: fmt.Sprintf("規則 %s はどの指定子にも一致しませんでした", rule)})
+	}
+	return findings
+}
+
+func (s remapSection) writeMarkdown(b *strings.Builder) {
+	if len(s.remap.Rewrites) == 0 {
+		fmt.Fprintf(b, "\n書き換えた指定子はありません（%d ファイルを検査）。\n", s.remap.Files)
+	} else {
+		fmt.Fprintf(b, "\n書き換えた指定子: %d 件（%d ファイルを検査）\n\n| ファイル | 変更前 | 変更後 | 規則 |\n|---|---|---|---|\n", len(s.remap.Rewrites), s.remap.Files)
+		for _, rewrite := range s.remap.Rewrites {
+			fmt.Fprintf(b, "| %s | %s | %s | %s |\n", markdownCode(fmt.Sprintf("%s:%d", rewrite.File, rewrite.Line)), markdownCode(rewrite.From), markdownCode(rewrite.To), markdownCell(rewrite.Rule))
+		}
+	}
+	if len(s.remap.UnusedRules) > 0 {
+		fmt.Fprintf(b, "\nどの指定子にも一致しなかった規則: %d 件\n\n", len(s.remap.UnusedRules))
+		for _, rule := range s.remap.UnusedRules {
+			fmt.Fprintf(b, "- %s\n", rule)
+		}
+	}
+}
+
+// パッケージ名の変更（rename-package）
+type packageRenameSection struct {
+	rename *PackageRenameResult
+	dryRun bool
+}
+
+func (s packageRenameSection) rule() string { return RulePackage }
+
+func (s packageRenameSection) summarize(summary *ReportSummary) {
+	summary.Renames++
+	summary.ImportUpdates += rewrittenFiles(s.rename.Imports)
+}
+
+func (s packageRenameSection) findings() []Finding {
+	rename := s.rename
+	rewrites := len(rename.Manifests) + len(rename.TSConfigs) + len(rename.Imports)
+	message := fmt.Sprintf("%s を %s に変更する予定です（書き換え: %d 件）", rename.From, rename.To, rewrites)
+	if !s.dryRun {
+		message = fmt.Sprintf("%s を %s に変更しました（書き換え: %d 件）", rename.From, rename.To, rewrites)
+	}
+	findings := []Finding{{RuleID: RulePackage, Level: LevelNote, Path: rename.Workspace + "/package.json", Message: message}}
+	for _, mention := range rename.Remaining {
+		file, rest, _ := strings.Cut(mention, ":")
+		line, _ := strconv.Atoi(rest)
+		findings = append(findings, Finding{RuleID: RulePackage, Level: LevelWarning, Path: file, Line: line, Message: fmt.Sprintf("%s で %s を参照しています（インポート以外のため書き換えていません）", mention, rename.From)})
+	}
+	return findings
+}
+
+func (s packageRenameSection) writeMarkdown(b *strings.Builder) {
+	rename := s.rename
+	fmt.Fprintf(b, "\n%s → %s（%s）\n", markdownCode(rename.From), markdownCode(rename.To), markdownCode(rename.Workspace))
+	rewrites := append(append(append([]SpecifierRewrite(nil), rename.Manifests...), rename.TSConfigs...), rename.Imports...)
+	if len(rewrites) > 0 {
+		fmt.Fprintf(b, "\n書き換え: %d 件\n\n| ファイル | 項目 | 変更前 | 変更後 |\n|---|---|---|---|\n", len(rewrites))
+		for _, rewrite := range rewrites {
+			kind := rewrite.Kind
+			if kind == "" {
+				kind = "import"
+			}
+			fmt.Fprintf(b, "| %s | %s | %s | %s |\n", markdownCode(fmt.Sprintf("%s:%d", rewrite.File, rewrite.Line)), markdownCell(kind), markdownCode(rewrite.From), markdownCode(rewrite.To))
+		}
+	}
+	if len(rename.Remaining) > 0 {
+		fmt.Fprintf(b, "\nインポート以外で %s を参照している箇所（書き換えていません）: %d 件\n\n", markdownCode(rename.From), len(rename.Remaining))
+		for _, mention := range rename.Remaining {
+			fmt.Fprintf(b, "- %s\n", markdownCode(mention))
+		}
+	}
+}
+
+// インポートの書き方の統一（normalize-imports）
+type normalizeSection struct {
+	normalize *NormalizeResult
+	dryRun    bool
+}
+
+func (s normalizeSection) rule() string { return RuleNormalize }
+
+func (s normalizeSection) summarize(summary *ReportSummary) {
+	summary.ImportUpdates += rewrittenFiles(s.normalize.Rewrites)
+}
+
+func (s normalizeSection) findings() []Finding {
+	var findings []Finding
+	for _, rewrite := range s.normalize.Rewrites {
+		message := fmt.Sprintf("%d 行目の '%s' を '%s' に書き換える予定です（%s）", rewrite.Line, rewrite.From, rewrite.To, rewrite.Rule)
+		if !s.dryRun {
+			message = fmt.Sprintf("%d 行目の '%s' を '%s' に書き換えました（%s）", rewrite.Line, rewrite.From, rewrite.To, rewrite.Rule)
+		}
+		findings = append(findings, Finding{RuleID: RuleNormalize, Level: LevelNote, Path: rewrite.File, Line: rewrite.Line, Message: message})
+	}
+	return findings
+}
+
+func (s normalizeSection) writeMarkdown(b *strings.Builder) {
+	if len(s.normalize.Rewrites) == 0 {
+		fmt.Fprintf(b, "\n書き換えた指定子はありません（%d ファイルを検査）。\n", s.normalize.Files)
+		return
+	}
+	fmt.Fprintf(b, "\n書き換えた指定子: %d 件（%d ファイルを検査）\n\n| ファイル | 変更前 | 変更後 | 理由 |\n|---|---|---|---|\n", len(s.normalize.Rewrites), s.normalize.Files)
+	for _, rewrite := range s.normalize.Rewrites {
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n", markdownCode(fmt.Sprintf("%s:%d", rewrite.File, rewrite.Line)), markdownCode(rewrite.From), markdownCode(rewrite.To), markdownCell(rewrite.Rule))
+	}
+}
+
+// パッケージへのコンポーネントのホイスト（hoist）
+type hoistSection struct {
+	hoist  *HoistResult
+	dryRun bool
+}
+
+func (s hoistSection) rule() string { return RuleHoist }
+
+func (s hoistSection) summarize(summary *ReportSummary) {
+	summary.Renames += len(s.hoist.Files)
+	summary.ImportUpdates += rewrittenFiles(s.hoist.Rewrites)
+}
+
+func (s hoistSection) findings() []Finding {
+	hoist := s.hoist
+	message := fmt.Sprintf("%s に移動して %s として公開する予定です（参照の書き換え: %d 件）", hoist.To, hoist.Specifier, len(hoist.Rewrites))
+	if !s.dryRun {
+		message = fmt.Sprintf("%s に移動して %s として公開しました（参照の書き換え: %d 件）", hoist.To, hoist.Specifier, len(hoist.Rewrites))
+	}
+	findings := []Finding{{RuleID: RuleHoist, Level: LevelNote, Path: hoist.From, Message: message}}
+	for _, warning := range hoist.Warnings {
+		findings = append(findings, Finding{RuleID: RuleHoist, Level: LevelWarning, Path: hoist.To, Message: warning})
+	}
+	return findings
+}
+
+func (s hoistSection) writeMarkdown(b *strings.Builder) {
+	hoist := s.hoist
+	fmt.Fprintf(b, "\n%s → %s（%s）\n\n| 移動元 | 移動先 |\n|---|---|\n", markdownCode(hoist.From), markdownCode(hoist.To), markdownCode(hoist.Specifier))
+	for _, file := range hoist.Files {
+		fmt.Fprintf(b, "| %s | %s |\n", markdownCode(file.From), markdownCode(file.To))
+	}
+	if len(hoist.Exports) > 0 {
+		fmt.Fprintf(b, "\n追加した exports: %d 件\n\n", len(hoist.Exports))
+		for _, export := range hoist.Exports {
+			fmt.Fprintf(b, "- %s\n", markdownCode(export))
+		}
+	}
+	if len(hoist.Rewrites) > 0 {
+		fmt.Fprintf(b, "\n書き換えた参照: %d 件\n\n| ファイル | 変更前 | 変更後 |\n|---|---|---|\n", len(hoist.Rewrites))
+		for _, rewrite := range hoist.Rewrites {
+			fmt.Fprintf(b, "| %s | %s | %s |\n", markdownCode(fmt.Sprintf("%s:%d", rewrite.File, rewrite.Line)), markdownCode(rewrite.From), markdownCode(rewrite.To))
+		}
+	}
+	if len(hoist.Warnings) > 0 {
+		fmt.Fprintf(b, "\n確認が必要な項目: %d 件\n\n", len(hoist.Warnings))
+		for _, warning := range hoist.Warnings {
+			fmt.Fprintf(b, "- %s\n", warning)
+		}
+	}
+}
+
+// 更新した、または更新が必要なバレル（barrels update / barrels check）
+type barrelSection struct {
+	barrels []BarrelResult
+	// barrels check の場合は更新が必要なバレルを警告にする
+	check  bool
+	dryRun bool
+}
+
+func (s barrelSection) rule() string { return RuleBarrel }
+
+func (s barrelSection) summarize(summary *ReportSummary) {
+	if !s.check {
+		summary.ImportUpdates += len(s.barrels)
+	}
+}
+
+func (s barrelSection) findings() []Finding {
+	var findings []Finding
+	for _, barrel := range s.barrels {
+		if barrel.Outdated {
+			finding := Finding{RuleID: RuleBarrel, Level: LevelNote, Path: barrel.File, Message: "バレルを" + barrelAction(barrel) + "する予定です"}
+			switch {
+			case s.check:
+				finding.Level = LevelWarning
+				finding.Message = "バレルの" + barrelAction(barrel) + "が必要です（barrels update で更新してください）"
+			case !s.dryRun:
+				finding.Message = "バレルを" + barrelAction(barrel) + "しました"
+			}
+			findings = append(findings, finding)
+		}
+		for _, missing := range barrel.Missing {
+			line, spec, _ := strings.Cut(missing, ": ")
+			lineNumber, _ := strconv.Atoi(line)
+			findings = append(findings, Finding{RuleID: RuleBarrel, Level: LevelError, Path: barrel.File, Line: lineNumber, Message: fmt.Sprintf("%s 行目の '%s' は存在しないファイルを指しています", line, spec)})
+		}
+	}
+	return findings
+}
+
+func (s barrelSection) writeMarkdown(b *strings.Builder) {
+	if len(s.barrels) == 0 {
+		b.WriteString("\n更新が必要なバレルはありません。\n")
+		return
+	}
+	fmt.Fprintf(b, "\nバレル: %d 件\n\n| ファイル | 追加 | 削除 | 存在しないファイル |\n|---|---|---|---|\n", len(s.barrels))
+	for _, barrel := range s.barrels {
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n", markdownCode(barrel.File), markdownSpecifiers(barrel.Added), markdownSpecifiers(barrel.Removed), markdownSpecifiers(barrel.Missing))
+	}
+}
+
+// エクスポートの識別子とファイル名の不一致と、両方向の修正の候補
+func exportNameMessage(mismatch ExportNameMismatch) string {
+	kind := "エクスポート"
+	if mismatch.Default {
+		kind = "default エクスポート"
+	}
+	return fmt.Sprintf("%d 行目の %s %s がファイル名（%s）と一致しません（%s に名前を変更するか、識別子を %s に変更してください）",
+		mismatch.Line, kind, mismatch.Symbol, mismatch.Expected, mismatch.SuggestedPath, mismatch.SuggestedSymbol)
+}
+
+// バレルの変更の種類（作成 / 更新）
+func barrelAction(barrel BarrelResult) string {
+	if barrel.Created {
+		return "作成"
+	}
+	return "更新"
+}
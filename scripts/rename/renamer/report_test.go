@@ -0,0 +1,411 @@
+package renamer
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+	fs     *MemFS
+	root   string
+	engine *Engine
+}
+
+func (s *ReportTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/components/Button.tsx":    "export const Button = () => null;\n",
+		"apps/web/components/user-card.tsx": "import { Button } from './Button';\n",
+	})
+	var err error
+	s.engine, err = NewEngine(s.root, Options{FS: s.fs, ConversionDirection: DirectionCamelToKebab})
+	s.Require().NoError(err)
+}
+
+func (s *ReportTestSuite) write(format string, report *Report) string {
+	var buf bytes.Buffer
+	s.Require().NoError(WriteReport(&buf, format, report))
+	return buf.String()
+}
+
+func (s *ReportTestSuite) planReport() *Report {
+	plan, err := s.engine.Plan([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	report := s.engine.NewPlanReport(plan)
+	report.AddPortability([]PortabilityIssue{{Path: "apps/web/components/con.tsx", Kind: PortabilityReservedName, Detail: "Windows の予約名です"}})
+	return report
+}
+
+func (s *ReportTestSuite) TestJSON() {
+	plan, err := s.engine.Plan([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	s.engine.SetDryRun(true)
+	results, err := s.engine.Apply(plan)
+	s.Require().NoError(err)
+
+	var decoded Report
+	s.Require().NoError(json.Unmarshal([]byte(s.write(FormatJSON, s.engine.NewApplyReport(plan, results))), &decoded))
+	s.Equal("apply", decoded.Command)
+	s.True(decoded.DryRun)
+	s.Require().Len(decoded.Dirs, 1)
+	s.Equal([]RenameReport{{From: "apps/web/components/Button.tsx", To: "apps/web/components/button.tsx"}}, decoded.Dirs[0].Renames)
+	s.Equal([]string{"apps/web/components/user-card.tsx"}, decoded.Dirs[0].ImportUpdates)
+	s.Equal(ReportSummary{Renames: 1, Processed: 1, Skipped: 1, ImportUpdates: 1}, decoded.Summary)
+}
+
+func (s *ReportTestSuite) TestSARIF() {
+	var log sarifLog
+	s.Require().NoError(json.Unmarshal([]byte(s.write(FormatSARIF, s.planReport())), &log))
+	s.Equal("2.1.0", log.Version)
+	s.Require().Len(log.Runs, 1)
+	run := log.Runs[0]
+	s.Equal([]sarifRule{
+		{ID: "naming", ShortDescription: sarifMessage{Text: "ファイル名の命名規則"}},
+		{ID: "portability/reserved-name", ShortDescription: sarifMessage{Text: "Windows の予約名"}},
+	}, run.Tool.Driver.Rules)
+	s.Require().Len(run.Results, 2)
+	s.Equal("note", run.Results[0].Level)
+	s.Equal("apps/web/components/Button.tsx", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	s.Nil(run.Results[0].Locations[0].PhysicalLocation.Region, "行を特定できない場合は region を出力しない")
+	s.Equal("warning", run.Results[1].Level)
+
+	// 行番号がわかる検出結果は region.startLine を出力する
+	report := s.engine.NewExportNameReport([]ExportNameMismatch{{Path: "apps/web/components/user-card.tsx", Symbol: "ProfileTile", Line: 3, Default: true, Expected: "UserCard"}})
+	s.Require().NoError(json.Unmarshal([]byte(s.write(FormatSARIF, report)), &log))
+	s.Require().Len(log.Runs[0].Results, 1)
+	s.Equal(&sarifRegion{StartLine: 3}, log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region)
+	s.Contains(s.write(FormatSARIF, report), `"startLine": 3`)
+}
+
+func (s *ReportTestSuite) TestJUnit() {
+	violations, err := s.engine.Check([]string{"apps/web/components"})
+	s.Require().NoError(err)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, s.engine.NewCheckReport(violations, 0))), &result))
+	s.Equal(1, result.Tests)
+	s.Equal(1, result.Failures)
+	s.Require().Len(result.Suites, 1)
+	s.Equal("apps/web/components/Button.tsx", result.Suites[0].Cases[0].Name)
+
+	// 違反がない場合も成功したテストケースを出力する
+	result = junitTestSuites{}
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, s.engine.NewCheckReport(nil, 1))), &result))
+	s.Equal(1, result.Tests)
+	s.Equal(0, result.Failures)
+	s.Nil(result.Suites[0].Cases[0].Failure)
+}
+
+func (s *ReportTestSuite) TestMarkdown() {
+	s.Equal("## rename-script plan（camel-to-kebab）\n"+
+		"\n| ディレクトリ | ファイル | リネーム | スキップ | エラー | インポート更新 |\n|---|---:|---:|---:|---:|---:|\n"+
+		"| apps/web/components | 2 | 1 | 1 | 0 | 0 |\n"+
+		"| **合計** | 2 | 1 | 1 | 0 | 0 |\n"+
+		"\n<details>\n<summary>リネーム（1 件）</summary>\n\n| 変更前 | 変更後 |\n|---|---|\n"+
+		"| `apps/web/components/Button.tsx` | `apps/web/components/button.tsx` |\n"+
+		"\n</details>\n"+
+		"\n移植性の問題: 1 件\n\n| パス | 種類 | 内容 |\n|---|---|---|\n"+
+		"| `apps/web/components/con.tsx` | reserved-name | Windows の予約名です |\n",
+		s.write(FormatMarkdown, s.planReport()))
+}
+
+func (s *ReportTestSuite) TestUnused() {
+	report := s.engine.NewUnusedReport([]UnusedModule{{Path: "apps/web/components/Orphan.tsx", Workspace: "apps/web"}})
+	s.Equal(1, report.Summary.Unused)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 1)
+	s.Equal(RuleUnused, result.Suites[0].Name)
+	s.Equal(1, result.Failures)
+
+	s.Equal("## rename-script unused\n"+
+		"\n未使用のモジュール: 1 件\n\n| パス | ワークスペース |\n|---|---|\n"+
+		"| `apps/web/components/Orphan.tsx` | apps/web |\n",
+		s.write(FormatMarkdown, report))
+}
+
+func (s *ReportTestSuite) TestExportNames() {
+	report := s.engine.NewExportNameReport([]ExportNameMismatch{{
+		Path: "apps/web/components/user-card.tsx", Symbol: "ProfileTile", Line: 3, Default: true, Expected: "UserCard",
+		SuggestedPath: "apps/web/components/profile-tile.tsx", SuggestedSymbol: "UserCard",
+	}})
+	s.Equal(1, report.Summary.ExportNames)
+	s.Equal("3 行目の default エクスポート ProfileTile がファイル名（UserCard）と一致しません"+
+		"（apps/web/components/profile-tile.tsx に名前を変更するか、識別子を UserCard に変更してください）", report.Findings()[0].Message)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 1)
+	s.Equal(RuleExportName, result.Suites[0].Name)
+	s.Equal(1, result.Failures)
+
+	s.Equal("## rename-script export-names\n"+
+		"\nファイル名と一致しないエクスポート: 1 件\n\n| パス | 識別子 | ファイル名の変更 | 識別子の変更 |\n|---|---|---|---|\n"+
+		"| `apps/web/components/user-card.tsx:3` | `ProfileTile` | `apps/web/components/profile-tile.tsx` | `UserCard` |\n",
+		s.write(FormatMarkdown, report))
+}
+
+func (s *ReportTestSuite) TestCycles() {
+	report := s.engine.NewCheckReport(nil, 0)
+	report.AddCycles([]ImportCycle{{
+		Files: []string{"apps/web/lib/a.ts", "apps/web/lib/b.ts"},
+		Edges: []GraphEdge{
+			{From: "apps/web/lib/a.ts", To: "apps/web/lib/b.ts", Specifier: "./b", Line: 1},
+			{From: "apps/web/lib/b.ts", To: "apps/web/lib/a.ts", Specifier: "./a", Line: 3},
+		},
+	}}, 2)
+	s.Equal(1, report.Summary.Cycles)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 2)
+	s.Equal(RuleCycle, result.Suites[1].Name)
+	s.Equal("apps/web/lib/a.ts", result.Suites[1].Cases[0].Name)
+	s.Equal("2 件のファイルがインポートで循環しています（apps/web/lib/a.ts:1 → apps/web/lib/b.ts, apps/web/lib/b.ts:3 → apps/web/lib/a.ts）",
+		result.Suites[1].Cases[0].Failure.Message)
+
+	s.Contains(s.write(FormatMarkdown, report), "\n循環しているインポート: 1 件\n"+
+		"\n1. 2 ファイル\n\n| インポート | 参照先 |\n|---|---|\n"+
+		"| `apps/web/lib/a.ts:1` | `apps/web/lib/b.ts` |\n"+
+		"| `apps/web/lib/b.ts:3` | `apps/web/lib/a.ts` |\n"+
+		"\nベースラインに記録済みの循環: 2 件\n")
+}
+
+func (s *ReportTestSuite) TestBoundaries() {
+	report := s.engine.NewBoundaryReport([]BoundaryViolation{
+		{Kind: BoundaryImport, File: "apps/web/app/page.tsx", Line: 2, Specifier: "../../admin/lib/skills", Target: "apps/admin/lib/skills.ts", FromWorkspace: "apps/web", ToWorkspace: "apps/admin", Rule: "apps/* → packages/*"},
+		{Kind: BoundaryInclude, File: "apps/web/tsconfig.json", Specifier: "../admin/lib/skills.ts", Target: "apps/admin/lib/skills.ts", FromWorkspace: "apps/web", ToWorkspace: "apps/admin"},
+	})
+	s.Equal(2, report.Summary.Boundaries)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 1)
+	s.Equal(RuleBoundary, result.Suites[0].Name)
+	s.Equal(2, result.Failures)
+	s.Equal(RuleBoundary+"/"+BoundaryInclude, result.Suites[0].Cases[1].ClassName)
+
+	s.Equal("## rename-script boundaries\n"+
+		"\nワークスペースの境界の違反: 2 件\n\n| ファイル | 指定子 | 参照先 | 規則 |\n|---|---|---|---|\n"+
+		"| `apps/web/app/page.tsx:2` | `../../admin/lib/skills` | `apps/admin/lib/skills.ts` | apps/* → packages/* |\n"+
+		"| `apps/web/tsconfig.json` | `../admin/lib/skills.ts` | `apps/admin/lib/skills.ts` | tsconfig の include |\n",
+		s.write(FormatMarkdown, report))
+}
+
+func (s *ReportTestSuite) TestMove() {
+	report := s.engine.NewMoveReport(&MoveResult{
+		From:  "apps/web/components/Button.tsx",
+		To:    "packages/ui/src/custom/button.tsx",
+		Files: 1,
+		Rewrites: []SpecifierRewrite{
+			{File: "apps/web/app/page.tsx", Line: 1, From: "~/components/Button", To: "@kit/ui/custom/button", Kind: ResolveKindWorkspace},
+			{File: "apps/web/lib/utils.ts", Line: 1, From: "../components/Button", To: "@kit/ui/custom/button", Kind: ResolveKindWorkspace},
+		},
+		Warnings: []string{"packages/ui/src/custom/button.tsx:1: '../lib/utils'（apps/web/lib/utils.ts をパッケージ名やエイリアスで参照できないため書き換えていません。package.json の dependencies と exports を確認してください）"},
+	})
+	s.Equal(1, report.Summary.Renames)
+	s.Equal(2, report.Summary.ImportUpdates)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 1)
+	s.Equal(RuleMove, result.Suites[0].Name)
+	s.Equal(1, result.Failures, "警告だけを失敗として出力する")
+
+	s.Equal("## rename-script mv\n"+
+		"\n`apps/web/components/Button.tsx` → `packages/ui/src/custom/button.tsx`（1 ファイル）\n"+
+		"\n書き換えた参照: 2 件\n\n| ファイル | 変更前 | 変更後 |\n|---|---|---|\n"+
+		"| `apps/web/app/page.tsx:1` | `~/components/Button` | `@kit/ui/custom/button` |\n"+
+		"| `apps/web/lib/utils.ts:1` | `../components/Button` | `@kit/ui/custom/button` |\n"+
+		"\n確認が必要な書き換え: 1 件\n\n"+
+		"- packages/ui/src/custom/button.tsx:1: '../lib/utils'（apps/web/lib/utils.ts をパッケージ名やエイリアスで参照できないため書き換えていません。package.json の dependencies と exports を確認してください）\n",
+		s.write(FormatMarkdown, report))
+}
+
+func (s *ReportTestSuite) TestRemap() {
+	s.engine.SetDryRun(true)
+	report := s.engine.NewRemapReport(&RemapResult{
+		Files: 3,
+		Rewrites: []SpecifierRewrite{
+			{File: "apps/web/app/page.tsx", Line: 1, From: "@kit/ui/old-header", To: "@kit/ui/page-header", Rule: "'@kit/ui/old-header' → '@kit/ui/page-header'"},
+			{File: "apps/web/app/page.tsx", Line: 4, From: "~/legacy/button", To: "~/components/button", Rule: "prefix '~/legacy' → '~/components'"},
+		},
+		UnusedRules: []string{"regex '^@kit/ui/(.*)-v2$' → '@kit/ui/$1'"},
+	})
+	s.Equal(1, report.Summary.ImportUpdates)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 1)
+	s.Equal(RuleRemap, result.Suites[0].Name)
+	s.Equal(1, result.Failures, "一致しなかった規則だけを失敗として出力する")
+
+	s.Equal("## rename-script rewrite-imports ドライラン\n"+
+		"\n書き換えた指定子: 2 件（3 ファイルを検査）\n\n| ファイル | 変更前 | 変更後 | 規則 |\n|---|---|---|---|\n"+
+		"| `apps/web/app/page.tsx:1` | `@kit/ui/old-header` | `@kit/ui/page-header` | '@kit/ui/old-header' → '@kit/ui/page-header' |\n"+
+		"| `apps/web/app/page.tsx:4` | `~/legacy/button` | `~/components/button` | prefix '~/legacy' → '~/components' |\n"+
+		"\nどの指定子にも一致しなかった規則: 1 件\n\n"+
+		"- regex '^@kit/ui/(.*)-v2$' → '@kit/ui/$1'\n",
+		s.write(FormatMarkdown, report))
+}
+
+func (s *ReportTestSuite) TestPackageRename() {
+	report := s.engine.NewPackageRenameReport(&PackageRenameResult{
+		From:      "@kit/features",
+		To:        "@kit/feature-auth",
+		Workspace: "packages/features/auth",
+		Manifests: []SpecifierRewrite{{File: "packages/features/auth/package.json", Line: 2, From: "@kit/features", To: "@kit/feature-auth", Kind: "name"}},
+		TSConfigs: []SpecifierRewrite{},
+		Imports:   []SpecifierRewrite{{File: "apps/web/app/page.tsx", Line: 1, From: "@kit/features/form", To: "@kit/feature-auth/form"}},
+		Remaining: []string{"apps/web/next.config.mjs:1"},
+	})
+	s.Equal(1, report.Summary.Renames)
+	s.Equal(1, report.Summary.ImportUpdates)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 1)
+	s.Equal(RulePackage, result.Suites[0].Name)
+	s.Equal(1, result.Failures, "書き換えていない参照だけを失敗として出力する")
+	s.Equal("apps/web/next.config.mjs", result.Suites[0].Cases[0].Name)
+
+	s.Equal("## rename-script rename-package\n"+
+		"\n`@kit/features` → `@kit/feature-auth`（`packages/features/auth`）\n"+
+		"\n書き換え: 2 件\n\n| ファイル | 項目 | 変更前 | 変更後 |\n|---|---|---|---|\n"+
+		"| `packages/features/auth/package.json:2` | name | `@kit/features` | `@kit/feature-auth` |\n"+
+		"| `apps/web/app/page.tsx:1` | import | `@kit/features/form` | `@kit/feature-auth/form` |\n"+
+		"\nインポート以外で `@kit/features` を参照している箇所（書き換えていません）: 1 件\n\n"+
+		"- `apps/web/next.config.mjs:1`\n",
+		s.write(FormatMarkdown, report))
+}
+
+func (s *ReportTestSuite) TestHoist() {
+	report := s.engine.NewHoistReport(&HoistResult{
+		From:      "apps/web/components/AppLogo.tsx",
+		To:        "packages/ui/src/custom/app-logo",
+		Package:   "@kit/ui",
+		Specifier: "@kit/ui/app-logo",
+		Files:     []HoistedFile{{From: "apps/web/components/AppLogo.tsx", To: "packages/ui/src/custom/app-logo/index.tsx"}},
+		Exports:   []string{`packages/ui/package.json: "./app-logo": "./src/custom/app-logo/index.tsx"`},
+		Rewrites:  []SpecifierRewrite{{File: "apps/web/app/page.tsx", Line: 1, From: "~/components/AppLogo", To: "@kit/ui/app-logo", Kind: ResolveKindWorkspace}},
+		Warnings:  []string{"apps/web/components/AppLogo.tsx:2: '@kit/shared'（@kit/ui は @kit/shared に依存していません）"},
+	})
+	s.Equal(1, report.Summary.Renames)
+	s.Equal(1, report.Summary.ImportUpdates)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 1)
+	s.Equal(RuleHoist, result.Suites[0].Name)
+	s.Equal(1, result.Failures, "警告だけを失敗として出力する")
+
+	s.Equal("## rename-script hoist\n"+
+		"\n`apps/web/components/AppLogo.tsx` → `packages/ui/src/custom/app-logo`（`@kit/ui/app-logo`）\n"+
+		"\n| 移動元 | 移動先 |\n|---|---|\n"+
+		"| `apps/web/components/AppLogo.tsx` | `packages/ui/src/custom/app-logo/index.tsx` |\n"+
+		"\n追加した exports: 1 件\n\n"+
+		"- `packages/ui/package.json: \"./app-logo\": \"./src/custom/app-logo/index.tsx\"`\n"+
+		"\n書き換えた参照: 1 件\n\n| ファイル | 変更前 | 変更後 |\n|---|---|---|\n"+
+		"| `apps/web/app/page.tsx:1` | `~/components/AppLogo` | `@kit/ui/app-logo` |\n"+
+		"\n確認が必要な項目: 1 件\n\n"+
+		"- apps/web/components/AppLogo.tsx:2: '@kit/shared'（@kit/ui は @kit/shared に依存していません）\n",
+		s.write(FormatMarkdown, report))
+}
+
+func (s *ReportTestSuite) TestNormalize() {
+	report := s.engine.NewNormalizeReport(&NormalizeResult{
+		Files: 3,
+		Rewrites: []SpecifierRewrite{
+			{File: "apps/web/app/settings/page.tsx", Line: 1, From: "../../../components/Button", To: "~/components/Button", Kind: ResolveKindAlias, Rule: "親ディレクトリが 2 階層を超える相対パス"},
+			{File: "apps/web/app/settings/page.tsx", Line: 2, From: "../../../lib/utils", To: "~/lib/utils", Kind: ResolveKindAlias, Rule: "親ディレクトリが 2 階層を超える相対パス"},
+		},
+	})
+	s.Equal(1, report.Summary.ImportUpdates)
+	s.Len(report.Findings(), 2)
+	s.Equal(LevelNote, report.Findings()[0].Level)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 1)
+	s.Equal(RuleNormalize, result.Suites[0].Name)
+	s.Equal(0, result.Failures)
+
+	s.Equal("## rename-script normalize-imports\n"+
+		"\n書き換えた指定子: 2 件（3 ファイルを検査）\n\n| ファイル | 変更前 | 変更後 | 理由 |\n|---|---|---|---|\n"+
+		"| `apps/web/app/settings/page.tsx:1` | `../../../components/Button` | `~/components/Button` | 親ディレクトリが 2 階層を超える相対パス |\n"+
+		"| `apps/web/app/settings/page.tsx:2` | `../../../lib/utils` | `~/lib/utils` | 親ディレクトリが 2 階層を超える相対パス |\n",
+		s.write(FormatMarkdown, report))
+}
+
+func (s *ReportTestSuite) TestBarrels() {
+	results := []BarrelResult{
+		{File: "packages/ui/src/hooks/index.ts", Outdated: true, Added: []string{"./use-theme"}, Removed: []string{"./use-old"}},
+		{File: "apps/web/components/forms/index.ts", Missing: []string{"7: ./Legacy"}},
+		{File: "apps/web/components/index.ts"},
+	}
+	report := s.engine.NewBarrelReport(true, results)
+	s.Len(report.Barrels, 2, "問題のないバレルは含めない")
+	findings := report.Findings()
+	s.Require().Len(findings, 2)
+	s.Equal(LevelWarning, findings[0].Level)
+	s.Equal(LevelError, findings[1].Level)
+	s.Equal("7 行目の './Legacy' は存在しないファイルを指しています", findings[1].Message)
+
+	var result junitTestSuites
+	s.Require().NoError(xml.Unmarshal([]byte(s.write(FormatJUnit, report)), &result))
+	s.Require().Len(result.Suites, 1)
+	s.Equal(RuleBarrel, result.Suites[0].Name)
+	s.Equal(2, result.Failures)
+
+	s.Equal("## rename-script barrels check\n"+
+		"\nバレル: 2 件\n\n| ファイル | 追加 | 削除 | 存在しないファイル |\n|---|---|---|---|\n"+
+		"| `packages/ui/src/hooks/index.ts` | `./use-theme` | `./use-old` |  |\n"+
+		"| `apps/web/components/forms/index.ts` |  |  | `7: ./Legacy` |\n",
+		s.write(FormatMarkdown, report))
+
+	report = s.engine.NewBarrelReport(false, results[:1])
+	s.Equal(1, report.Summary.ImportUpdates)
+	s.Equal(LevelNote, report.Findings()[0].Level)
+	s.Equal("バレルを更新しました", report.Findings()[0].Message)
+}
+
+// 各コマンドのレポートは、JUnit のテストスイートとなるルールを持つセクションを 1 つだけ含む
+func (s *ReportTestSuite) TestSections() {
+	for rule, report := range map[string]*Report{
+		RuleNaming:     s.planReport(),
+		RuleUnused:     s.engine.NewUnusedReport(nil),
+		RuleExportName: s.engine.NewExportNameReport(nil),
+		RuleBoundary:   s.engine.NewBoundaryReport(nil),
+		RuleMove:       s.engine.NewMoveReport(&MoveResult{}),
+		RuleRemap:      s.engine.NewRemapReport(&RemapResult{}),
+		RulePackage:    s.engine.NewPackageRenameReport(&PackageRenameResult{}),
+		RuleHoist:      s.engine.NewHoistReport(&HoistResult{}),
+		RuleNormalize:  s.engine.NewNormalizeReport(&NormalizeResult{}),
+		RuleBarrel:     s.engine.NewBarrelReport(true, nil),
+	} {
+		var rules []string
+		for _, section := range report.sections() {
+			if section.rule() != "" {
+				rules = append(rules, section.rule())
+			}
+		}
+		s.Equal([]string{rule}, rules, report.Command)
+	}
+	s.Empty(s.engine.NewAnalyzeReport(&ProjectStructure{}).Findings())
+}
+
+func (s *ReportTestSuite) TestUnknownFormat() {
+	s.Error(WriteReport(&bytes.Buffer{}, "yaml", s.planReport()))
+}
+
+func TestReportSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
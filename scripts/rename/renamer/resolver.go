@@ -0,0 +1,439 @@
+package renamer
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ResolveStatus はモジュール指定子の解決結果の種類です
+type ResolveStatus int
+
+const (
+	// ワークスペース外のパッケージ（node_modules など）で、検証の対象外
+	ResolveExternal ResolveStatus = iota
+	// 解決できた
+	ResolveOK
+	// 大文字小文字を区別しない場合のみ解決できた（Linux の CI などでは失敗する）
+	ResolveCaseMismatch
+	// 解決できなかった
+	ResolveUnresolved
+)
+
+// String は解決結果の種類を表す文字列を返します
+func (s ResolveStatus) String() string {
+	switch s {
+	case ResolveOK:
+		return "ok"
+	case ResolveCaseMismatch:
+		return "case-mismatch"
+	case ResolveUnresolved:
+		return "unresolved"
+	default:
+		return "external"
+	}
+}
+
+// 解決方法の種類
+const (
+	ResolveKindRelative  = "relative"
+	ResolveKindAlias     = "alias"
+	ResolveKindWorkspace = "workspace"
+	ResolveKindBaseURL   = "baseUrl"
+)
+
+// Resolution はモジュール指定子の解決結果です
+type Resolution struct {
+	Status ResolveStatus
+	// 解決方法（relative / alias / workspace / baseUrl、外部パッケージの場合は空）
+	Kind string
+	// 解決先の絶対パス（実際の大文字小文字）。ビルド成果物を指す exports の場合は空
+	Path string
+	// 大文字小文字が一致しない場合の修正後の指定子
+	Suggestion string
+}
+
+// 拡張子を省略した指定子に補う拡張子（TypeScript の解決順）
+var resolveExtensions = []string{".ts", ".tsx", ".d.ts", ".js", ".jsx", ".mjs", ".cjs", ".json"}
+
+// exports の解決先が存在しなくても解決済みとみなすビルド成果物のディレクトリ
+var buildOutputDirs = []string{"dist", "build"}
+
+// Resolver は相対パス・tsconfig の paths・ワークスペースパッケージの規則でモジュール指定子を解決します
+// ディレクトリの内容はキャッシュされるため、ファイルを変更した後は新しい Resolver を作成してください
+type Resolver struct {
+	fs         FS
+	root       string
+	workspaces []*Workspace
+
+	mu        sync.Mutex
+	tsconfigs map[string]*TSConfig
+	dirs      map[string]*dirListing
+}
+
+// ディレクトリの内容
+type dirListing struct {
+	// 名前 → ディレクトリかどうか
+	entries map[string]bool
+	// 小文字の名前 → 実際の名前
+	lower map[string][]string
+}
+
+// NewResolver はプロジェクトルートのワークスペース設定を読み込んだ Resolver を作成します
+func NewResolver(fsys FS, root string) (*Resolver, error) {
+	workspaces, err := LoadWorkspaces(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+	return &Resolver{
+		fs:         fsys,
+		root:       root,
+		workspaces: workspaces,
+		tsconfigs:  make(map[string]*TSConfig),
+		dirs:       make(map[string]*dirListing),
+	}, nil
+}
+
+// Workspaces はワークスペースの一覧を返します
+func (r *Resolver) Workspaces() []*Workspace {
+	return r.workspaces
+}
+
+// WorkspaceOf はパスを含むワークスペースを返します（どのワークスペースにも含まれない場合は nil）
+func (r *Resolver) WorkspaceOf(path string) *Workspace {
+	var found *Workspace
+	for _, ws := range r.workspaces {
+		if path == ws.Path || strings.HasPrefix(path, ws.Path+string(filepath.Separator)) {
+			if found == nil || len(ws.Path) > len(found.Path) {
+				found = ws
+			}
+		}
+	}
+	return found
+}
+
+// WorkspaceByName はパッケージ名からワークスペースを返します
+func (r *Resolver) WorkspaceByName(name string) *Workspace {
+	for _, ws := range r.workspaces {
+		if ws.Name == name {
+			return ws
+		}
+	}
+	return nil
+}
+
+// Resolve は fromFile の中に書かれたモジュール指定子を解決します
+func (r *Resolver) Resolve(fromFile, spec string) Resolution {
+	// クエリ（?raw など）は解決に関係しない
+	if i := strings.IndexAny(spec, "?#"); i > 0 {
+		spec = spec[:i]
+	}
+
+	if spec == "." || spec == ".." || strings.HasPrefix(spec, "./") || strings.HasPrefix(spec, "../") {
+		base := filepath.Join(filepath.Dir(fromFile), filepath.FromSlash(spec))
+		return r.resolveTo(ResolveKindRelative, spec, relativeTail(spec), []string{base})
+	}
+	if strings.HasPrefix(spec, "/") || strings.Contains(spec, ":") {
+		return Resolution{Status: ResolveExternal}
+	}
+
+	config := r.TSConfigFor(filepath.Dir(fromFile))
+	if targets, capture, ok := config.MatchPaths(spec); ok {
+		res := r.resolveTo(ResolveKindAlias, spec, len(capture), targets)
+		if res.Status == ResolveUnresolved && inNodeModules(targets) {
+			// node_modules を指すエイリアス（型定義の差し替えなど）は外部パッケージとして扱う
+			return Resolution{Status: ResolveExternal}
+		}
+		return res
+	}
+
+	if ws, subpath := r.matchWorkspace(spec); ws != nil {
+		return r.resolveWorkspace(ws, spec, subpath)
+	}
+
+	if config != nil && config.BaseURL != "" {
+		res := r.resolveTo(ResolveKindBaseURL, spec, len(spec), []string{filepath.Join(config.BaseURL, filepath.FromSlash(spec))})
+		if res.Status != ResolveUnresolved {
+			return res
+		}
+	}
+	return Resolution{Status: ResolveExternal}
+}
+
+// ResolveFile は拡張子の補完とディレクトリの index を考慮して base の実際のファイルを探します
+// 大文字小文字が一致するファイルを優先し、一致しない場合は exact が false になります
+func (r *Resolver) ResolveFile(base string) (path string, exact bool, ok bool) {
+	path, _, exact, ok = r.resolveFile(base)
+	return path, exact, ok
+}
+
+// TSConfigFor はディレクトリに適用される（最も近い）tsconfig.json を返します
+func (r *Resolver) TSConfigFor(dir string) *TSConfig {
+	r.mu.Lock()
+	if config, ok := r.tsconfigs[dir]; ok {
+		r.mu.Unlock()
+		return config
+	}
+	r.mu.Unlock()
+
+	var config *TSConfig
+	path := filepath.Join(dir, "tsconfig.json")
+	if exists(r.fs, path) {
+		loaded, err := LoadTSConfig(r.fs, path, r.workspaces)
+		if err == nil {
+			config = loaded
+		}
+	} else if dir != r.root && strings.HasPrefix(dir, r.root) {
+		config = r.TSConfigFor(filepath.Dir(dir))
+	}
+
+	r.mu.Lock()
+	r.tsconfigs[dir] = config
+	r.mu.Unlock()
+	return config
+}
+
+// 指定子に一致するワークスペースと、exports のサブパス（"." または "./xxx"）を返す
+func (r *Resolver) matchWorkspace(spec string) (*Workspace, string) {
+	var found *Workspace
+	for _, ws := range r.workspaces {
+		if ws.Name == "" {
+			continue
+		}
+		if spec == ws.Name || strings.HasPrefix(spec, ws.Name+"/") {
+			if found == nil || len(ws.Name) > len(found.Name) {
+				found = ws
+			}
+		}
+	}
+	if found == nil {
+		return nil, ""
+	}
+	if spec == found.Name {
+		return found, "."
+	}
+	return found, "./" + strings.TrimPrefix(spec, found.Name+"/")
+}
+
+// ワークスペースパッケージの指定子を解決する
+func (r *Resolver) resolveWorkspace(ws *Workspace, spec, subpath string) Resolution {
+	if ws.Exports == nil {
+		// exports がない場合はパッケージのディレクトリからの相対パスとして解決する
+		var bases []string
+		if subpath == "." {
+			for _, entry := range []string{ws.Types, ws.Module, ws.Main} {
+				if entry != "" {
+					bases = append(bases, filepath.Join(ws.Path, filepath.FromSlash(entry)))
+				}
+			}
+			bases = append(bases, ws.Path)
+		} else {
+			bases = []string{filepath.Join(ws.Path, filepath.FromSlash(subpath))}
+		}
+		res := r.resolveTo(ResolveKindWorkspace, spec, len(subpath)-2, bases)
+		if res.Status == ResolveUnresolved && subpath == "." {
+			// main がビルド成果物を指している場合
+			return Resolution{Status: ResolveOK, Kind: ResolveKindWorkspace}
+		}
+		return res
+	}
+
+	targets, ok := ws.MatchExport(subpath)
+	if !ok {
+		// exports のキーは大文字小文字を区別するため、一致しない場合は修正候補を提示する
+		for key := range ws.Exports {
+			if !strings.Contains(key, "*") && strings.EqualFold(key, subpath) {
+				suggestion := ws.Name
+				if key != "." {
+					suggestion += "/" + strings.TrimPrefix(key, "./")
+				}
+				return Resolution{Status: ResolveCaseMismatch, Kind: ResolveKindWorkspace, Suggestion: suggestion}
+			}
+		}
+		return Resolution{Status: ResolveUnresolved, Kind: ResolveKindWorkspace}
+	}
+
+	var bases []string
+	for _, target := range targets {
+		bases = append(bases, filepath.Join(ws.Path, filepath.FromSlash(target)))
+	}
+	res := r.resolveTo(ResolveKindWorkspace, spec, 0, bases)
+	if res.Status == ResolveUnresolved {
+		for _, target := range targets {
+			first := strings.SplitN(strings.TrimPrefix(target, "./"), "/", 2)[0]
+			if contains(buildOutputDirs, first) {
+				return Resolution{Status: ResolveOK, Kind: ResolveKindWorkspace}
+			}
+		}
+	}
+	return res
+}
+
+// 候補のいずれかに解決する。tail は指定子の末尾のうち、大文字小文字の修正対象となる文字数
+func (r *Resolver) resolveTo(kind, spec string, tail int, bases []string) Resolution {
+	var mismatch *Resolution
+	for _, base := range bases {
+		path, suffix, exact, ok := r.resolveFile(base)
+		if !ok {
+			continue
+		}
+		if exact {
+			return Resolution{Status: ResolveOK, Kind: kind, Path: path}
+		}
+		if mismatch == nil {
+			mismatch = &Resolution{Status: ResolveCaseMismatch, Kind: kind, Path: path}
+			// 補完した拡張子や index を除いた実際のパスの末尾で指定子の末尾を置き換える
+			actualBase := path[:len(path)-len(suffix)]
+			if tail > 0 && tail <= len(spec) && tail <= len(actualBase) {
+				fixed := spec[:len(spec)-tail] + filepath.ToSlash(actualBase[len(actualBase)-tail:])
+				if fixed != spec && strings.EqualFold(fixed, spec) {
+					mismatch.Suggestion = fixed
+				}
+			}
+		}
+	}
+	if mismatch != nil {
+		return *mismatch
+	}
+	return Resolution{Status: ResolveUnresolved, Kind: kind}
+}
+
+// base を実際のファイルに解決し、補完した接尾辞（拡張子や /index.ts）を返す
+func (r *Resolver) resolveFile(base string) (path, suffix string, exact, ok bool) {
+	var candidates []string
+	candidates = append(candidates, "")
+	for _, ext := range resolveExtensions {
+		candidates = append(candidates, ext)
+	}
+	// TypeScript では .js の指定子で .ts ファイルを参照できる
+	var swapped []string
+	for _, pair := range [][2]string{{".js", ".ts"}, {".js", ".tsx"}, {".jsx", ".tsx"}, {".mjs", ".mts"}, {".cjs", ".cts"}} {
+		if strings.HasSuffix(base, pair[0]) {
+			swapped = append(swapped, strings.TrimSuffix(base, pair[0])+pair[1])
+		}
+	}
+	for _, ext := range resolveExtensions {
+		candidates = append(candidates, string(filepath.Separator)+"index"+ext)
+	}
+
+	var firstMismatch, mismatchSuffix string
+	try := func(candidate, suffix string) bool {
+		actual, exactCase, isDir, found := r.lookup(candidate)
+		if !found || isDir {
+			return false
+		}
+		if exactCase {
+			path = actual
+			return true
+		}
+		if firstMismatch == "" {
+			firstMismatch, mismatchSuffix = actual, suffix
+		}
+		return false
+	}
+	for _, candidate := range candidates {
+		if try(base+candidate, candidate) {
+			return path, candidate, true, true
+		}
+	}
+	for _, candidate := range swapped {
+		if try(candidate, "") {
+			return path, "", true, true
+		}
+	}
+	if firstMismatch != "" {
+		return firstMismatch, mismatchSuffix, false, true
+	}
+	return "", "", false, false
+}
+
+// パスをディレクトリの内容と照合し、実際の大文字小文字のパスを返す
+func (r *Resolver) lookup(path string) (actual string, exact, isDir, found bool) {
+	path = filepath.Clean(path)
+	rel, err := filepath.Rel(r.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		// プロジェクト外のパスは大文字小文字を照合しない
+		info, err := r.fs.Stat(path)
+		if err != nil {
+			return "", false, false, false
+		}
+		return path, true, info.IsDir(), true
+	}
+	if rel == "." {
+		return r.root, true, true, true
+	}
+
+	current := r.root
+	exact, isDir = true, true
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		if !isDir {
+			return "", false, false, false
+		}
+		listing := r.listDir(current)
+		if listing == nil {
+			return "", false, false, false
+		}
+		if dir, ok := listing.entries[name]; ok {
+			current, isDir = filepath.Join(current, name), dir
+			continue
+		}
+		matches := listing.lower[strings.ToLower(name)]
+		if len(matches) == 0 {
+			return "", false, false, false
+		}
+		exact = false
+		current, isDir = filepath.Join(current, matches[0]), listing.entries[matches[0]]
+	}
+	return current, exact, isDir, true
+}
+
+// ディレクトリの内容を読み込む（キャッシュする）
+func (r *Resolver) listDir(dir string) *dirListing {
+	r.mu.Lock()
+	listing, ok := r.dirs[dir]
+	r.mu.Unlock()
+	if ok {
+		return listing
+	}
+
+	if entries, err := r.fs.ReadDir(dir); err == nil {
+		listing = &dirListing{entries: make(map[string]bool), lower: make(map[string][]string)}
+		for _, entry := range entries {
+			listing.entries[entry.Name()] = entry.IsDir()
+			lower := strings.ToLower(entry.Name())
+			listing.lower[lower] = append(listing.lower[lower], entry.Name())
+		}
+	}
+
+	r.mu.Lock()
+	r.dirs[dir] = listing
+	r.mu.Unlock()
+	return listing
+}
+
+// 相対パスの指定子から ./ と ../ を除いた末尾の文字数
+func relativeTail(spec string) int {
+	rest := spec
+	for {
+		switch {
+		case strings.HasPrefix(rest, "./"):
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "../"):
+			rest = rest[3:]
+		case rest == "." || rest == "..":
+			return 0
+		default:
+			return len(rest)
+		}
+	}
+}
+
+// すべてのパスが node_modules 配下かどうか
+func inNodeModules(paths []string) bool {
+	for _, path := range paths {
+		if !strings.Contains(filepath.ToSlash(path)+"/", "/node_modules/") {
+			return false
+		}
+	}
+	return len(paths) > 0
+}
@@ -0,0 +1,186 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// テスト用のモノレポ（パスはプロジェクトルートからの相対パス）
+var testMonorepoFiles = map[string]string{
+	"package.json": `{"name": "root", "workspaces": ["apps/*", "packages/*", "tooling/*"]}`,
+
+	"tooling/typescript/package.json": `{"name": "@kit/tsconfig"}`,
+	"tooling/typescript/base.json":    `{"compilerOptions": {"strict": true}}`,
+
+	"apps/web/package.json": `{"name": "web", "dependencies": {"@kit/ui": "workspace:*"}}`,
+	"apps/web/tsconfig.json": `{
+  // コメントと末尾のカンマを含む
+  "extends": "@kit/tsconfig/base.json",
+  "compilerOptions": {
+    "baseUrl": ".",
+    "paths": {
+      "~/*": ["./app/*"],
+      "~/components/*": ["./components/*"],
+      "react": ["./node_modules/@types/react"],
+    },
+  },
+}`,
+	"apps/web/app/page.tsx":                  "",
+	"apps/web/app/layout.tsx":                "",
+	"apps/web/components/Button.tsx":         "",
+	"apps/web/components/user-card/index.ts": "",
+	"apps/web/lib/utils.ts":                  "",
+
+	"packages/ui/package.json": `{
+  "name": "@kit/ui",
+  "exports": {
+    "./page-header": "./src/custom/page-header/index.tsx",
+    "./utils": {"types": "./src/lib/utils.ts", "default": "./src/lib/utils.ts"},
+    "./hooks/*": "./src/hooks/*.ts",
+    "./built": {"types": "./dist/built.d.ts", "import": "./dist/built.mjs"}
+  }
+}`,
+	"packages/ui/tsconfig.json":                    `{"compilerOptions": {"paths": {"~/custom/*": ["./src/custom/*"]}}}`,
+	"packages/ui/src/custom/page-header/index.tsx": "",
+	"packages/ui/src/lib/utils.ts":                 "",
+	"packages/ui/src/hooks/use-toast.ts":           "",
+
+	"packages/shared/package.json":  `{"name": "@kit/shared", "main": "./src/index.ts"}`,
+	"packages/shared/src/index.ts":  "",
+	"packages/shared/src/logger.ts": "",
+}
+
+// メモリ上にファイルを作成する
+func writeTestFiles(s *suite.Suite, fsys FS, root string, files map[string]string) {
+	for path, content := range files {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		s.Require().NoError(fsys.MkdirAll(filepath.Dir(full), 0755))
+		s.Require().NoError(fsys.WriteFile(full, []byte(content), 0644))
+	}
+}
+
+type ResolverTestSuite struct {
+	suite.Suite
+	fs       *MemFS
+	root     string
+	resolver *Resolver
+}
+
+func (s *ResolverTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+
+	resolver, err := NewResolver(s.fs, s.root)
+	s.Require().NoError(err)
+	s.resolver = resolver
+}
+
+func (s *ResolverTestSuite) path(rel string) string {
+	return filepath.Join(s.root, filepath.FromSlash(rel))
+}
+
+func (s *ResolverTestSuite) TestLoadWorkspaces() {
+	var names []string
+	for _, ws := range s.resolver.Workspaces() {
+		names = append(names, ws.Dir+"="+ws.Name)
+	}
+	s.Equal([]string{
+		"apps/web=web",
+		"packages/shared=@kit/shared",
+		"packages/ui=@kit/ui",
+		"tooling/typescript=@kit/tsconfig",
+	}, names)
+
+	s.Equal("web", s.resolver.WorkspaceOf(s.path("apps/web/app/page.tsx")).Name)
+	s.Nil(s.resolver.WorkspaceOf(s.path("scripts/tool.ts")))
+	s.True(s.resolver.WorkspaceByName("web").DependsOn("@kit/ui"))
+}
+
+func (s *ResolverTestSuite) TestResolve() {
+	from := s.path("apps/web/app/page.tsx")
+	tests := []struct {
+		name   string
+		spec   string
+		status ResolveStatus
+		kind   string
+		path   string
+	}{
+		{"相対パス（拡張子の補完）", "./layout", ResolveOK, ResolveKindRelative, "apps/web/app/layout.tsx"},
+		{"相対パス（親ディレクトリ）", "../components/Button", ResolveOK, ResolveKindRelative, "apps/web/components/Button.tsx"},
+		{"相対パス（index）", "../components/user-card", ResolveOK, ResolveKindRelative, "apps/web/components/user-card/index.ts"},
+		{"相対パス（存在しない）", "./missing", ResolveUnresolved, ResolveKindRelative, ""},
+		{"エイリアス", "~/components/Button", ResolveOK, ResolveKindAlias, "apps/web/components/Button.tsx"},
+		{"エイリアス（~/*）", "~/layout", ResolveOK, ResolveKindAlias, "apps/web/app/layout.tsx"},
+		{"エイリアス（存在しない）", "~/components/Missing", ResolveUnresolved, ResolveKindAlias, ""},
+		{"baseUrl", "lib/utils", ResolveOK, ResolveKindBaseURL, "apps/web/lib/utils.ts"},
+		{"ワークスペース（exports）", "@kit/ui/page-header", ResolveOK, ResolveKindWorkspace, "packages/ui/src/custom/page-header/index.tsx"},
+		{"ワークスペース（条件付き exports）", "@kit/ui/utils", ResolveOK, ResolveKindWorkspace, "packages/ui/src/lib/utils.ts"},
+		{"ワークスペース（ワイルドカード）", "@kit/ui/hooks/use-toast", ResolveOK, ResolveKindWorkspace, "packages/ui/src/hooks/use-toast.ts"},
+		{"ワークスペース（ビルド成果物）", "@kit/ui/built", ResolveOK, ResolveKindWorkspace, ""},
+		{"ワークスペース（exports にない）", "@kit/ui/missing", ResolveUnresolved, ResolveKindWorkspace, ""},
+		{"ワークスペース（main）", "@kit/shared", ResolveOK, ResolveKindWorkspace, "packages/shared/src/index.ts"},
+		{"ワークスペース（exports なし）", "@kit/shared/src/logger", ResolveOK, ResolveKindWorkspace, "packages/shared/src/logger.ts"},
+		{"外部パッケージ", "next/link", ResolveExternal, "", ""},
+		{"node_modules を指すエイリアス", "react", ResolveExternal, "", ""},
+		{"組み込みモジュール", "node:fs", ResolveExternal, "", ""},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res := s.resolver.Resolve(from, tt.spec)
+			s.Equal(tt.status, res.Status)
+			s.Equal(tt.kind, res.Kind)
+			if tt.path != "" {
+				s.Equal(s.path(tt.path), res.Path)
+			}
+		})
+	}
+}
+
+func (s *ResolverTestSuite) TestResolveCaseMismatch() {
+	from := s.path("apps/web/app/page.tsx")
+
+	res := s.resolver.Resolve(from, "../components/button")
+	s.Equal(ResolveCaseMismatch, res.Status)
+	s.Equal(s.path("apps/web/components/Button.tsx"), res.Path)
+	s.Equal("../components/Button", res.Suggestion)
+
+	res = s.resolver.Resolve(from, "~/components/User-Card")
+	s.Equal(ResolveCaseMismatch, res.Status)
+	s.Equal("~/components/user-card", res.Suggestion)
+
+	res = s.resolver.Resolve(from, "@kit/ui/Page-Header")
+	s.Equal(ResolveCaseMismatch, res.Status)
+	s.Equal("@kit/ui/page-header", res.Suggestion)
+
+	// 大文字小文字が一致するファイルが存在する場合はそちらを優先する
+	s.Require().NoError(s.fs.WriteFile(s.path("apps/web/components/button.tsx"), nil, 0644))
+	resolver, err := NewResolver(s.fs, s.root)
+	s.Require().NoError(err)
+	res = resolver.Resolve(from, "../components/button")
+	s.Equal(ResolveOK, res.Status)
+	s.Equal(s.path("apps/web/components/button.tsx"), res.Path)
+}
+
+func (s *ResolverTestSuite) TestTSConfigFor() {
+	config := s.resolver.TSConfigFor(s.path("apps/web/components/user-card"))
+	s.Require().NotNil(config)
+	s.Equal(s.path("apps/web/tsconfig.json"), config.Path)
+	s.Equal(s.path("apps/web"), config.BaseURL)
+
+	// baseUrl がない場合、paths は tsconfig.json のディレクトリを基準にする
+	config = s.resolver.TSConfigFor(s.path("packages/ui/src/custom"))
+	s.Require().NotNil(config)
+	targets, capture, ok := config.MatchPaths("~/custom/page-header")
+	s.True(ok)
+	s.Equal("page-header", capture)
+	s.Equal([]string{s.path("packages/ui/src/custom/page-header")}, targets)
+
+	s.Nil(s.resolver.TSConfigFor(s.root))
+}
+
+func TestResolverSuite(t *testing.T) {
+	suite.Run(t, new(ResolverTestSuite))
+}
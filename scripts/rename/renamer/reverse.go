@@ -0,0 +1,207 @@
+package renamer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 指定子の書き方（元の指定子から引き継ぐ拡張子・index の省略・クエリ）
+type specifierForm struct {
+	// 指定子に書く拡張子（".js" など、省略している場合は空）
+	ext string
+	// ディレクトリの index を省略しているかどうか
+	dirIndex bool
+	// クエリ（"?raw" など）
+	query string
+}
+
+// 元の指定子と解決先のファイルから、指定子の書き方を求める
+func specifierFormOf(spec, target string) specifierForm {
+	var form specifierForm
+	if i := strings.IndexAny(spec, "?#"); i > 0 {
+		spec, form.query = spec[:i], spec[i:]
+	}
+	last := spec[strings.LastIndex(spec, "/")+1:]
+	base := filepath.Base(target)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	switch {
+	case stem == "index" && last != "index" && !strings.HasPrefix(last, "index."):
+		form.dirIndex = true
+	case strings.HasPrefix(strings.ToLower(last), strings.ToLower(stem)+"."):
+		form.ext = last[len(stem):]
+	}
+	return form
+}
+
+// 解決先のファイルを指定子の書き方で表した絶対パス（拡張子を補う前のパス）
+func (f specifierForm) modulePath(target string) string {
+	base := filepath.Base(target)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	if f.dirIndex && stem == "index" {
+		return filepath.Dir(target)
+	}
+	return strings.TrimSuffix(target, filepath.Ext(base)) + f.ext
+}
+
+// fromFile から modulePath を参照する相対パスの指定子
+func relativeSpecifier(fromFile, modulePath string) string {
+	rel, err := filepath.Rel(filepath.Dir(fromFile), modulePath)
+	if err != nil {
+		return ""
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		return "."
+	}
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return rel
+	}
+	return "./" + rel
+}
+
+// fromFile に適用される tsconfig の paths で modulePath を参照するエイリアスの指定子（具体的なパターンの順）
+func (r *Resolver) aliasSpecifiers(fromFile, modulePath string) []string {
+	config := r.TSConfigFor(filepath.Dir(fromFile))
+	if config == nil || config.Paths == nil {
+		return nil
+	}
+	type candidate struct {
+		spec   string
+		prefix int
+	}
+	var candidates []candidate
+	for key, targets := range config.Paths {
+		for _, target := range targets {
+			abs := filepath.Join(config.PathsBase, filepath.FromSlash(target))
+			prefix, suffix, wildcard := strings.Cut(abs, "*")
+			if !wildcard {
+				if abs == modulePath {
+					candidates = append(candidates, candidate{spec: key, prefix: len(abs)})
+				}
+				continue
+			}
+			if len(modulePath) <= len(prefix)+len(suffix) || !strings.HasPrefix(modulePath, prefix) || !strings.HasSuffix(modulePath, suffix) {
+				continue
+			}
+			capture := filepath.ToSlash(modulePath[len(prefix) : len(modulePath)-len(suffix)])
+			candidates = append(candidates, candidate{spec: strings.Replace(key, "*", capture, 1), prefix: len(prefix)})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].prefix != candidates[j].prefix {
+			return candidates[i].prefix > candidates[j].prefix
+		}
+		return candidates[i].spec < candidates[j].spec
+	})
+	specs := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		specs = append(specs, c.spec)
+	}
+	return specs
+}
+
+// target を含むワークスペースのパッケージ名で target を参照する指定子
+// exports がある場合は target を指すサブパス、ない場合はパッケージのディレクトリからの相対パスを使います
+// fromFile のワークスペースがパッケージに依存していない場合は、パッケージ名では参照できないものとします
+func (r *Resolver) packageSpecifiers(fromFile, target, modulePath string) []string {
+	ws := r.WorkspaceOf(target)
+	if ws == nil || ws.Name == "" {
+		return nil
+	}
+	if from := r.WorkspaceOf(fromFile); from != nil && from != ws && !from.DependsOn(ws.Name) {
+		return nil
+	}
+	subpathSpecifier := func(subpath string) string {
+		if subpath == "." {
+			return ws.Name
+		}
+		return ws.Name + "/" + strings.TrimPrefix(subpath, "./")
+	}
+
+	var specs []string
+	if ws.Exports == nil {
+		for _, entry := range []string{ws.Types, ws.Module, ws.Main} {
+			if entry == "" {
+				continue
+			}
+			if path, _, ok := r.ResolveFile(filepath.Join(ws.Path, filepath.FromSlash(entry))); ok && path == target {
+				specs = append(specs, ws.Name)
+				break
+			}
+		}
+		if rel, err := filepath.Rel(ws.Path, modulePath); err == nil && rel != "." {
+			specs = append(specs, ws.Name+"/"+filepath.ToSlash(rel))
+		}
+		return specs
+	}
+
+	keys := make([]string, 0, len(ws.Exports))
+	for key := range ws.Exports {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, exported := range ws.Exports[key] {
+			abs := filepath.Join(ws.Path, filepath.FromSlash(exported))
+			prefix, suffix, wildcard := strings.Cut(abs, "*")
+			if !wildcard {
+				if abs == target || abs == modulePath {
+					specs = append(specs, subpathSpecifier(key))
+				}
+				continue
+			}
+			for _, path := range []string{target, modulePath} {
+				if len(path) > len(prefix)+len(suffix) && strings.HasPrefix(path, prefix) && strings.HasSuffix(path, suffix) {
+					capture := filepath.ToSlash(path[len(prefix) : len(path)-len(suffix)])
+					specs = append(specs, subpathSpecifier(strings.Replace(key, "*", capture, 1)))
+					break
+				}
+			}
+		}
+	}
+	return specs
+}
+
+// specifierFor は fromFile から target を参照するモジュール指定子を、元の指定子の書き方（form）と解決方法（kind）を引き継いで作成します
+// 同じワークスペースの中では元の解決方法（相対パスまたはエイリアス）を優先し、ワークスペースをまたぐ場合はパッケージ名を優先します
+// 候補は実際に解決して target を指すものだけを使い、見つからない場合は相対パスの指定子と false を返します
+// ワークスペースをまたぐ場合は相対パスを使わず（パッケージの境界を越え、Next.js や TypeScript のプロジェクト参照で解決できなくなるため）、
+// パッケージ名とエイリアスで参照できなければ空文字列と false を返します
+func (r *Resolver) specifierFor(fromFile, target string, form specifierForm, kind string) (string, string, bool) {
+	modulePath := form.modulePath(target)
+	relative := func() []string { return []string{relativeSpecifier(fromFile, modulePath)} }
+	alias := func() []string { return r.aliasSpecifiers(fromFile, modulePath) }
+	pkg := func() []string { return r.packageSpecifiers(fromFile, target, modulePath) }
+
+	type source struct {
+		kind       string
+		candidates func() []string
+	}
+	crossWorkspace := r.WorkspaceOf(fromFile) != r.WorkspaceOf(target)
+	var order []source
+	switch {
+	case crossWorkspace:
+		order = []source{{ResolveKindWorkspace, pkg}, {ResolveKindAlias, alias}}
+	case kind == ResolveKindRelative:
+		order = []source{{ResolveKindRelative, relative}, {ResolveKindAlias, alias}, {ResolveKindWorkspace, pkg}}
+	default:
+		order = []source{{ResolveKindAlias, alias}, {ResolveKindRelative, relative}, {ResolveKindWorkspace, pkg}}
+	}
+
+	for _, s := range order {
+		for _, spec := range s.candidates() {
+			if spec == "" {
+				continue
+			}
+			res := r.Resolve(fromFile, spec)
+			if res.Status == ResolveOK && res.Path == target {
+				return spec + form.query, s.kind, true
+			}
+		}
+	}
+	if crossWorkspace {
+		return "", "", false
+	}
+	return relativeSpecifier(fromFile, modulePath) + form.query, ResolveKindRelative, false
+}
@@ -0,0 +1,64 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReverseTestSuite struct {
+	suite.Suite
+	fs       *MemFS
+	root     string
+	resolver *Resolver
+}
+
+func (s *ReverseTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	resolver, err := NewResolver(s.fs, s.root)
+	s.Require().NoError(err)
+	s.resolver = resolver
+}
+
+func (s *ReverseTestSuite) path(rel string) string {
+	return filepath.Join(s.root, filepath.FromSlash(rel))
+}
+
+func (s *ReverseTestSuite) TestSpecifierFormOf() {
+	s.Equal(specifierForm{}, specifierFormOf("./Button", s.path("apps/web/components/Button.tsx")))
+	s.Equal(specifierForm{ext: ".js"}, specifierFormOf("./Button.js", s.path("apps/web/components/Button.tsx")))
+	s.Equal(specifierForm{dirIndex: true}, specifierFormOf("./user-card", s.path("apps/web/components/user-card/index.ts")))
+	s.Equal(specifierForm{}, specifierFormOf("./user-card/index", s.path("apps/web/components/user-card/index.ts")))
+	s.Equal(specifierForm{query: "?raw"}, specifierFormOf("./utils?raw", s.path("apps/web/lib/utils.ts")))
+}
+
+func (s *ReverseTestSuite) TestSpecifierFor() {
+	page := s.path("apps/web/app/page.tsx")
+	for _, tc := range []struct {
+		target, kind string
+		form         specifierForm
+		expected     string
+		message      string
+	}{
+		{"apps/web/components/Button.tsx", ResolveKindAlias, specifierForm{}, "~/components/Button", "エイリアスを優先する"},
+		{"apps/web/components/Button.tsx", ResolveKindRelative, specifierForm{}, "../components/Button", "相対パスを引き継ぐ"},
+		{"apps/web/components/user-card/index.ts", ResolveKindAlias, specifierForm{dirIndex: true}, "~/components/user-card", "index を省略する"},
+		{"packages/ui/src/custom/page-header/index.tsx", ResolveKindRelative, specifierForm{dirIndex: true}, "@kit/ui/page-header", "ワークスペースをまたぐ場合は exports のサブパス"},
+		{"packages/ui/src/hooks/use-toast.ts", ResolveKindRelative, specifierForm{}, "@kit/ui/hooks/use-toast", "ワイルドカードの exports"},
+	} {
+		spec, _, ok := s.resolver.specifierFor(page, s.path(tc.target), tc.form, tc.kind)
+		s.True(ok, tc.message)
+		s.Equal(tc.expected, spec, tc.message)
+	}
+
+	spec, _, ok := s.resolver.specifierFor(page, s.path("packages/shared/src/logger.ts"), specifierForm{}, ResolveKindRelative)
+	s.False(ok)
+	s.Empty(spec, "依存していないパッケージはパッケージ名で参照せず、ワークスペースをまたぐ相対パスも作らない")
+}
+
+func TestReverseSuite(t *testing.T) {
+	suite.Run(t, new(ReverseTestSuite))
+}
@@ -0,0 +1,152 @@
+package renamer
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// from 句のモジュール指定子（from の直後の引用符で囲まれた部分）
+var fromSpecifierRegex = regexp.MustCompile(`(from\s+['"])([^'"]*)(['"])`)
+
+// ファイルごとのインポートパスの書き換え結果
+type rewriteResult struct {
+	// 書き換えたかどうか
+	changed bool
+	// 適用したリネーム（"変換前 -> 変換後"）
+	applied []string
+	err     error
+}
+
+// importRewriter はリネームしたファイルまたはディレクトリを指すモジュール指定子の最後の要素を、変換後の名前に書き換えます
+// 同じベース名のファイルが複数のディレクトリにある場合も、リネームしたものを指す指定子だけを書き換えるよう、
+// 書き換えた指定子をリネーム後のファイル構成で解決して確かめます
+type importRewriter struct {
+	resolver *Resolver
+	// 変換前のベース名 → そのベース名のリネーム
+	renames map[string][]ConversionResult
+}
+
+// リネームを実行した後のファイル構成で、results のリネームに対応する importRewriter を作成する
+func (e *Engine) newImportRewriter(results []ConversionResult) (*importRewriter, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+	renames := make(map[string][]ConversionResult, len(results))
+	for _, result := range results {
+		renames[result.OldBaseName] = append(renames[result.OldBaseName], result)
+	}
+	return &importRewriter{resolver: resolver, renames: renames}, nil
+}
+
+// file の中の指定子 specifier（最後の要素が name）がリネームしたファイルまたはディレクトリを指している場合は、変換後の名前を返す
+func (w *importRewriter) newName(file, specifier, name string) (string, bool) {
+	for _, rename := range w.renames[name] {
+		if rename.NewBaseName == name {
+			continue
+		}
+		resolved := w.resolver.Resolve(file, strings.TrimSuffix(specifier, name)+rename.NewBaseName)
+		if resolved.Status != ResolveOK {
+			continue
+		}
+		if resolved.Path == rename.NewPath || (rename.IsDir && strings.HasPrefix(resolved.Path, rename.NewPath+string(filepath.Separator))) {
+			return rename.NewBaseName, true
+		}
+	}
+	return "", false
+}
+
+// rewriteImports は files のインポートパスのうち、results でリネームしたファイルまたはディレクトリを指すものの最後の要素を
+// 変換後のベース名に書き換え、書き換えたファイルを files の順に返します
+// リネームを実行した後に呼び出してください（指定子はリネーム後のファイル構成で解決します）
+// 各ファイルは 1 回だけ読み込み、変更がある場合だけ 1 回書き込みます
+// ファイルは Options.RewriteWorkers を上限とするワーカーで並列に処理し、進捗とエラーは files の順に出力します
+func (e *Engine) rewriteImports(files []string, results []ConversionResult) []string {
+	rewriter, err := e.newImportRewriter(results)
+	if err != nil {
+		e.printf("ワークスペース設定の読み込みに失敗しました: %v\n", err)
+		return nil
+	}
+
+	rewrites := make([]rewriteResult, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(e.opts.RewriteWorkers, len(files)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				rewrites[i] = e.rewriteFile(files[i], rewriter)
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var updated []string
+	for i, result := range rewrites {
+		file := files[i]
+		if result.err != nil {
+			e.printf("%v\n", result.err)
+			continue
+		}
+		if !result.changed {
+			continue
+		}
+		e.printf("  ファイル %s 内のインポートパスを更新中 (%s)\n", filepath.Base(file), strings.Join(result.applied, ", "))
+		if e.opts.DryRun {
+			e.printf("  - インポートパスの更新予定: %s\n", file)
+		}
+		updated = append(updated, file)
+	}
+	return updated
+}
+
+// 1 ファイルのインポートパスを書き換える
+func (e *Engine) rewriteFile(file string, rewriter *importRewriter) rewriteResult {
+	content, err := e.fs.ReadFile(file)
+	if err != nil {
+		return rewriteResult{err: fmt.Errorf("ファイル読み込みエラー (%s): %w", file, err)}
+	}
+	newContent, applied := rewriteImportContent(string(content), func(specifier, name string) (string, bool) {
+		return rewriter.newName(file, specifier, name)
+	})
+	if len(applied) == 0 {
+		return rewriteResult{}
+	}
+	// ドライランの場合はオーバーレイに書き込まれる
+	if err := e.fs.WriteFile(file, []byte(newContent), 0644); err != nil {
+		return rewriteResult{err: fmt.Errorf("ファイル書き込みエラー (%s): %w", file, err)}
+	}
+	return rewriteResult{changed: true, applied: applied}
+}
+
+// from 句のモジュール指定子の最後の要素を newName が返す名前に書き換え、書き換えた内容と適用したリネームを返す
+func rewriteImportContent(content string, newName func(specifier, name string) (string, bool)) (string, []string) {
+	var applied []string
+	seen := make(map[string]bool)
+	newContent := fromSpecifierRegex.ReplaceAllStringFunc(content, func(match string) string {
+		parts := fromSpecifierRegex.FindStringSubmatch(match)
+		specifier := parts[2]
+		dir, name := "", specifier
+		if i := strings.LastIndex(specifier, "/"); i >= 0 {
+			dir, name = specifier[:i+1], specifier[i+1:]
+		}
+		renamed, ok := newName(specifier, name)
+		if !ok || renamed == name {
+			return match
+		}
+		if !seen[name] {
+			seen[name] = true
+			applied = append(applied, fmt.Sprintf("%s -> %s", name, renamed))
+		}
+		return parts[1] + dir + renamed + parts[3]
+	})
+	return newContent, applied
+}
@@ -0,0 +1,231 @@
+package renamer
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// 書き込みの回数を数える FS
+type countingFS struct {
+	FS
+	mu     sync.Mutex
+	writes map[string]int
+}
+
+func (c *countingFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	c.mu.Lock()
+	c.writes[name]++
+	c.mu.Unlock()
+	return c.FS.WriteFile(name, data, perm)
+}
+
+type RewriteTestSuite struct {
+	suite.Suite
+	root string
+}
+
+func (s *RewriteTestSuite) SetupTest() {
+	s.root = filepath.FromSlash("/repo")
+}
+
+// n 個のコンポーネントと、それぞれが複数のコンポーネントをインポートするページからなるツリーを作成する
+// コンポーネントはリネームした後の状態（foo-bar0.tsx など）で作成し、対応する変換結果を返す
+func syntheticTree(root string, components, pages int) (*MemFS, []string, []ConversionResult) {
+	fsys := NewMemFS()
+	var results []ConversionResult
+	for i := range components {
+		name := fmt.Sprintf("FooBar%d", i)
+		newName := fmt.Sprintf("foo-bar%d", i)
+		dir := filepath.Join(root, "components")
+		path := filepath.Join(dir, newName+".tsx")
+		fsys.MkdirAll(dir, 0755)
+		fsys.WriteFile(path, []byte(fmt.Sprintf("export const %s = () => null;\n", name)), 0644)
+		results = append(results, ConversionResult{OldPath: filepath.Join(dir, name+".tsx"), NewPath: path, OldBaseName: name, NewBaseName: newName})
+	}
+	var files []string
+	for i := range pages {
+		var b strings.Builder
+		b.WriteString("import React from 'react';\n")
+		for j := range 5 {
+			fmt.Fprintf(&b, "import { FooBar%[1]d } from '../../components/FooBar%[1]d';\n", (i*7+j)%components)
+		}
+		b.WriteString("import { helper } from './helper';\n\nexport default function Page() {\n  return null;\n}\n")
+		path := filepath.Join(root, "app", fmt.Sprintf("page%d", i), "page.tsx")
+		fsys.MkdirAll(filepath.Dir(path), 0755)
+		fsys.WriteFile(path, []byte(b.String()), 0644)
+		files = append(files, path)
+	}
+	return fsys, files, results
+}
+
+func (s *RewriteTestSuite) newEngine(fsys FS) *Engine {
+	engine, err := NewEngine(s.root, Options{FS: fsys, ConversionDirection: DirectionCamelToKebab})
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *RewriteTestSuite) TestRewriteImports() {
+	fsys, files, results := syntheticTree(s.root, 20, 30)
+	var before []string
+	for _, file := range files {
+		content, err := fsys.ReadFile(file)
+		s.Require().NoError(err)
+		before = append(before, string(content))
+	}
+
+	updated := s.newEngine(fsys).rewriteImports(files, results)
+	s.Equal(files, updated, "書き換えたファイルは入力の順に返す")
+
+	for i, file := range files {
+		got, err := fsys.ReadFile(file)
+		s.Require().NoError(err)
+		s.Equal(strings.ReplaceAll(before[i], "/FooBar", "/foo-bar"), string(got))
+	}
+}
+
+// ベンチマークで比べる従来の方法と同じ結果になる
+func (s *RewriteTestSuite) TestMatchesLegacyUpdateImportPaths() {
+	fsys, files, results := syntheticTree(s.root, 20, 30)
+	legacyFS, _, _ := syntheticTree(s.root, 20, 30)
+	s.newEngine(fsys).rewriteImports(files, results)
+	for _, result := range results {
+		for _, file := range files {
+			s.Require().NoError(legacyUpdateImportPaths(legacyFS, file, result.OldBaseName, result.NewBaseName))
+		}
+	}
+	for _, file := range files {
+		got, err := fsys.ReadFile(file)
+		s.Require().NoError(err)
+		want, err := legacyFS.ReadFile(file)
+		s.Require().NoError(err)
+		s.Equal(string(want), string(got), file)
+	}
+}
+
+func (s *RewriteTestSuite) TestWritesEachFileOnce() {
+	memFS, files, results := syntheticTree(s.root, 5, 10)
+	fsys := &countingFS{FS: memFS, writes: make(map[string]int)}
+	unchanged := filepath.Join(s.root, "app", "other.tsx")
+	s.Require().NoError(memFS.WriteFile(unchanged, []byte("import { x } from './x';\n"), 0644))
+
+	s.newEngine(fsys).rewriteImports(append(files, unchanged), results)
+	for _, file := range files {
+		s.Equal(1, fsys.writes[file], file)
+	}
+	s.Zero(fsys.writes[unchanged], "変更のないファイルは書き込まない")
+}
+
+// ワーカー数はエンジンごとのオプションで、数に関係なく同じ結果と出力になる
+func (s *RewriteTestSuite) TestRewriteWorkers() {
+	fsys, _, _ := syntheticTree(s.root, 1, 1)
+	s.Equal(runtime.NumCPU(), s.newEngine(fsys).Options().RewriteWorkers, "省略時は CPU 数")
+
+	var outputs []string
+	for _, workers := range []int{1, 8} {
+		fsys, files, results := syntheticTree(s.root, 10, 20)
+		var out bytes.Buffer
+		engine, err := NewEngine(s.root, Options{FS: fsys, ConversionDirection: DirectionCamelToKebab, RewriteWorkers: workers, Output: &out})
+		s.Require().NoError(err)
+		s.Equal(files, engine.rewriteImports(files, results))
+		outputs = append(outputs, out.String())
+	}
+	s.Equal(outputs[0], outputs[1])
+}
+
+func (s *RewriteTestSuite) TestRewriteImportContent() {
+	content, applied := rewriteImportContent(strings.Join([]string{
+		"import { Button } from '../common/Button';",
+		"import Button2 from \"@/components/Button\";",
+		"export * from './Button';",
+		"import { ButtonGroup } from './ButtonGroup';",
+		"import { Card } from './Card/Button/index';",
+	}, "\n"), func(specifier, name string) (string, bool) {
+		newName, ok := map[string]string{"Button": "button", "Card": "card"}[name]
+		return newName, ok
+	})
+
+	s.Equal(strings.Join([]string{
+		"import { Button } from '../common/button';",
+		"import Button2 from \"@/components/button\";",
+		"export * from './button';",
+		"import { ButtonGroup } from './ButtonGroup';",
+		"import { Card } from './Card/Button/index';",
+	}, "\n"), content)
+	s.Equal([]string{"Button -> button"}, applied)
+}
+
+func (s *RewriteTestSuite) TestOrderedOutput() {
+	fsys, files, results := syntheticTree(s.root, 3, 20)
+	var out bytes.Buffer
+	s.newEngine(fsys).WithOutput(&out).rewriteImports(files, results)
+
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		names = append(names, strings.Fields(line)[1])
+	}
+	s.Len(names, len(files))
+	for i, file := range files {
+		s.Equal(filepath.Base(file), names[i])
+	}
+}
+
+func TestRewriteSuite(t *testing.T) {
+	suite.Run(t, new(RewriteTestSuite))
+}
+
+// 変換対象 200 件、インポート元 1000 ファイルのツリー
+const (
+	benchComponents = 200
+	benchPages      = 1000
+)
+
+// 単一パスの書き換えを導入する前の方法（比較のためのベンチマーク専用）
+// リネーム 1 件ごとに正規表現を作成し、すべてのファイルを読み込んで書き換える
+func legacyUpdateImportPaths(fsys FS, file, oldName, newName string) error {
+	content, err := fsys.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	re := regexp.MustCompile(fmt.Sprintf(`(from\s+['"])([.]{1,2}/)?([^'"]*/)?%s(['"])`, regexp.QuoteMeta(oldName)))
+	newContent := re.ReplaceAllString(string(content), fmt.Sprintf("${1}${2}${3}%s${4}", newName))
+	if newContent == string(content) {
+		return nil
+	}
+	return fsys.WriteFile(file, []byte(newContent), 0644)
+}
+
+// リネーム 1 件ごとにすべてのファイルを読み書きする従来の方法（変換対象 × インポート元の回数だけ読み込む）
+func BenchmarkUpdateImportPaths(b *testing.B) {
+	root := filepath.FromSlash("/repo")
+	for range b.N {
+		b.StopTimer()
+		fsys, files, results := syntheticTree(root, benchComponents, benchPages)
+		b.StartTimer()
+		for _, result := range results {
+			for _, file := range files {
+				legacyUpdateImportPaths(fsys, file, result.OldBaseName, result.NewBaseName)
+			}
+		}
+	}
+}
+
+// 各ファイルを 1 回だけ読み込み、指定子を解決して並列に書き換える
+func BenchmarkRewriteImports(b *testing.B) {
+	root := filepath.FromSlash("/repo")
+	for range b.N {
+		b.StopTimer()
+		fsys, files, results := syntheticTree(root, benchComponents, benchPages)
+		engine, _ := NewEngine(root, Options{FS: fsys, ConversionDirection: DirectionCamelToKebab})
+		b.StartTimer()
+		engine.rewriteImports(files, results)
+	}
+}
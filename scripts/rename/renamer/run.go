@@ -0,0 +1,60 @@
+package renamer
+
+// Run は Execute による 1 回の実行の記録です
+type Run struct {
+	// 実行中に行った変更の記録
+	Journal *Journal
+	// ドライランの場合の変更後の状態（実際に変更した場合は nil）
+	Overlay *OverlayFS
+}
+
+// DryRun はドライランとして実行したかどうかを返します
+func (r *Run) DryRun() bool {
+	return r.Overlay != nil
+}
+
+// Execute は変更をジャーナルに記録するエンジンで fn を実行します
+// ドライランの場合は OverlayFS 上で実行するため、ファイルシステムは変更されません
+// fn がエラーを返した場合も、それまでの変更を記録した Run を返します
+func (e *Engine) Execute(command string, fn func(worker *Engine) error) (*Run, error) {
+	run := &Run{Journal: NewJournal(e.root, command)}
+	if _, ok := e.fs.(*GitFS); ok {
+		run.Journal.Git = true
+	}
+	fsys := e.fs
+	if e.opts.DryRun {
+		run.Overlay = NewOverlayFS(e.fs)
+		fsys = run.Overlay
+	}
+	err := fn(e.WithFS(NewJournalFS(fsys, run.Journal)))
+	if !run.DryRun() && len(run.Journal.Ops) > 0 {
+		e.InvalidateIndex()
+	}
+	return run, err
+}
+
+// Execute の中で変更を記録しているジャーナル（Execute の外では nil）
+func (e *Engine) journal() *Journal {
+	if fsys, ok := e.fs.(*JournalFS); ok {
+		return fsys.Journal()
+	}
+	return nil
+}
+
+// View は実行後の状態を参照するエンジンを返します
+// ドライランの場合は変更後の OverlayFS を、実際に変更した場合は同じファイルシステムを参照します
+func (e *Engine) View(run *Run) *Engine {
+	if run != nil && run.Overlay != nil {
+		return e.WithFS(run.Overlay)
+	}
+	return e
+}
+
+// Rollback は実行した変更を元に戻します（ドライランの場合は何もしません）
+func (e *Engine) Rollback(run *Run) error {
+	if run == nil || run.DryRun() {
+		return nil
+	}
+	e.InvalidateIndex()
+	return run.Journal.Rollback(e.fs)
+}
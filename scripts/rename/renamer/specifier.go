@@ -0,0 +1,130 @@
+package renamer
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ImportRef はソースファイル中のモジュール指定子（import / export from / import() / require()）です
+type ImportRef struct {
+	// モジュール指定子（引用符を除く）
+	Specifier string `json:"specifier"`
+	// 1 から始まる行番号
+	Line int `json:"line"`
+	// 指定子の先頭（引用符の内側）のバイトオフセット
+	Offset int `json:"offset"`
+	// import type / export type による型のみのインポートかどうか
+	TypeOnly bool `json:"typeOnly,omitempty"`
+	// import() または require() によるインポートかどうか
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+// End は指定子の末尾（引用符の内側）のバイトオフセットを返します
+func (r ImportRef) End() int {
+	return r.Offset + len(r.Specifier)
+}
+
+var (
+	// import ... from '...'、export ... from '...'、import '...'
+	staticImportRegex = regexp.MustCompile(`(?:^|[^\w$.])(?:import|export)(\s+type)?\s*(?:[\w$*{}\s,]*?\s*from\s*)?['"]([^'"\r\n]+)['"]`)
+	// import('...')、require('...')
+	dynamicImportRegex = regexp.MustCompile(`(?:^|[^\w$.])(?:import|require)\s*\(\s*['"]([^'"\r\n]+)['"]\s*\)`)
+)
+
+// インポートを解析する対象の拡張子
+var sourceExtensions = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs", ".mts", ".cts"}
+
+// IsSourceFile はインポートの解析対象となるソースファイルかどうかを返します
+func IsSourceFile(path string) bool {
+	return contains(sourceExtensions, filepath.Ext(path))
+}
+
+// ScanImports はソースコードからモジュール指定子を出現順に取り出します
+// コメントや文字列リテラルの中に書かれたインポートは無視します
+func ScanImports(content []byte) []ImportRef {
+	masked := maskNonCode(content)
+	lines := lineStarts(content)
+
+	var refs []ImportRef
+	for _, match := range staticImportRegex.FindAllSubmatchIndex(masked, -1) {
+		refs = append(refs, ImportRef{
+			Specifier: string(content[match[4]:match[5]]),
+			Line:      lineAt(lines, match[4]),
+			Offset:    match[4],
+			TypeOnly:  match[2] >= 0,
+		})
+	}
+	for _, match := range dynamicImportRegex.FindAllSubmatchIndex(masked, -1) {
+		refs = append(refs, ImportRef{
+			Specifier: string(content[match[2]:match[3]]),
+			Line:      lineAt(lines, match[2]),
+			Offset:    match[2],
+			Dynamic:   true,
+		})
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Offset < refs[j].Offset })
+	return refs
+}
+
+// コメントを空白に、文字列リテラルの中身を "x" に置き換える（改行とオフセットは維持する）
+// 引用符は残るため、指定子の位置は置き換え後の内容から求められる
+func maskNonCode(content []byte) []byte {
+	masked := append([]byte(nil), content...)
+	var quote byte
+	for i := 0; i < len(masked); i++ {
+		c := masked[i]
+		if quote != 0 {
+			switch {
+			case c == '\\' && i+1 < len(masked) && masked[i+1] != '\n':
+				masked[i], masked[i+1] = 'x', 'x'
+				i++
+			case c == quote || (c == '\n' && quote != '`'):
+				quote = 0
+			case c != '\n':
+				masked[i] = 'x'
+			}
+			continue
+		}
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '/' && i+1 < len(masked) && masked[i+1] == '/':
+			for ; i < len(masked) && masked[i] != '\n'; i++ {
+				masked[i] = ' '
+			}
+		case c == '/' && i+1 < len(masked) && masked[i+1] == '*':
+			start := i
+			end := strings.Index(string(masked[i+2:]), "*/")
+			if end < 0 {
+				i = len(masked)
+			} else {
+				i += end + 3
+			}
+			for j := start; j < i && j < len(masked); j++ {
+				if masked[j] != '\n' {
+					masked[j] = ' '
+				}
+			}
+			i--
+		}
+	}
+	return masked
+}
+
+// 各行の先頭のバイトオフセット
+func lineStarts(content []byte) []int {
+	starts := []int{0}
+	for i, c := range content {
+		if c == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// オフセットの行番号（1 始まり）
+func lineAt(starts []int, offset int) int {
+	return sort.Search(len(starts), func(i int) bool { return starts[i] > offset })
+}
@@ -0,0 +1,61 @@
+package renamer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanImports(t *testing.T) {
+	source := `import React from 'react';
+import type { Props } from "./types";
+import {
+  Button,
+  type ButtonProps,
+} from '~/components/Button';
+import './styles.css';
+export { default } from './UserCard';
+export * from "@kit/ui/page-header";
+// import { Old } from './old';
+/* import { Older } from './older'; */
+const Chart = dynamic(() => import('./Chart'));
+const config = require("../config");
+const label = 'import x from "./not-an-import"';
+`
+	refs := ScanImports([]byte(source))
+
+	var specs []string
+	for _, ref := range refs {
+		specs = append(specs, ref.Specifier)
+	}
+	assert.Equal(t, []string{
+		"react",
+		"./types",
+		"~/components/Button",
+		"./styles.css",
+		"./UserCard",
+		"@kit/ui/page-header",
+		"./Chart",
+		"../config",
+	}, specs)
+
+	assert.Equal(t, 1, refs[0].Line)
+	assert.True(t, refs[1].TypeOnly)
+	assert.False(t, refs[2].TypeOnly, "一部だけ type 指定のインポートは型のみではない")
+	assert.Equal(t, 6, refs[2].Line, "複数行のインポートは指定子の行を返す")
+	assert.True(t, refs[6].Dynamic)
+	assert.True(t, refs[7].Dynamic)
+
+	// オフセットは指定子そのものを指す
+	for _, ref := range refs {
+		assert.Equal(t, ref.Specifier, source[ref.Offset:ref.End()])
+	}
+}
+
+func TestIsSourceFile(t *testing.T) {
+	assert.True(t, IsSourceFile("app/page.tsx"))
+	assert.True(t, IsSourceFile("lib/utils.ts"))
+	assert.True(t, IsSourceFile("next.config.mjs"))
+	assert.False(t, IsSourceFile("styles/globals.css"))
+	assert.False(t, IsSourceFile("package.json"))
+}
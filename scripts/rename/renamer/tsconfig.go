@@ -0,0 +1,245 @@
+package renamer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// TSConfig は tsconfig.json のうちモジュール解決に関係する設定です（extends は展開済み）
+type TSConfig struct {
+	// tsconfig.json の絶対パス
+	Path string
+	// baseUrl の絶対パス（未指定の場合は空）
+	BaseURL string
+	// paths の解決先の基準ディレクトリ（baseUrl、未指定の場合は paths を定義した tsconfig のディレクトリ）
+	PathsBase string
+	// paths（エイリアス → 解決先の一覧）
+	Paths map[string][]string
+	// include（tsconfig のディレクトリからの相対パス）
+	Include []string
+}
+
+// tsconfig.json の生データ
+type rawTSConfig struct {
+	Extends         json.RawMessage `json:"extends"`
+	CompilerOptions struct {
+		BaseURL *string             `json:"baseUrl"`
+		Paths   map[string][]string `json:"paths"`
+	} `json:"compilerOptions"`
+	Include []string `json:"include"`
+}
+
+// LoadTSConfig は tsconfig.json を読み込み、extends を展開して返します
+// extends のパッケージ指定（"@kit/tsconfig/base.json" など）はワークスペースから解決します
+func LoadTSConfig(fsys FS, path string, workspaces []*Workspace) (*TSConfig, error) {
+	return loadTSConfig(fsys, path, workspaces, map[string]bool{})
+}
+
+func loadTSConfig(fsys FS, path string, workspaces []*Workspace, visiting map[string]bool) (*TSConfig, error) {
+	if visiting[path] {
+		return nil, fmt.Errorf("tsconfig の extends が循環しています: %s", path)
+	}
+	visiting[path] = true
+
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw rawTSConfig
+	if err := unmarshalJSONC(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s の解析に失敗しました: %w", path, err)
+	}
+
+	config := &TSConfig{Path: path}
+	dir := filepath.Dir(path)
+
+	// 継承元の設定を先に適用する
+	for _, parentPath := range tsconfigExtends(raw.Extends, dir, workspaces) {
+		parent, err := loadTSConfig(fsys, parentPath, workspaces, visiting)
+		if err != nil {
+			// 継承元が見つからない場合（node_modules 内の設定など）は無視する
+			if isNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if parent.BaseURL != "" {
+			config.BaseURL = parent.BaseURL
+		}
+		if parent.Paths != nil {
+			config.Paths = parent.Paths
+			config.PathsBase = parent.PathsBase
+		}
+		if parent.Include != nil {
+			config.Include = parent.Include
+		}
+	}
+
+	if raw.CompilerOptions.BaseURL != nil {
+		config.BaseURL = filepath.Join(dir, filepath.FromSlash(*raw.CompilerOptions.BaseURL))
+	}
+	if raw.CompilerOptions.Paths != nil {
+		config.Paths = raw.CompilerOptions.Paths
+		config.PathsBase = dir
+	}
+	if config.Paths != nil && config.BaseURL != "" {
+		config.PathsBase = config.BaseURL
+	}
+	if raw.Include != nil {
+		config.Include = raw.Include
+	}
+	return config, nil
+}
+
+// extends（文字列または配列）を tsconfig の絶対パスに変換する
+func tsconfigExtends(raw json.RawMessage, dir string, workspaces []*Workspace) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var specs []string
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		specs = []string{single}
+	} else if err := json.Unmarshal(raw, &specs); err != nil {
+		return nil
+	}
+
+	var paths []string
+	for _, spec := range specs {
+		var path string
+		if strings.HasPrefix(spec, ".") || filepath.IsAbs(spec) {
+			path = filepath.Join(dir, filepath.FromSlash(spec))
+		} else {
+			for _, ws := range workspaces {
+				if spec == ws.Name {
+					path = filepath.Join(ws.Path, "tsconfig.json")
+				} else if strings.HasPrefix(spec, ws.Name+"/") {
+					path = filepath.Join(ws.Path, filepath.FromSlash(strings.TrimPrefix(spec, ws.Name+"/")))
+				}
+			}
+		}
+		if path == "" {
+			continue
+		}
+		if !strings.HasSuffix(path, ".json") {
+			path += ".json"
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// MatchPaths は paths に一致するエイリアスの解決先（絶対パス）と、* に一致した部分を返します
+// TypeScript と同様に、完全一致を優先し、次に最も長い接頭辞を持つパターンを使用します
+func (c *TSConfig) MatchPaths(spec string) (targets []string, capture string, ok bool) {
+	if c == nil || c.Paths == nil {
+		return nil, "", false
+	}
+
+	key := ""
+	if _, exact := c.Paths[spec]; exact {
+		key = spec
+	} else {
+		bestPrefix := -1
+		for pattern := range c.Paths {
+			prefix, suffix, wildcard := strings.Cut(pattern, "*")
+			if !wildcard || len(spec) < len(prefix)+len(suffix) || !strings.HasPrefix(spec, prefix) || !strings.HasSuffix(spec, suffix) {
+				continue
+			}
+			if len(prefix) > bestPrefix {
+				key, bestPrefix = pattern, len(prefix)
+				capture = spec[len(prefix) : len(spec)-len(suffix)]
+			}
+		}
+		if key == "" {
+			return nil, "", false
+		}
+	}
+
+	for _, target := range c.Paths[key] {
+		target = strings.ReplaceAll(target, "*", capture)
+		targets = append(targets, filepath.Join(c.PathsBase, filepath.FromSlash(target)))
+	}
+	return targets, capture, true
+}
+
+// コメントと末尾のカンマを取り除いて JSON として解析する（tsconfig.json は JSONC 形式のため）
+func unmarshalJSONC(data []byte, v interface{}) error {
+	return json.Unmarshal(stripJSONC(data), v)
+}
+
+// JSONC のコメントと末尾のカンマを取り除く
+func stripJSONC(data []byte) []byte {
+	return stripTrailingCommas(stripJSONComments(data))
+}
+
+// 文字列の外にあるコメントを取り除く（行コメントの改行は残す）
+func stripJSONComments(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i+1 < len(data) && data[i+1] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// 文字列の外にある、閉じ括弧の直前のカンマを取り除く
+func stripTrailingCommas(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == ',':
+			j := i + 1
+			for j < len(data) && strings.ContainsRune(" \t\r\n", rune(data[j])) {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				continue
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package renamer
+
+import "io"
+
+// 変換方向
+const (
+	DirectionCamelToKebab = "camel-to-kebab"
+	DirectionKebabToCamel = "kebab-to-camel"
+)
+
+// エンジンのオプション
+type Options struct {
+	// 除外するファイル名パターン
+	ExcludePatterns []string
+	// 除外するインポートパスパターン
+	ExcludeImportPatterns []string
+	// 除外するディレクトリ一覧
+	ExcludeDirectories []string
+	// 変換方向: "camel-to-kebab" または "kebab-to-camel"
+	ConversionDirection string
+	// ドライラン（true: 実際に変更を行わない、変更予定のファイルだけ表示）
+	DryRun bool
+	// デバッグモード（true: 詳細情報を表示）
+	DebugMode bool
+	// ケバブケースへの変換時に略語の大文字を保持するかどうか（false: "FormUI" → "form-ui"）
+	PreserveAcronymCase bool
+	// 進捗メッセージの出力先（nil の場合は出力しない）
+	Output io.Writer
+	// 使用するファイルシステム（nil の場合は実際のファイルシステム）
+	FS FS
+	// パスの長さの上限（プロジェクトルートからの相対パスの文字数、0 の場合は DefaultMaxPathLength）
+	MaxPathLength int
+	// ファイル名の長さの上限（バイト数、0 の場合は DefaultMaxNameLength）
+	MaxNameLength int
+	// 移植性の問題を生じる変換計画の実行を拒否するかどうか
+	BlockUnportable bool
+	// 変換対象を限定するファイル（プロジェクトルートからの相対パス、スラッシュ区切り）
+	// nil の場合は限定しない。インポートパスの更新はこの限定に関係なくワークスペース全体に対して行う
+	OnlyFiles []string
+	// 索引のモジュール指定子を保存するキャッシュファイルのパス（空の場合は保存しない）
+	IndexCachePath string
+	// 未使用のモジュールの検出で、インポートされていなくても報告しないファイルのパターン
+	UnusedAllow []string
+	// check で循環しているインポートを検査するかどうか
+	CheckCycles bool
+	// 循環の検出で型のみのインポートを無視するかどうか
+	CyclesIgnoreTypeOnly bool
+	// apply と mv の後に更新するバレルの設定（nil の場合は更新しない）
+	Barrels *BarrelConfig
+	// インポートパスの書き換えを並列に行うワーカー数の上限（0 の場合は CPU 数）
+	RewriteWorkers int
+}
+
+// 変換結果
+type ConversionResult struct {
+	OldPath     string
+	NewPath     string
+	OldBaseName string
+	NewBaseName string
+	// ディレクトリ型コンポーネントかどうか
+	IsDir bool
+	// 処理ディレクトリ
+	TargetDir string
+	// 処理統計
+	TotalFiles     int
+	ProcessedFiles int
+	SkippedFiles   int
+	ErrorFiles     int
+	// インポートパス更新
+	ImportUpdateFiles []string
+	// リネームの後に更新したバレル（プロジェクトルートからの相対パス）
+	Barrels []string
+	// エラーの内容（"名前: 内容" の形式）
+	Errors []string
+}
+
+// 変換計画
+type Plan struct {
+	// 変換方向
+	ConversionDirection string
+	// ディレクトリごとの計画
+	Dirs []DirPlan
+}
+
+// ディレクトリごとの変換計画
+type DirPlan struct {
+	// 処理ディレクトリ（プロジェクトルートからの相対パス）
+	TargetDir string
+	// 予定しているリネーム（ファイル → ディレクトリ型コンポーネントの順）
+	Renames []ConversionResult
+	// 検出したファイル数
+	TotalFiles int
+	// スキップしたファイル数
+	SkippedFiles int
+}
+
+// 計画に含まれるリネームの総数を返す
+func (p *Plan) RenameCount() int {
+	count := 0
+	for _, dir := range p.Dirs {
+		count += len(dir.Renames)
+	}
+	return count
+}
+
+// プロジェクト構造
+type ProjectStructure struct {
+	RootType    string
+	Directories []string
+	FileStats   map[string]FileStatistics
+}
+
+// ファイル統計
+type FileStatistics struct {
+	CamelCaseCount int
+	KebabCaseCount int
+	TotalFiles     int
+	FilePaths      []string
+}
+
+// キャメルケースで命名されたファイルまたはディレクトリ
+type CamelCaseEntry struct {
+	Path     string
+	FileName string
+	IsDir    bool
+}
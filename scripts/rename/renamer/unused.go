@@ -0,0 +1,207 @@
+package renamer
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// UnusedModule はどのファイルからもインポートされていないモジュールです
+type UnusedModule struct {
+	// プロジェクトルートからの相対パス（スラッシュ区切り）
+	Path string `json:"path"`
+	// ファイルを含むワークスペースのディレクトリ（どのワークスペースにも含まれない場合は空）
+	Workspace string `json:"workspace,omitempty"`
+}
+
+// 未使用の検出の対象とする拡張子
+var unusedExtensions = []string{".ts", ".tsx", ".js", ".jsx"}
+
+// Next.js の app ディレクトリで、インポートされなくてもフレームワークが読み込むファイル（拡張子を除く）
+var nextAppEntries = []string{
+	"page", "layout", "route", "template", "default", "loading", "error", "global-error", "not-found",
+	"opengraph-image", "twitter-image", "icon", "apple-icon", "sitemap", "robots", "manifest",
+}
+
+// Next.js がディレクトリに関係なく読み込むファイル（拡張子を除く）
+var nextRootEntries = []string{"middleware", "instrumentation"}
+
+// ストーリーとテストのファイル名
+var storyOrTestRegex = regexp.MustCompile(`\.(stories|story|test|spec)\.[cm]?[jt]sx?$`)
+
+// FindUnused は dirs（プロジェクトルートからの相対パス）配下のモジュールのうち、
+// プロジェクト内のどのファイルからもインポートされていないものを返します
+// 依存には型のみのインポートと import() / require() を含み、次のファイルはインポートされていなくても使用中とみなします
+//   - Next.js のエントリ（アプリのワークスペースの app ディレクトリの page / layout / route など、pages ディレクトリのファイル、middleware）
+//   - ワークスペースの package.json の exports / main / types / module が指すファイル
+//   - Storybook のストーリーとテスト
+//   - Options.UnusedAllow のパターンに一致するファイル
+func (e *Engine) FindUnused(dirs []string) ([]UnusedModule, error) {
+	graph, err := e.BuildGraph()
+	if err != nil {
+		return nil, err
+	}
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+	exported := e.exportedFiles(resolver)
+	apps := e.nextApps(resolver)
+	workspaces := make(map[string]string)
+	for _, node := range graph.Nodes {
+		workspaces[node.Path] = node.Workspace
+	}
+
+	seen := make(map[string]bool)
+	var unused []UnusedModule
+	for _, dir := range dirs {
+		files, err := e.findSourceFiles(e.abs(dir))
+		if err != nil {
+			return nil, fmt.Errorf("%s のファイル一覧の取得に失敗しました: %w", dir, err)
+		}
+		for _, file := range files {
+			rel := e.Rel(file)
+			if seen[rel] {
+				continue
+			}
+			seen[rel] = true
+			if !isUnusedCandidate(rel) || exported[rel] || e.allowedUnused(rel) || isEntryFile(rel, apps) {
+				continue
+			}
+			if imported(graph, rel) {
+				continue
+			}
+			unused = append(unused, UnusedModule{Path: rel, Workspace: workspaces[rel]})
+		}
+	}
+	sort.Slice(unused, func(i, j int) bool { return unused[i].Path < unused[j].Path })
+	return unused, nil
+}
+
+// ほかのファイルからインポートされているかどうか
+func imported(graph *Graph, rel string) bool {
+	for _, i := range graph.importers[rel] {
+		if graph.Edges[i].From != rel {
+			return true
+		}
+	}
+	return false
+}
+
+// 未使用の検出の対象とするファイルかどうか（型定義ファイルは対象外）
+func isUnusedCandidate(rel string) bool {
+	return contains(unusedExtensions, filepath.Ext(rel)) && !strings.HasSuffix(rel, ".d.ts")
+}
+
+// インポートされなくても読み込まれるファイル（Next.js のエントリ・ストーリー・テスト）かどうか
+// pages と app は、Next.js のアプリのワークスペース（nextApps）の直下または src 直下にあるものだけをエントリのディレクトリとみなします
+func isEntryFile(rel string, nextApps []string) bool {
+	base := filepath.Base(rel)
+	if storyOrTestRegex.MatchString(base) {
+		return true
+	}
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+	if contains(nextRootEntries, name) {
+		return true
+	}
+	segments := strings.Split(rel, "/")
+	for _, segment := range segments[:len(segments)-1] {
+		if segment == "__tests__" || segment == "__mocks__" {
+			return true
+		}
+	}
+	for _, app := range nextApps {
+		sub := rel
+		if app != "." {
+			var ok bool
+			if sub, ok = strings.CutPrefix(rel, app+"/"); !ok {
+				continue
+			}
+		}
+		sub = strings.TrimPrefix(sub, "src/")
+		if strings.HasPrefix(sub, "pages/") || (strings.HasPrefix(sub, "app/") && contains(nextAppEntries, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next.js の設定ファイル
+var nextConfigFiles = []string{"next.config.js", "next.config.mjs", "next.config.cjs", "next.config.ts", "next.config.mts"}
+
+// Next.js のアプリのワークスペース（next に依存しているか、next.config があるもの）のディレクトリ（プロジェクトルートからの相対パス）
+func (e *Engine) nextApps(resolver *Resolver) []string {
+	var apps []string
+	for _, ws := range resolver.Workspaces() {
+		isApp := ws.Dependencies["next"] != ""
+		for _, config := range nextConfigFiles {
+			if !isApp && exists(e.fs, filepath.Join(ws.Path, config)) {
+				isApp = true
+			}
+		}
+		if isApp {
+			apps = append(apps, ws.Dir)
+		}
+	}
+	return apps
+}
+
+// 許可リストのパターンに一致するかどうか
+// パターンはファイル名またはプロジェクトルートからの相対パスに一致するもの（filepath.Match の形式）か、
+// "/" で終わるディレクトリです
+func (e *Engine) allowedUnused(rel string) bool {
+	for _, pattern := range e.opts.UnusedAllow {
+		if strings.HasSuffix(pattern, "/") && strings.HasPrefix(rel, pattern) {
+			return true
+		}
+		if matched, err := filepath.Match(pattern, filepath.Base(rel)); err == nil && matched {
+			return true
+		}
+		if matched, err := filepath.Match(pattern, rel); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ワークスペースの package.json の exports / main / types / module が指すファイル（プロジェクトルートからの相対パス）
+func (e *Engine) exportedFiles(resolver *Resolver) map[string]bool {
+	exported := make(map[string]bool)
+	add := func(ws *Workspace, target string) {
+		if target == "" {
+			return
+		}
+		if path, _, ok := resolver.ResolveFile(filepath.Join(ws.Path, filepath.FromSlash(target))); ok {
+			exported[e.Rel(path)] = true
+		}
+	}
+	for _, ws := range resolver.Workspaces() {
+		for _, entry := range []string{ws.Main, ws.Types, ws.Module} {
+			add(ws, entry)
+		}
+		for _, targets := range ws.Exports {
+			for _, target := range targets {
+				if !strings.Contains(target, "*") {
+					add(ws, target)
+					continue
+				}
+				// ワイルドカードの場合は一致するすべてのファイル
+				pattern := regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(strings.TrimPrefix(target, "./")), `\*`, ".+") + "$")
+				prefix := ws.Dir + "/"
+				if ws.Dir == "." {
+					prefix = ""
+				}
+				files, _ := e.findSourceFiles(ws.Path)
+				for _, file := range files {
+					rel := e.Rel(file)
+					if pattern.MatchString(strings.TrimPrefix(rel, prefix)) {
+						exported[rel] = true
+					}
+				}
+			}
+		}
+	}
+	return exported
+}
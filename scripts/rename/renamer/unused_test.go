@@ -0,0 +1,107 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UnusedTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *UnusedTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/app/page.tsx":                   "import { Button } from '~/components/Button';\nconst Chart = import('../components/chart');\n",
+		"apps/web/app/blog/[slug]/page.tsx":       "",
+		"apps/web/app/api/health/route.ts":        "",
+		"apps/web/app/_components/hero.tsx":       "",
+		"apps/web/components/Button.tsx":          "import type { Props } from './types';\n",
+		"apps/web/components/types.ts":            "",
+		"apps/web/components/chart.tsx":           "",
+		"apps/web/components/Orphan.tsx":          "",
+		"apps/web/components/Button.stories.tsx":  "import { Button } from './Button';\n",
+		"apps/web/components/Button.test.tsx":     "",
+		"apps/web/components/legacy/Old.tsx":      "",
+		"apps/web/components/global.d.ts":         "",
+		"apps/web/components/self.tsx":            "import { x } from './self';\n",
+		"apps/web/pages/about.tsx":                "",
+		"apps/web/middleware.ts":                  "",
+		"apps/web/next.config.mjs":                "",
+		"apps/web/components/pages/list.tsx":      "",
+		"packages/ui/src/pages/index.tsx":         "",
+		"packages/ui/src/app/page.tsx":            "",
+		"packages/ui/src/hooks/use-toast.ts":      "",
+		"packages/ui/src/hooks/use-unused.ts":     "",
+		"packages/ui/src/custom/internal.tsx":     "",
+		"packages/ui/src/custom/page-header/x.ts": "",
+	})
+}
+
+func (s *UnusedTestSuite) find(dirs []string, allow ...string) []string {
+	engine, err := NewEngine(s.root, Options{FS: s.fs, UnusedAllow: allow})
+	s.Require().NoError(err)
+	unused, err := engine.FindUnused(dirs)
+	s.Require().NoError(err)
+	var paths []string
+	for _, module := range unused {
+		paths = append(paths, module.Path)
+	}
+	return paths
+}
+
+func (s *UnusedTestSuite) TestFindUnused() {
+	s.Equal([]string{
+		"apps/web/app/_components/hero.tsx",
+		"apps/web/components/Orphan.tsx",
+		"apps/web/components/legacy/Old.tsx",
+		"apps/web/components/pages/list.tsx",
+		"apps/web/components/self.tsx",
+		"apps/web/components/user-card/index.ts",
+		"apps/web/lib/utils.ts",
+		"packages/ui/src/app/page.tsx",
+		"packages/ui/src/custom/internal.tsx",
+		"packages/ui/src/custom/page-header/x.ts",
+		"packages/ui/src/pages/index.tsx",
+	}, s.find([]string{"apps/web", "packages/ui/src"}),
+		"Next.js のエントリ・exports・ストーリー・テスト・型定義・動的インポートされたファイルは報告しない")
+}
+
+// pages と app は Next.js のアプリのワークスペース直下（または src 直下）のものだけをエントリとみなす
+func (s *UnusedTestSuite) TestIsEntryFile() {
+	apps := []string{"apps/web"}
+	s.True(isEntryFile("apps/web/pages/about.tsx", apps))
+	s.True(isEntryFile("apps/web/src/pages/blog/[slug].tsx", apps))
+	s.True(isEntryFile("apps/web/app/blog/page.tsx", apps))
+	s.True(isEntryFile("apps/web/src/app/layout.tsx", apps))
+	s.False(isEntryFile("apps/web/app/_components/hero.tsx", apps), "app の中でも Next.js が読み込まない名前はエントリではない")
+	s.False(isEntryFile("apps/web/components/pages/list.tsx", apps), "入れ子の pages はエントリではない")
+	s.False(isEntryFile("packages/ui/src/pages/index.tsx", apps), "アプリでないワークスペースの pages はエントリではない")
+	s.False(isEntryFile("packages/ui/src/app/page.tsx", apps))
+	s.True(isEntryFile("pages/index.tsx", []string{"."}), "ルートがアプリの場合")
+	s.True(isEntryFile("packages/ui/src/Button.test.tsx", apps))
+}
+
+func (s *UnusedTestSuite) TestWorkspace() {
+	engine, err := NewEngine(s.root, Options{FS: s.fs})
+	s.Require().NoError(err)
+	unused, err := engine.FindUnused([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	s.Equal(UnusedModule{Path: "apps/web/components/Orphan.tsx", Workspace: "apps/web"}, unused[0])
+}
+
+func (s *UnusedTestSuite) TestAllowlist() {
+	s.Equal([]string{"apps/web/components/self.tsx"},
+		s.find([]string{"apps/web/components"}, "Orphan.tsx", "apps/web/components/legacy/", "apps/web/components/pages/", "apps/web/components/*/index.ts"))
+	s.Empty(s.find([]string{"apps/web/components"}, "apps/web/components/"))
+}
+
+func TestUnusedSuite(t *testing.T) {
+	suite.Run(t, new(UnusedTestSuite))
+}
@@ -1,4 +1,4 @@
-package main
+package renamer
 
 import (
 	"path/filepath"
@@ -8,39 +8,42 @@ import (
 
 // 特殊な大文字略語のマップ（すべて大文字で定義）
 var upperCaseAcronyms = map[string]bool{
-	"API": true,
-	"FAQ": true,
-	"UI":  true,
-	"ID":  true,
-	"URL": true,
-	"SDK": true,
-	"CSS": true,
-	"HTML": true,
-	"HTTP": true,
+	"API":   true,
+	"FAQ":   true,
+	"UI":    true,
+	"ID":    true,
+	"URL":   true,
+	"SDK":   true,
+	"CSS":   true,
+	"HTML":  true,
+	"HTTP":  true,
 	"HTTPS": true,
-	"JSON": true,
-	"XML": true,
-	"JWT": true,
-	"SEO": true,
-	"DX": true,
-	"AI": true,
-	"CTA": true,
+	"JSON":  true,
+	"XML":   true,
+	"JWT":   true,
+	"SEO":   true,
+	"DX":    true,
+	"AI":    true,
+	"CTA":   true,
 }
 
-// ケバブケースへの変換時に略語を小文字にするかどうか
-var lowerCaseAcronymsInKebab = true
+// CamelToKebab はキャメルケースからケバブケースへ変換します（略語は小文字になります）
+func CamelToKebab(s string) string {
+	return camelToKebab(s, false)
+}
 
 // キャメルケースからケバブケースへの変換
-func camelToKebab(s string) string {
+// preserveAcronyms が true の場合、既知の略語は大文字のまま残す（例: "FormUI" → "form-UI"）
+func camelToKebab(s string, preserveAcronyms bool) string {
 	if s == "" {
 		return s
 	}
-	
+
 	// "Index" の特殊処理 - 単純に "index" に変換
 	if s == "Index" {
 		return "index"
 	}
-	
+
 	// 「先頭だけが大文字で残りが小文字」のパターンを処理
 	// 例えば "Header" -> "header", "Footer" -> "footer"
 	if len(s) > 1 && s[0] >= 'A' && s[0] <= 'Z' {
@@ -58,30 +61,30 @@ func camelToKebab(s string) string {
 
 	var result strings.Builder
 	i := 0
-	
+
 	// 連続する大文字を検出する関数
 	findAcronym := func(start int) (string, int) {
 		end := start
 		for end < len(s) && s[end] >= 'A' && s[end] <= 'Z' {
 			end++
 		}
-		
+
 		// 最後の文字が次の単語の一部（小文字が続く場合）であれば調整
 		if end > start+1 && end < len(s) && s[end] >= 'a' && s[end] <= 'z' {
 			end--
 		}
-		
+
 		return s[start:end], end
 	}
-	
+
 	// 先頭の略語または単語を処理
 	if s[0] >= 'A' && s[0] <= 'Z' {
 		acronym, nextPos := findAcronym(0)
-		
+
 		// 大文字の略語かどうかをチェック
 		if len(acronym) > 1 && upperCaseAcronyms[acronym] {
 			// 全体が略語の場合
-			result.WriteString(strings.ToLower(acronym))
+			result.WriteString(formatAcronym(acronym, preserveAcronyms))
 		} else {
 			// 通常の単語の場合は先頭を小文字に
 			result.WriteRune(rune(s[0] - 'A' + 'a'))
@@ -95,17 +98,17 @@ func camelToKebab(s string) string {
 		result.WriteRune(rune(s[0]))
 		i = 1
 	}
-	
+
 	// 残りの文字列を処理
 	for i < len(s) {
 		if s[i] >= 'A' && s[i] <= 'Z' {
 			// 大文字が出現したら新しい単語または略語の開始
 			acronym, nextPos := findAcronym(i)
-			
+
 			// 略語かどうかをチェック
 			if len(acronym) > 1 && upperCaseAcronyms[acronym] {
 				result.WriteRune('-')
-				result.WriteString(strings.ToLower(acronym))
+				result.WriteString(formatAcronym(acronym, preserveAcronyms))
 			} else {
 				// 通常の単語の場合
 				result.WriteRune('-')
@@ -121,12 +124,20 @@ func camelToKebab(s string) string {
 			i++
 		}
 	}
-	
+
 	return result.String()
 }
 
-// ケバブケースからキャメルケースへの変換
-func kebabToCamel(s string) string {
+// ケバブケースに含める略語を整形
+func formatAcronym(acronym string, preserve bool) string {
+	if preserve {
+		return acronym
+	}
+	return strings.ToLower(acronym)
+}
+
+// KebabToCamel はケバブケースからキャメルケースへ変換します
+func KebabToCamel(s string) string {
 	if s == "" {
 		return s
 	}
@@ -156,8 +167,8 @@ func kebabToCamel(s string) string {
 	return strings.Join(parts, "")
 }
 
-// 文字列がキャメルケースかどうかを確認
-func isCamelCase(s string) bool {
+// IsCamelCase は文字列がキャメルケースかどうかを確認します
+func IsCamelCase(s string) bool {
 	if s == "" {
 		return false
 	}
@@ -166,7 +177,7 @@ func isCamelCase(s string) bool {
 	if s == "Index" {
 		return true
 	}
-	
+
 	// 「先頭だけが大文字で残りが小文字」のパターンをチェック
 	// 例えば "Header", "Footer" なども変換対象にする
 	if len(s) > 1 && s[0] >= 'A' && s[0] <= 'Z' {
@@ -202,8 +213,8 @@ func isCamelCase(s string) bool {
 	return true
 }
 
-// 文字列がケバブケースかどうかを確認
-func isKebabCase(s string) bool {
+// IsKebabCase は文字列がケバブケースかどうかを確認します
+func IsKebabCase(s string) bool {
 	if s == "" {
 		return false
 	}
@@ -221,7 +232,7 @@ func isExcludedDirectory(dir string, excludePatterns []string) bool {
 		if err == nil && matched {
 			return true
 		}
-		
+
 		// パターンがパス全体にマッチするかをチェック
 		matched, err = filepath.Match(pattern, dir)
 		if err == nil && matched {
@@ -269,17 +280,17 @@ func isExcludedDir(name string) bool {
 		".git",
 		".next",
 	}
-	
+
 	for _, excluded := range baseExcludedDirs {
 		if name == excluded {
 			return true
 		}
 	}
-	
+
 	// 先頭がドットのディレクトリも除外
 	if strings.HasPrefix(name, ".") && name != "." {
 		return true
 	}
-	
+
 	return false
-} 
\ No newline at end of file
+}
@@ -1,4 +1,4 @@
-package main
+package renamer
 
 import (
 	"testing"
@@ -46,7 +46,7 @@ func TestIsCamelCase(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isCamelCase(tt.input)
+			result := IsCamelCase(tt.input)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -77,7 +77,7 @@ func TestIsKebabCase(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isKebabCase(tt.input)
+			result := IsKebabCase(tt.input)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -123,7 +123,7 @@ func TestConvertCamelToKebab(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := camelToKebab(tt.input)
+			result := CamelToKebab(tt.input)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -154,8 +154,8 @@ func TestConvertKebabToCamel(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := kebabToCamel(tt.input)
+			result := KebabToCamel(tt.input)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
-} 
\ No newline at end of file
+}
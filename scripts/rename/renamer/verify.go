@@ -0,0 +1,219 @@
+package renamer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ImportIssue は解決できない、または大文字小文字が一致しないモジュール指定子です
+type ImportIssue struct {
+	// ファイルのプロジェクトルートからの相対パス（スラッシュ区切り）
+	File string
+	// 1 から始まる行番号
+	Line int
+	// モジュール指定子
+	Specifier string
+	// ResolveUnresolved または ResolveCaseMismatch
+	Status ResolveStatus
+	// 大文字小文字を修正した指定子（修正できる場合のみ）
+	Suggestion string
+	// 指定子の先頭（引用符の内側）のバイトオフセット
+	Offset int
+}
+
+// String は "ファイル:行: 内容" の形式で問題を表します
+func (i ImportIssue) String() string {
+	if i.Status == ResolveCaseMismatch {
+		if i.Suggestion != "" {
+			return fmt.Sprintf("%s:%d: '%s' は大文字小文字が一致しません（正しくは '%s'）", i.File, i.Line, i.Specifier, i.Suggestion)
+		}
+		return fmt.Sprintf("%s:%d: '%s' は大文字小文字が一致しません", i.File, i.Line, i.Specifier)
+	}
+	return fmt.Sprintf("%s:%d: '%s' を解決できません", i.File, i.Line, i.Specifier)
+}
+
+// CheckImports は dirs（プロジェクトルートからの相対パス）配下のソースファイルに含まれる
+// モジュール指定子をすべて解決し、解決できないものと大文字小文字が一致しないものを返します
+func (e *Engine) CheckImports(dirs []string) ([]ImportIssue, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []ImportIssue
+	for _, dir := range dirs {
+		files, err := e.findSourceFiles(e.abs(dir))
+		if err != nil {
+			return nil, fmt.Errorf("%s のファイル一覧の取得に失敗しました: %w", dir, err)
+		}
+		for _, file := range files {
+			refs, err := e.Imports(file)
+			if err != nil {
+				return nil, fmt.Errorf("%s の読み込みに失敗しました: %w", e.Rel(file), err)
+			}
+			for _, ref := range refs {
+				res := resolver.Resolve(file, ref.Specifier)
+				if res.Status != ResolveUnresolved && res.Status != ResolveCaseMismatch {
+					continue
+				}
+				issues = append(issues, ImportIssue{
+					File:       e.Rel(file),
+					Line:       ref.Line,
+					Specifier:  ref.Specifier,
+					Status:     res.Status,
+					Suggestion: res.Suggestion,
+					Offset:     ref.Offset,
+				})
+			}
+		}
+	}
+	sortImportIssues(issues)
+	return issues, nil
+}
+
+// ソースファイル（.ts / .tsx / .js など）の一覧を取得する
+func (e *Engine) findSourceFiles(rootDir string) ([]string, error) {
+	var files []string
+	err := e.walkIndexed(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// 対象ディレクトリが存在しない場合は空とみなす
+			if path == rootDir && isNotExist(err) {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if info.IsDir() {
+			if path != rootDir && (isExcludedDir(info.Name()) || contains(e.opts.ExcludeDirectories, info.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if IsSourceFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// AffectedWorkspaces は paths（プロジェクトルートからの相対パス）を含むワークスペースと、
+// それらのパッケージに依存するワークスペースのディレクトリを返します
+// どのワークスペースにも含まれないパスは、そのパス（ファイルの場合は親ディレクトリ）を対象にします
+func (e *Engine) AffectedWorkspaces(paths []string) ([]string, error) {
+	resolver, err := NewResolver(e.fs, e.root)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := make(map[string]bool)
+	changed := make(map[string]bool)
+	for _, path := range paths {
+		abs := e.abs(filepath.FromSlash(path))
+		if ws := resolver.WorkspaceOf(abs); ws != nil {
+			scope[ws.Dir] = true
+			changed[ws.Name] = true
+			continue
+		}
+		if info, err := e.fs.Stat(abs); err == nil && !info.IsDir() {
+			abs = filepath.Dir(abs)
+		}
+		scope[e.Rel(abs)] = true
+	}
+	for _, ws := range resolver.Workspaces() {
+		for name := range changed {
+			if name != "" && ws.DependsOn(name) {
+				scope[ws.Dir] = true
+			}
+		}
+	}
+
+	dirs := make([]string, 0, len(scope))
+	for dir := range scope {
+		dirs = append(dirs, dir)
+	}
+	dirs = e.removeChildDirectories(dirs)
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// VerifyReport は実行後のインポート検証の結果です
+type VerifyReport struct {
+	// 検証したディレクトリ（プロジェクトルートからの相対パス）
+	Scope []string
+	// 実行後に残っているすべての問題
+	Issues []ImportIssue
+	// 実行によって新たに発生した問題
+	Introduced []ImportIssue
+}
+
+// OK は実行によって新たな問題が発生していないかどうかを返します
+func (r *VerifyReport) OK() bool {
+	return len(r.Introduced) == 0
+}
+
+// Verify は実行後の状態でインポートを検証し、実行前の状態と比較して実行によって新たに発生した問題を求めます
+// 検証範囲は scope（プロジェクトルートからの相対パス）に、実行で変更したパスを含むワークスペースを加えたものです
+// 実行前の状態はジャーナルを OverlayFS 上で巻き戻して再現するため、事前に検証しておく必要はありません
+func (e *Engine) Verify(run *Run, scope []string) (*VerifyReport, error) {
+	after := e.View(run)
+	var before *Engine
+	if run.DryRun() {
+		before = e
+	} else {
+		overlay := NewOverlayFS(e.fs)
+		if err := run.Journal.Rollback(overlay); err != nil {
+			return nil, fmt.Errorf("実行前の状態の再現に失敗しました: %w", err)
+		}
+		before = e.WithFS(overlay)
+	}
+
+	paths := append([]string(nil), run.Journal.ChangedPaths()...)
+	for _, dir := range scope {
+		paths = append(paths, run.Journal.MapPath(dir))
+	}
+	afterScope, err := after.AffectedWorkspaces(paths)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := after.CheckImports(afterScope)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := before.CheckImports(afterScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// 実行前から存在した問題は、ファイルの移動を反映したうえで除外する
+	known := make(map[string]bool)
+	for _, issue := range previous {
+		known[importIssueKey(run.Journal.MapPath(issue.File), issue)] = true
+	}
+	report := &VerifyReport{Scope: afterScope, Issues: issues}
+	for _, issue := range issues {
+		if !known[importIssueKey(issue.File, issue)] {
+			report.Introduced = append(report.Introduced, issue)
+		}
+	}
+	return report, nil
+}
+
+// 実行前後で問題を照合するためのキー（行番号は変わりうるため含めない）
+func importIssueKey(file string, issue ImportIssue) string {
+	return fmt.Sprintf("%s\x00%s\x00%s", file, issue.Specifier, issue.Status)
+}
+
+// ファイル・行・指定子の順に並べる
+func sortImportIssues(issues []ImportIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Specifier < issues[j].Specifier
+	})
+}
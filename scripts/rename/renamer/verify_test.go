@@ -0,0 +1,139 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type VerifyTestSuite struct {
+	suite.Suite
+	fs   *MemFS
+	root string
+}
+
+func (s *VerifyTestSuite) SetupTest() {
+	s.fs = NewMemFS()
+	s.root = filepath.FromSlash("/repo")
+	writeTestFiles(&s.Suite, s.fs, s.root, testMonorepoFiles)
+	writeTestFiles(&s.Suite, s.fs, s.root, map[string]string{
+		"apps/web/app/page.tsx": `import { Button } from '../components/Button';
+import { UserCard } from '~/components/user-card';
+import { PageHeader } from '@kit/ui/page-header';
+import { Missing } from './missing';
+`,
+		"apps/web/app/layout.tsx": `import { Button } from '~/components/button';
+`,
+		"packages/ui/src/custom/page-header/index.tsx": `import { cn } from '../../lib/utils';
+`,
+	})
+}
+
+func (s *VerifyTestSuite) newEngine(dryRun bool) *Engine {
+	engine, err := NewEngine(s.root, Options{FS: s.fs, DryRun: dryRun})
+	s.Require().NoError(err)
+	return engine
+}
+
+func (s *VerifyTestSuite) path(rel string) string {
+	return filepath.Join(s.root, filepath.FromSlash(rel))
+}
+
+func (s *VerifyTestSuite) TestCheckImports() {
+	issues, err := s.newEngine(false).CheckImports([]string{"apps/web", "packages/ui"})
+	s.Require().NoError(err)
+	s.Require().Len(issues, 2)
+
+	s.Equal("apps/web/app/layout.tsx", issues[0].File)
+	s.Equal(1, issues[0].Line)
+	s.Equal(ResolveCaseMismatch, issues[0].Status)
+	s.Equal("~/components/Button", issues[0].Suggestion)
+
+	s.Equal("apps/web/app/page.tsx", issues[1].File)
+	s.Equal(4, issues[1].Line)
+	s.Equal(ResolveUnresolved, issues[1].Status)
+	s.Equal("apps/web/app/page.tsx:4: './missing' を解決できません", issues[1].String())
+}
+
+func (s *VerifyTestSuite) TestAffectedWorkspaces() {
+	engine := s.newEngine(false)
+
+	// 変更したパッケージに依存するワークスペースも対象になる
+	dirs, err := engine.AffectedWorkspaces([]string{"packages/ui/src/custom/page-header"})
+	s.Require().NoError(err)
+	s.Equal([]string{"apps/web", "packages/ui"}, dirs)
+
+	// ワークスペース外のパスはそのディレクトリが対象になる
+	dirs, err = engine.AffectedWorkspaces([]string{"scripts/tool"})
+	s.Require().NoError(err)
+	s.Equal([]string{"scripts/tool"}, dirs)
+}
+
+func (s *VerifyTestSuite) TestVerifyDetectsIntroducedIssues() {
+	engine := s.newEngine(false)
+
+	// インポートを更新せずにリネームする
+	run, err := engine.Execute("test", func(worker *Engine) error {
+		return worker.FS().Rename(s.path("packages/ui/src/lib/utils.ts"), s.path("packages/ui/src/lib/cn.ts"))
+	})
+	s.Require().NoError(err)
+
+	report, err := engine.Verify(run, []string{"packages/ui"})
+	s.Require().NoError(err)
+	s.Equal([]string{"apps/web", "packages/ui"}, report.Scope)
+	s.Len(report.Issues, 3)
+	s.False(report.OK())
+	s.Require().Len(report.Introduced, 1)
+	s.Equal("packages/ui/src/custom/page-header/index.tsx", report.Introduced[0].File)
+	s.Equal("../../lib/utils", report.Introduced[0].Specifier)
+
+	// ジャーナルから元に戻せる
+	s.Require().NoError(engine.Rollback(run))
+	s.True(exists(s.fs, s.path("packages/ui/src/lib/utils.ts")))
+	s.False(exists(s.fs, s.path("packages/ui/src/lib/cn.ts")))
+}
+
+func (s *VerifyTestSuite) TestVerifyIgnoresExistingIssuesInMovedFiles() {
+	engine := s.newEngine(false)
+
+	// 既存の問題を含むファイルを移動しても、新たな問題とはみなさない
+	run, err := engine.Execute("test", func(worker *Engine) error {
+		return worker.FS().Rename(s.path("apps/web/app/layout.tsx"), s.path("apps/web/app/root-layout.tsx"))
+	})
+	s.Require().NoError(err)
+
+	report, err := engine.Verify(run, nil)
+	s.Require().NoError(err)
+	s.Len(report.Issues, 2)
+	s.True(report.OK())
+}
+
+func (s *VerifyTestSuite) TestVerifyDryRun() {
+	engine := s.newEngine(true)
+	engine.SetConversionDirection(DirectionCamelToKebab)
+	plan, err := engine.Plan([]string{"apps/web/components"})
+	s.Require().NoError(err)
+	s.Require().Equal(1, plan.RenameCount())
+
+	_, run, err := engine.ApplyRun(plan)
+	s.Require().NoError(err)
+	s.True(run.DryRun())
+
+	report, err := engine.Verify(run, []string{"apps/web/components"})
+	s.Require().NoError(err)
+	s.True(report.OK(), "インポートも更新されるため新たな問題は発生しない: %v", report.Introduced)
+
+	// 大文字小文字の不一致はリネームによって解消される
+	for _, issue := range report.Issues {
+		s.NotEqual(ResolveCaseMismatch, issue.Status)
+	}
+
+	// ドライランではファイルシステムは変更されない
+	s.True(exists(s.fs, s.path("apps/web/components/Button.tsx")))
+	s.Nil(engine.Rollback(run))
+}
+
+func TestVerifySuite(t *testing.T) {
+	suite.Run(t, new(VerifyTestSuite))
+}
@@ -0,0 +1,280 @@
+package renamer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Workspace はモノレポのワークスペース（package.json を持つパッケージ）です
+type Workspace struct {
+	// package.json の name
+	Name string
+	// プロジェクトルートからの相対パス（スラッシュ区切り）
+	Dir string
+	// 絶対パス
+	Path string
+	// package.json の main / types / module
+	Main   string
+	Types  string
+	Module string
+	// dependencies / devDependencies / peerDependencies をまとめたもの
+	Dependencies map[string]string
+	// exports のサブパスごとの解決先（exports がない場合は nil）
+	Exports map[string][]string
+}
+
+// package.json のうち使用するフィールド
+type packageJSON struct {
+	Name             string            `json:"name"`
+	Main             string            `json:"main"`
+	Types            string            `json:"types"`
+	Typings          string            `json:"typings"`
+	Module           string            `json:"module"`
+	Workspaces       json.RawMessage   `json:"workspaces"`
+	Exports          json.RawMessage   `json:"exports"`
+	Dependencies     map[string]string `json:"dependencies"`
+	DevDependencies  map[string]string `json:"devDependencies"`
+	PeerDependencies map[string]string `json:"peerDependencies"`
+}
+
+// LoadWorkspaces はルートの package.json の workspaces を展開してワークスペース一覧を返します
+// ワークスペースはディレクトリ順に並びます
+func LoadWorkspaces(fsys FS, root string) ([]*Workspace, error) {
+	data, err := fsys.ReadFile(filepath.Join(root, "package.json"))
+	if err != nil {
+		if isNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("package.json の読み込みに失敗しました: %w", err)
+	}
+	var manifest packageJSON
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("package.json の解析に失敗しました: %w", err)
+	}
+
+	patterns, err := workspacePatterns(manifest.Workspaces)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var workspaces []*Workspace
+	for _, pattern := range patterns {
+		for _, dir := range expandWorkspacePattern(fsys, root, pattern) {
+			if seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			ws, err := loadWorkspace(fsys, root, dir)
+			if err != nil {
+				return nil, err
+			}
+			if ws != nil {
+				workspaces = append(workspaces, ws)
+			}
+		}
+	}
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].Dir < workspaces[j].Dir })
+	return workspaces, nil
+}
+
+// workspaces フィールド（配列または { packages: [...] }）からパターンを取り出す
+func workspacePatterns(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var patterns []string
+	if err := json.Unmarshal(raw, &patterns); err == nil {
+		return patterns, nil
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, fmt.Errorf("package.json の workspaces の解析に失敗しました: %w", err)
+	}
+	return object.Packages, nil
+}
+
+// ワークスペースのパターン（"packages/*" など）に一致するディレクトリを返す
+func expandWorkspacePattern(fsys FS, root, pattern string) []string {
+	pattern = strings.TrimPrefix(strings.TrimSuffix(pattern, "/"), "./")
+	dirs := []string{""}
+	for _, segment := range strings.Split(pattern, "/") {
+		var next []string
+		for _, dir := range dirs {
+			if !strings.ContainsAny(segment, "*?[") {
+				candidate := joinSlash(dir, segment)
+				if info, err := fsys.Stat(filepath.Join(root, filepath.FromSlash(candidate))); err == nil && info.IsDir() {
+					next = append(next, candidate)
+				}
+				continue
+			}
+			entries, err := fsys.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				if !entry.IsDir() || isExcludedDir(entry.Name()) {
+					continue
+				}
+				if matched, _ := filepath.Match(segment, entry.Name()); matched {
+					next = append(next, joinSlash(dir, entry.Name()))
+				}
+			}
+		}
+		dirs = next
+	}
+	return dirs
+}
+
+// ディレクトリの package.json を読み込む（package.json がない場合は nil）
+func loadWorkspace(fsys FS, root, dir string) (*Workspace, error) {
+	path := filepath.Join(root, filepath.FromSlash(dir))
+	data, err := fsys.ReadFile(filepath.Join(path, "package.json"))
+	if err != nil {
+		if isNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s/package.json の読み込みに失敗しました: %w", dir, err)
+	}
+	var manifest packageJSON
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%s/package.json の解析に失敗しました: %w", dir, err)
+	}
+
+	ws := &Workspace{
+		Name:         manifest.Name,
+		Dir:          dir,
+		Path:         path,
+		Main:         manifest.Main,
+		Types:        manifest.Types,
+		Module:       manifest.Module,
+		Dependencies: make(map[string]string),
+	}
+	if ws.Types == "" {
+		ws.Types = manifest.Typings
+	}
+	for _, deps := range []map[string]string{manifest.PeerDependencies, manifest.DevDependencies, manifest.Dependencies} {
+		for name, version := range deps {
+			ws.Dependencies[name] = version
+		}
+	}
+	if len(manifest.Exports) > 0 && string(manifest.Exports) != "null" {
+		exports, err := parseExports(manifest.Exports)
+		if err != nil {
+			return nil, fmt.Errorf("%s/package.json の exports の解析に失敗しました: %w", dir, err)
+		}
+		ws.Exports = exports
+	}
+	return ws, nil
+}
+
+// 条件付きエクスポートで優先する条件
+var exportConditions = []string{"types", "import", "module", "default", "require", "node", "browser"}
+
+// exports フィールドをサブパスごとの解決先一覧に変換する
+func parseExports(raw json.RawMessage) (map[string][]string, error) {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+
+	exports := make(map[string][]string)
+	object, ok := value.(map[string]interface{})
+	isSubpathMap := ok && len(object) > 0
+	for key := range object {
+		if !strings.HasPrefix(key, ".") {
+			isSubpathMap = false
+			break
+		}
+	}
+	if !isSubpathMap {
+		// "exports": "./index.js" や条件のみのオブジェクトは "." と同じ扱い
+		exports["."] = exportTargets(value)
+		return exports, nil
+	}
+	for key, target := range object {
+		exports[key] = exportTargets(target)
+	}
+	return exports, nil
+}
+
+// exports の値から解決先のパスを優先順に取り出す
+func exportTargets(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var targets []string
+		for _, item := range v {
+			targets = append(targets, exportTargets(item)...)
+		}
+		return targets
+	case map[string]interface{}:
+		var targets []string
+		for _, condition := range exportConditions {
+			if item, ok := v[condition]; ok {
+				targets = append(targets, exportTargets(item)...)
+			}
+		}
+		var others []string
+		for condition := range v {
+			if !contains(exportConditions, condition) {
+				others = append(others, condition)
+			}
+		}
+		sort.Strings(others)
+		for _, condition := range others {
+			targets = append(targets, exportTargets(v[condition])...)
+		}
+		return targets
+	}
+	return nil
+}
+
+// MatchExport はサブパス（"." または "./xxx"）に一致する exports の解決先を返します
+// ワイルドカードを含むキーは * の部分を置き換えた解決先を返します
+func (ws *Workspace) MatchExport(subpath string) ([]string, bool) {
+	if targets, ok := ws.Exports[subpath]; ok {
+		return targets, true
+	}
+
+	// Node.js と同様に、最も長い接頭辞を持つキーを優先する
+	bestKey, bestCapture, bestPrefix := "", "", -1
+	for key := range ws.Exports {
+		prefix, suffix, ok := strings.Cut(key, "*")
+		if !ok || len(subpath) < len(prefix)+len(suffix) || !strings.HasPrefix(subpath, prefix) || !strings.HasSuffix(subpath, suffix) {
+			continue
+		}
+		if len(prefix) > bestPrefix {
+			bestKey, bestPrefix = key, len(prefix)
+			bestCapture = subpath[len(prefix) : len(subpath)-len(suffix)]
+		}
+	}
+	if bestKey == "" {
+		return nil, false
+	}
+	var targets []string
+	for _, target := range ws.Exports[bestKey] {
+		targets = append(targets, strings.ReplaceAll(target, "*", bestCapture))
+	}
+	return targets, true
+}
+
+// DependsOn はワークスペースが指定したパッケージに依存しているかどうかを返します
+func (ws *Workspace) DependsOn(name string) bool {
+	_, ok := ws.Dependencies[name]
+	return ok
+}
+
+// スラッシュ区切りのパスを結合する（空のディレクトリはルートを表す）
+func joinSlash(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"rename-script/renamer"
+)
+
+// レポートの出力形式のオプション（analyze / plan / apply / check）
+type reportFlags struct {
+	format string
+	// レポートの出力先（text 以外の形式では、通常の出力を標準エラー出力に切り替える）
+	stdout *os.File
+}
+
+// 出力形式のオプションを登録
+func (r *reportFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&r.format, "format", renamer.FormatText,
+		fmt.Sprintf("出力形式（%s）", strings.Join(renamer.ReportFormats, " / ")))
+}
+
+// 出力形式を検証し、text 以外の形式では標準出力をレポート専用にする
+// 通常のメッセージは標準エラー出力に表示されるため、パイプやリダイレクトでレポートだけを取り出せる
+func (r *reportFlags) begin() error {
+	if !slices.Contains(renamer.ReportFormats, r.format) {
+		return fmt.Errorf("対応していない出力形式です: %s（%s）", r.format, strings.Join(renamer.ReportFormats, " / "))
+	}
+	if r.enabled() {
+		r.stdout = redirectStdout()
+	}
+	return nil
+}
+
+// 通常のメッセージの出力先を標準エラー出力に切り替え、元の標準出力を返す
+func redirectStdout() *os.File {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	return stdout
+}
+
+// text 以外の形式が指定されているかどうか
+func (r *reportFlags) enabled() bool {
+	return r.format != renamer.FormatText
+}
+
+// レポートを標準出力に出力する（text の場合は何もしない）
+func (r *reportFlags) write(report *renamer.Report) error {
+	if !r.enabled() {
+		return nil
+	}
+	if err := renamer.WriteReport(r.stdout, r.format, report); err != nil {
+		return fmt.Errorf("レポートの出力に失敗しました: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"rename-script/renamer"
+)
+
+// rewrite-imports サブコマンド
+// 書き換えの規則ファイルに従って、ファイルを移動せずにモジュール指定子を書き換える
+func runRewriteImports(args []string) int {
+	fs := flag.NewFlagSet("rewrite-imports", flag.ExitOnError)
+	var flags commonFlags
+	fs.BoolVar(&flags.debug, "debug", false, "デバッグモードを有効にする（詳細な情報を表示）")
+	fs.StringVar(&flags.dirs, "dirs", "", "対象ディレクトリ（カンマ区切り、省略時はプロジェクト全体）")
+	flags.registerFilter(fs)
+	dryRun := fs.Bool("dry-run", false, "ドライラン（ファイルを変更せず、変更予定のみ表示）")
+	diff := fs.Bool("diff", false, "変更予定を git apply で適用できる unified diff で出力する（--dry-run を含む）")
+	force := fs.Bool("force", false, "コミットされていない変更があっても実行する")
+	noVerify := fs.Bool("no-verify", false, "実行後のインポートの検証を行わない")
+	rollback := fs.Bool("rollback", false, "検証で解決できないインポートが見つかった場合に確認せずに元に戻す")
+	var report reportFlags
+	report.register(fs)
+
+	positional := parseArgs(fs, args)
+	if len(positional) != 1 {
+		fmt.Println("使い方: rename-script rewrite-imports <規則ファイル> [オプション]")
+		return 1
+	}
+	if *diff && report.enabled() {
+		fmt.Println("--diff と --format は同時に指定できません")
+		return 1
+	}
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	var diffOutput *os.File
+	if *diff {
+		*dryRun = true
+		diffOutput = redirectStdout()
+	}
+
+	engine, err := newEngine(flags.debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	mappingPath, err := filepath.Abs(positional[0])
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	mapping, err := renamer.LoadImportMapping(engine.FS(), mappingPath)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if err := flags.applyFilter(engine); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if !*dryRun {
+		if err := checkWorkingTree(engine, *force); err != nil {
+			fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+			return 1
+		}
+	}
+
+	fmt.Printf("書き換えの規則: %d 件（%s）\n", len(mapping.Rules), engine.Rel(mappingPath))
+	if flags.debug {
+		for _, rule := range mapping.Rules {
+			fmt.Printf("  %s\n", rule)
+		}
+	}
+
+	engine.SetDryRun(*dryRun)
+	result, run, err := engine.RewriteSpecifiers(mapping, splitDirs(flags.dirs))
+	journalPath := saveJournal(engine, run)
+	if err != nil {
+		fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
+		return 1
+	}
+	if *diff && writeDiff(diffOutput, engine, run) != nil {
+		return 1
+	}
+	if err := report.write(engine.NewRemapReport(result)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	if *dryRun {
+		fmt.Printf("\n書き換える予定の指定子: %d 件（%d ファイルを検査、ドライランのため、ファイルは変更されていません）\n", len(result.Rewrites), result.Files)
+	} else {
+		fmt.Printf("\n%s書き換えた指定子: %d 件（%d ファイルを検査）%s\n", colorGreen, len(result.Rewrites), result.Files, colorReset)
+	}
+	for _, rewrite := range result.Rewrites {
+		fmt.Printf("  %s\n", rewrite)
+		if flags.debug {
+			fmt.Printf("      （規則: %s）\n", rewrite.Rule)
+		}
+	}
+	if len(result.UnusedRules) > 0 {
+		fmt.Printf("\n%sどの指定子にも一致しなかった規則: %d 件%s\n", colorYellow, len(result.UnusedRules), colorReset)
+		for _, rule := range result.UnusedRules {
+			fmt.Printf("  %s\n", rule)
+		}
+	}
+
+	if *noVerify || len(result.Rewrites) == 0 {
+		return 0
+	}
+	mode := rollbackPrompt
+	if *rollback {
+		mode = rollbackAuto
+	}
+	return verifyRun(engine, run, nil, journalPath, mode, flags.debug)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+// unused サブコマンド
+// 未使用のモジュールがある場合は終了コード 1 を返す
+func runUnused(args []string) int {
+	fs := flag.NewFlagSet("unused", flag.ExitOnError)
+	var flags commonFlags
+	flags.register(fs)
+	allow := fs.String("allow", "", "インポートされていなくても報告しないファイルのパターン（カンマ区切り、除外設定の unused.allow に追加）")
+	var report reportFlags
+	report.register(fs)
+	fs.Parse(args)
+	if err := report.begin(); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	engine, err := newEngine(flags.debug)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if !flags.debug {
+		engine = engine.WithOutput(io.Discard)
+	}
+	engine.AllowUnused(splitDirs(*allow)...)
+
+	dirs, err := flags.targetDirs(engine)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	unused, err := engine.FindUnused(dirs)
+	if err != nil {
+		fmt.Printf("未使用のモジュールの検出に失敗しました: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("\n=== 未使用のモジュール ===\n")
+	fmt.Printf("検査したディレクトリ: %d\n", len(dirs))
+	if flags.debug {
+		for _, dir := range dirs {
+			fmt.Printf("  %s\n", dir)
+		}
+	}
+	if len(unused) == 0 {
+		fmt.Printf("%s未使用のモジュールはありません%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("\n%sどのファイルからもインポートされていないモジュール: %d 件%s\n", colorYellow, len(unused), colorReset)
+		for _, module := range unused {
+			fmt.Printf("  %s\n", module.Path)
+		}
+		fmt.Println("\n意図して残しているファイルは、除外設定の unused.allow または --allow で除外できます。")
+	}
+
+	if err := report.write(engine.NewUnusedReport(unused)); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	if len(unused) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/manifoldco/promptui"
+
+	"rename-script/renamer"
+)
+
+// 検証で新たな問題が見つかった場合のロールバック方法
+type rollbackMode int
+
+const (
+	// 端末から実行している場合は確認し、それ以外は undo コマンドを案内する
+	rollbackPrompt rollbackMode = iota
+	// 確認せずにロールバックする
+	rollbackAuto
+	// ロールバックせず undo コマンドを案内する
+	rollbackNever
+)
+
+// 実行の記録をジャーナルとして保存し、保存先を返す（ドライランまたは変更がない場合は空）
+func saveJournal(engine *renamer.Engine, run *renamer.Run) string {
+	if run == nil || run.DryRun() || run.Journal.Empty() {
+		return ""
+	}
+	path, err := run.Journal.Save(engine.FS(), renamer.JournalDir(engine.Root()))
+	if err != nil {
+		fmt.Printf("%s警告: %v%s\n", colorYellow, err, colorReset)
+		return ""
+	}
+	fmt.Printf("\n変更内容をジャーナルに記録しました: %s\n", engine.Rel(path))
+	fmt.Println("  rename-script undo で元に戻せます。")
+	return path
+}
+
+// 実行後にすべてのインポートが解決できるか検証し、終了コードを返す
+// 実行によって新たに解決できなくなったインポートがある場合は、ジャーナルを使ったロールバックを提案する
+func verifyRun(engine *renamer.Engine, run *renamer.Run, scope []string, journalPath string, mode rollbackMode, debugMode bool) int {
+	fmt.Println("\n=== インポートの検証 ===")
+	report, err := engine.Verify(run, scope)
+	if err != nil {
+		fmt.Printf("%sインポートの検証に失敗しました: %v%s\n", colorRed, err, colorReset)
+		return 1
+	}
+
+	fmt.Printf("検証したワークスペース: %d\n", len(report.Scope))
+	if debugMode {
+		for _, dir := range report.Scope {
+			fmt.Printf("  %s\n", dir)
+		}
+	}
+
+	existing := len(report.Issues) - len(report.Introduced)
+	if existing > 0 {
+		fmt.Printf("%s実行前から解決できないインポート: %d 件%s\n", colorYellow, existing, colorReset)
+		if debugMode {
+			introduced := make(map[string]bool)
+			for _, issue := range report.Introduced {
+				introduced[issue.String()] = true
+			}
+			for _, issue := range report.Issues {
+				if !introduced[issue.String()] {
+					fmt.Printf("  %s\n", issue)
+				}
+			}
+		}
+	}
+
+	if report.OK() {
+		fmt.Printf("%s今回の実行で解決できなくなったインポートはありません%s\n", colorGreen, colorReset)
+		return 0
+	}
+
+	fmt.Printf("%s今回の実行で解決できなくなったインポート: %d 件%s\n", colorRed, len(report.Introduced), colorReset)
+	for _, issue := range report.Introduced {
+		fmt.Printf("  %s\n", issue)
+	}
+
+	if run.DryRun() {
+		fmt.Println("ドライランのため、ファイルは変更されていません。")
+		return 1
+	}
+
+	if mode == rollbackPrompt && !isTerminal() {
+		mode = rollbackNever
+	}
+	if mode == rollbackPrompt {
+		prompt := promptui.Select{
+			Label: "変更を元に戻しますか？",
+			Items: []string{"元に戻す", "このままにする"},
+		}
+		_, choice, err := prompt.Run()
+		if err == nil && choice == "元に戻す" {
+			mode = rollbackAuto
+		} else {
+			mode = rollbackNever
+		}
+	}
+	if mode == rollbackNever {
+		fmt.Println("変更を元に戻すには rename-script undo を実行してください。")
+		return 1
+	}
+
+	if err := rollbackRun(engine, run, journalPath); err != nil {
+		fmt.Printf("%sロールバックに失敗しました: %v%s\n", colorRed, err, colorReset)
+	}
+	return 1
+}
+
+// 実行した変更を元に戻し、ジャーナルに記録する
+func rollbackRun(engine *renamer.Engine, run *renamer.Run, journalPath string) error {
+	if err := engine.Rollback(run); err != nil {
+		return err
+	}
+	run.Journal.MarkUndone()
+	if journalPath != "" {
+		if _, err := run.Journal.Save(engine.FS(), filepath.Dir(journalPath)); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%s変更を元に戻しました（%d 件の操作）%s\n", colorGreen, len(run.Journal.Ops), colorReset)
+	return nil
+}
+
+// 標準入力と標準出力がどちらも端末かどうか
+func isTerminal() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}